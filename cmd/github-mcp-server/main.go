@@ -53,6 +53,8 @@ var (
 				ExportTranslations:   viper.GetBool("export-translations"),
 				EnableCommandLogging: viper.GetBool("enable-command-logging"),
 				LogFilePath:          viper.GetString("log-file"),
+				OutputFormat:         viper.GetString("output_format"),
+				MaxResponseSize:      viper.GetInt("max_response_size"),
 			}
 
 			return ghmcp.RunStdioServer(stdioServerConfig)
@@ -73,6 +75,8 @@ func init() {
 	rootCmd.PersistentFlags().Bool("enable-command-logging", false, "When enabled, the server will log all command requests and responses to the log file")
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
+	rootCmd.PersistentFlags().String("output-format", github.OutputFormatJSON, "Default format of tool results that support it, json or markdown")
+	rootCmd.PersistentFlags().Int("max-response-size", github.DefaultMaxResponseSize, "Maximum size in bytes of a tool result before it is truncated, or 0 for no limit")
 
 	// Bind flag to viper
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
@@ -82,6 +86,8 @@ func init() {
 	_ = viper.BindPFlag("enable-command-logging", rootCmd.PersistentFlags().Lookup("enable-command-logging"))
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
+	_ = viper.BindPFlag("output_format", rootCmd.PersistentFlags().Lookup("output-format"))
+	_ = viper.BindPFlag("max_response_size", rootCmd.PersistentFlags().Lookup("max-response-size"))
 
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
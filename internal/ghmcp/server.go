@@ -43,18 +43,51 @@ type MCPServerConfig struct {
 	// ReadOnly indicates if we should only offer read-only tools
 	ReadOnly bool
 
+	// OutputFormat is the format of tool results for calls that don't choose one, json or markdown
+	OutputFormat string
+
+	// MaxResponseSize is the largest tool result, in bytes, before results are truncated. 0 means no limit.
+	MaxResponseSize int
+
 	// Translator provides translated text for the server tooling
 	Translator translations.TranslationHelperFunc
 }
 
 func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
+	s, err := newMCPServer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return s.MCPServer, nil
+}
+
+// mcpServer is the MCP server together with the support for the requests mcp-go does not route
+// itself, which the transport has to pass on.
+type mcpServer struct {
+	*server.MCPServer
+	subscriptions *github.ResourceSubscriptions
+	completions   *github.Completions
+}
+
+func newMCPServer(cfg MCPServerConfig) (*mcpServer, error) {
 	apiHost, err := parseAPIHost(cfg.Host)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse API host: %w", err)
 	}
 
+	outputFormat := cfg.OutputFormat
+	if outputFormat == "" {
+		outputFormat = github.OutputFormatJSON
+	}
+	if err := github.ValidateOutputFormat(outputFormat); err != nil {
+		return nil, err
+	}
+
 	// Construct our REST client
-	restClient := gogithub.NewClient(nil).WithAuthToken(cfg.Token)
+	// Requests go through a WriteTrackingTransport so cancelled tool calls can say what they had already changed
+	restClient := gogithub.NewClient(&http.Client{
+		Transport: &github.WriteTrackingTransport{Transport: http.DefaultTransport},
+	}).WithAuthToken(cfg.Token)
 	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
 	restClient.BaseURL = apiHost.baseRESTURL
 	restClient.UploadURL = apiHost.uploadURL
@@ -64,7 +97,7 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	// did the necessary API host parsing so that github.com will return the correct URL anyway.
 	gqlHTTPClient := &http.Client{
 		Transport: &bearerAuthTransport{
-			transport: http.DefaultTransport,
+			transport: &github.WriteTrackingTransport{Transport: http.DefaultTransport},
 			token:     cfg.Token,
 		},
 	} // We're going to wrap the Transport later in beforeInit
@@ -87,8 +120,16 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		}
 	}
 
+	// Drop what is kept for a session when it ends, so its subscriptions stop being polled
+	s := &mcpServer{}
+	afterUnregister := func(_ context.Context, session server.ClientSession) {
+		s.subscriptions.UnsubscribeAll(session.SessionID())
+		s.completions.ForgetSession(session.SessionID())
+	}
+
 	hooks := &server.Hooks{
-		OnBeforeInitialize: []server.OnBeforeInitializeFunc{beforeInit},
+		OnBeforeInitialize:  []server.OnBeforeInitializeFunc{beforeInit},
+		OnUnregisterSession: []server.OnUnregisterSessionHookFunc{afterUnregister},
 	}
 
 	ghServer := github.NewServer(cfg.Version, server.WithHooks(hooks))
@@ -130,13 +171,29 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	// Register the tools with the server
 	toolsets.RegisterTools(ghServer)
 	context.RegisterTools(ghServer)
+	toolsets.RegisterPrompts(ghServer)
+
+	// Render results as markdown after they are shaped, so markdown results are trimmed the same way
+	renderer := github.NewMarkdownRenderer(outputFormat)
+	server.WithToolHandlerMiddleware(renderer.Middleware)(ghServer)
+
+	// Shape results once the tools are known, so the shaper can tell which of them take a page
+	shaper := github.NewResponseShaper(cfg.MaxResponseSize, toolsets)
+	server.WithToolHandlerMiddleware(shaper.Middleware)(ghServer)
+
+	// Gather and continue pages innermost, so the shaper sees every page gathered for a call
+	server.WithToolHandlerMiddleware(github.PaginationMiddleware)(ghServer)
 
 	if cfg.DynamicToolsets {
 		dynamic := github.InitDynamicToolset(ghServer, toolsets, cfg.Translator)
 		dynamic.RegisterTools(ghServer)
 	}
 
-	return ghServer, nil
+	s.MCPServer = ghServer
+	s.subscriptions = github.NewResourceSubscriptions(ghServer, getClient, github.DefaultMaxSubscriptionsPerSession)
+	s.completions = github.NewCompletions(ghServer, getClient, toolsets)
+
+	return s, nil
 }
 
 type StdioServerConfig struct {
@@ -160,6 +217,12 @@ type StdioServerConfig struct {
 	// ReadOnly indicates if we should only register read-only tools
 	ReadOnly bool
 
+	// OutputFormat is the format of tool results for calls that don't choose one, json or markdown
+	OutputFormat string
+
+	// MaxResponseSize is the largest tool result, in bytes, before results are truncated. 0 means no limit.
+	MaxResponseSize int
+
 	// ExportTranslations indicates if we should export translations
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#i18n--overriding-descriptions
 	ExportTranslations bool
@@ -179,21 +242,21 @@ func RunStdioServer(cfg StdioServerConfig) error {
 
 	t, dumpTranslations := translations.TranslationHelper()
 
-	ghServer, err := NewMCPServer(MCPServerConfig{
+	ghServer, err := newMCPServer(MCPServerConfig{
 		Version:         cfg.Version,
 		Host:            cfg.Host,
 		Token:           cfg.Token,
 		EnabledToolsets: cfg.EnabledToolsets,
 		DynamicToolsets: cfg.DynamicToolsets,
 		ReadOnly:        cfg.ReadOnly,
+		OutputFormat:    cfg.OutputFormat,
+		MaxResponseSize: cfg.MaxResponseSize,
 		Translator:      t,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
 	}
 
-	stdioServer := server.NewStdioServer(ghServer)
-
 	logrusLogger := logrus.New()
 	if cfg.LogFilePath != "" {
 		file, err := os.OpenFile(cfg.LogFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
@@ -205,7 +268,10 @@ func RunStdioServer(cfg StdioServerConfig) error {
 		logrusLogger.SetOutput(file)
 	}
 	stdLogger := log.New(logrusLogger.Writer(), "stdioserver", 0)
-	stdioServer.SetErrorLogger(stdLogger)
+	stdioServer := newStdioTransport(ghServer.MCPServer, stdLogger,
+		ghServer.subscriptions.HandleMessage,
+		ghServer.completions.HandleMessage,
+	)
 
 	if cfg.ExportTranslations {
 		// Once server is initialized, all translations are loaded
@@ -225,6 +291,8 @@ func RunStdioServer(cfg StdioServerConfig) error {
 		errC <- stdioServer.Listen(ctx, in, out)
 	}()
 
+	go ghServer.subscriptions.Run(ctx, resourcePollInterval)
+
 	// Output github-mcp-server string
 	_, _ = fmt.Fprintf(os.Stderr, "GitHub MCP Server running on stdio\n")
 
@@ -0,0 +1,373 @@
+package ghmcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// stdioSessionID is the ID the single stdio session is registered under.
+	stdioSessionID = "stdio"
+
+	// resourcePollInterval is how often subscribed resources are checked for changes.
+	resourcePollInterval = time.Minute
+
+	methodNotificationCancelled = "notifications/cancelled"
+)
+
+// messageHandler answers a request that mcp-go does not route itself, reporting whether message was
+// one it handles.
+type messageHandler func(ctx context.Context, sessionID string, message json.RawMessage) (mcp.JSONRPCMessage, bool)
+
+// stdioTransport serves an MCP server over stdio. Unlike mcp-go's stdio server it handles requests
+// concurrently, each with its own context, so that notifications/cancelled can stop a request that
+// is still running. It also passes the requests mcp-go does not route itself to handlers, and, like
+// mcp-go's stdio server, sends sampling requests to the client and routes back its responses.
+type stdioTransport struct {
+	server    *server.MCPServer
+	handlers  []messageHandler
+	errLogger *log.Logger
+
+	session *stdioSession
+	writer  *syncWriter
+
+	mu       sync.Mutex
+	inFlight map[string]context.CancelFunc // by request ID
+	wg       sync.WaitGroup
+}
+
+func newStdioTransport(s *server.MCPServer, errLogger *log.Logger, handlers ...messageHandler) *stdioTransport {
+	return &stdioTransport{
+		server:    s,
+		handlers:  handlers,
+		errLogger: errLogger,
+		session: &stdioSession{
+			notifications: make(chan mcp.JSONRPCNotification, 100),
+			pending:       make(map[string]chan samplingResponse),
+		},
+		inFlight: make(map[string]context.CancelFunc),
+	}
+}
+
+// Listen reads messages from in and writes responses and notifications to out until in is closed
+// or ctx is done. Requests still running when in is closed are waited for.
+func (t *stdioTransport) Listen(ctx context.Context, in io.Reader, out io.Writer) error {
+	if err := t.server.RegisterSession(ctx, t.session); err != nil {
+		return err
+	}
+	defer t.server.UnregisterSession(ctx, stdioSessionID)
+	ctx = t.server.WithContext(ctx, t.session)
+
+	t.writer = &syncWriter{writer: out}
+	t.session.writer = t.writer
+	notifyCtx, stopNotifications := context.WithCancel(ctx)
+	defer stopNotifications()
+	go t.forwardNotifications(notifyCtx)
+
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		reader := bufio.NewReader(in)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(bytes.TrimSpace(line)) > 0 {
+				select {
+				case lines <- line:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-readErr:
+			t.wg.Wait()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		case line := <-lines:
+			t.handleLine(ctx, bytes.TrimSpace(line))
+		}
+	}
+}
+
+func (t *stdioTransport) handleLine(ctx context.Context, line []byte) {
+	var envelope struct {
+		ID     *mcp.RequestId `json:"id"`
+		Method string         `json:"method"`
+		Params struct {
+			RequestID mcp.RequestId `json:"requestId"`
+			Meta      struct {
+				ProgressToken mcp.ProgressToken `json:"progressToken"`
+			} `json:"_meta"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(line, &envelope); err != nil {
+		t.write(mcp.NewJSONRPCError(mcp.NewRequestId(nil), mcp.PARSE_ERROR, "Parse error", nil))
+		return
+	}
+
+	switch {
+	case envelope.ID != nil && envelope.Method == "":
+		// A response from the client, to a sampling request
+		t.session.handleResponse(*envelope.ID, line)
+	case envelope.Method == methodNotificationCancelled:
+		t.cancel(envelope.Params.RequestID)
+	case envelope.ID == nil:
+		// Notifications are handled in order, since later requests may depend on them
+		if response := t.handle(ctx, line); response != nil {
+			t.write(response)
+		}
+	default:
+		key := envelope.ID.String()
+		requestCtx, cancel := context.WithCancel(ctx)
+		if token := envelope.Params.Meta.ProgressToken; token != nil {
+			// mcp-go drops the _meta of some requests, such as resources/read, while parsing them
+			requestCtx = github.ContextWithProgressToken(requestCtx, token)
+		}
+		t.mu.Lock()
+		t.inFlight[key] = cancel
+		t.mu.Unlock()
+
+		t.wg.Add(1)
+		go func() {
+			defer t.wg.Done()
+			response := t.handle(requestCtx, line)
+
+			t.mu.Lock()
+			delete(t.inFlight, key)
+			t.mu.Unlock()
+			cancelled := requestCtx.Err() != nil
+			cancel()
+
+			// The client has given up on a cancelled request, so it is not answered
+			if response == nil || cancelled {
+				return
+			}
+			t.write(response)
+		}()
+	}
+}
+
+func (t *stdioTransport) handle(ctx context.Context, message json.RawMessage) mcp.JSONRPCMessage {
+	for _, handler := range t.handlers {
+		if response, handled := handler(ctx, stdioSessionID, message); handled {
+			return response
+		}
+	}
+	return t.server.HandleMessage(ctx, message)
+}
+
+// cancel stops the request with the given ID, if it is still running.
+func (t *stdioTransport) cancel(id mcp.RequestId) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if cancel, ok := t.inFlight[id.String()]; ok {
+		cancel()
+	}
+}
+
+func (t *stdioTransport) forwardNotifications(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification := <-t.session.notifications:
+			t.write(notification)
+		}
+	}
+}
+
+func (t *stdioTransport) write(message mcp.JSONRPCMessage) {
+	b, err := json.Marshal(message)
+	if err != nil {
+		t.errLogger.Printf("Error marshalling message: %v", err)
+		return
+	}
+	if _, err := t.writer.Write(append(b, '\n')); err != nil {
+		t.errLogger.Printf("Error writing message: %v", err)
+	}
+}
+
+// stdioSession is the single client session served over stdio.
+type stdioSession struct {
+	notifications chan mcp.JSONRPCNotification
+	initialized   atomic.Bool
+	loggingLevel  atomic.Value
+	clientInfo    atomic.Value
+	capabilities  atomic.Value
+
+	writer    *syncWriter
+	requestID atomic.Int64
+	pendingMu sync.Mutex
+	pending   map[string]chan samplingResponse // by request ID
+}
+
+type samplingResponse struct {
+	result *mcp.CreateMessageResult
+	err    error
+}
+
+var (
+	_ server.ClientSession         = (*stdioSession)(nil)
+	_ server.SessionWithLogging    = (*stdioSession)(nil)
+	_ server.SessionWithClientInfo = (*stdioSession)(nil)
+	_ server.SessionWithSampling   = (*stdioSession)(nil)
+)
+
+func (s *stdioSession) SessionID() string {
+	return stdioSessionID
+}
+
+func (s *stdioSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func (s *stdioSession) Initialize() {
+	s.loggingLevel.Store(mcp.LoggingLevelError)
+	s.initialized.Store(true)
+}
+
+func (s *stdioSession) Initialized() bool {
+	return s.initialized.Load()
+}
+
+func (s *stdioSession) GetClientInfo() mcp.Implementation {
+	clientInfo, _ := s.clientInfo.Load().(mcp.Implementation)
+	return clientInfo
+}
+
+func (s *stdioSession) SetClientInfo(clientInfo mcp.Implementation) {
+	s.clientInfo.Store(clientInfo)
+}
+
+func (s *stdioSession) GetClientCapabilities() mcp.ClientCapabilities {
+	capabilities, _ := s.capabilities.Load().(mcp.ClientCapabilities)
+	return capabilities
+}
+
+func (s *stdioSession) SetClientCapabilities(capabilities mcp.ClientCapabilities) {
+	s.capabilities.Store(capabilities)
+}
+
+func (s *stdioSession) SetLogLevel(level mcp.LoggingLevel) {
+	s.loggingLevel.Store(level)
+}
+
+func (s *stdioSession) GetLogLevel() mcp.LoggingLevel {
+	level, ok := s.loggingLevel.Load().(mcp.LoggingLevel)
+	if !ok {
+		return mcp.LoggingLevelError
+	}
+	return level
+}
+
+// RequestSampling sends a sampling request to the client and waits for its response.
+func (s *stdioSession) RequestSampling(ctx context.Context, request mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+	if s.writer == nil {
+		return nil, errors.New("the session is not connected")
+	}
+
+	id := mcp.NewRequestId(s.requestID.Add(1))
+	responses := make(chan samplingResponse, 1)
+	s.pendingMu.Lock()
+	s.pending[id.String()] = responses
+	s.pendingMu.Unlock()
+	defer func() {
+		s.pendingMu.Lock()
+		delete(s.pending, id.String())
+		s.pendingMu.Unlock()
+	}()
+
+	b, err := json.Marshal(mcp.JSONRPCRequest{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		ID:      id,
+		Params:  request.CreateMessageParams,
+		Request: mcp.Request{Method: string(mcp.MethodSamplingCreateMessage)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sampling request: %w", err)
+	}
+	if _, err := s.writer.Write(append(b, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to write sampling request: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case response := <-responses:
+		return response.result, response.err
+	}
+}
+
+// handleResponse passes a response from the client to the sampling request waiting for it.
+func (s *stdioSession) handleResponse(id mcp.RequestId, message json.RawMessage) {
+	s.pendingMu.Lock()
+	responses, ok := s.pending[id.String()]
+	s.pendingMu.Unlock()
+	if !ok {
+		return
+	}
+
+	var response struct {
+		Result *mcp.CreateMessageResult `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	var result samplingResponse
+	switch err := json.Unmarshal(message, &response); {
+	case err != nil:
+		result.err = fmt.Errorf("failed to unmarshal sampling response: %w", err)
+	case response.Error != nil:
+		result.err = fmt.Errorf("sampling request failed: %s", response.Error.Message)
+	case response.Result == nil:
+		result.err = errors.New("sampling response has no result")
+	default:
+		result.result = response.Result
+		if content, ok := result.result.Content.(map[string]any); ok {
+			if parsed, err := mcp.ParseContent(content); err == nil {
+				result.result.Content = parsed
+			}
+		}
+	}
+
+	select {
+	case responses <- result:
+	default:
+	}
+}
+
+// syncWriter serializes writes, so that each message is written whole.
+type syncWriter struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.writer.Write(p)
+}
@@ -0,0 +1,240 @@
+package ghmcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	gogithub "github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stdioClient drives a stdioTransport the way a client on the other end of stdio would.
+type stdioClient struct {
+	t   *testing.T
+	in  *io.PipeWriter
+	out chan map[string]any
+}
+
+func startStdioTransport(t *testing.T, s *server.MCPServer, handlers ...messageHandler) *stdioClient {
+	inReader, inWriter := io.Pipe()
+	outReader, outWriter := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	transport := newStdioTransport(s, log.New(io.Discard, "", 0), handlers...)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = transport.Listen(ctx, inReader, outWriter)
+	}()
+
+	out := make(chan map[string]any, 10)
+	go func() {
+		defer close(out)
+		scanner := bufio.NewScanner(outReader)
+		for scanner.Scan() {
+			var message map[string]any
+			if err := json.Unmarshal(scanner.Bytes(), &message); err == nil {
+				out <- message
+			}
+		}
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		_ = inWriter.Close()
+		<-done
+		_ = outWriter.Close()
+	})
+
+	c := &stdioClient{t: t, in: inWriter, out: out}
+	c.send(`{"jsonrpc":"2.0","id":0,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{"sampling":{}},"clientInfo":{"name":"test","version":"1.0"}}}`)
+	assert.Equal(t, float64(0), c.receive()["id"])
+	return c
+}
+
+func (c *stdioClient) send(message string) {
+	_, err := c.in.Write([]byte(message + "\n"))
+	require.NoError(c.t, err)
+}
+
+func (c *stdioClient) callTool(id int, name string) {
+	c.send(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"tools/call","params":{"name":%q}}`, id, name))
+}
+
+func (c *stdioClient) receive() map[string]any {
+	select {
+	case message := <-c.out:
+		return message
+	case <-time.After(5 * time.Second):
+		c.t.Fatal("timed out waiting for a message from the server")
+		return nil
+	}
+}
+
+func (c *stdioClient) expectNothing() {
+	select {
+	case message := <-c.out:
+		c.t.Fatalf("unexpected message from the server: %v", message)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func resultText(t *testing.T, message map[string]any) string {
+	result, ok := message["result"].(map[string]any)
+	require.True(t, ok, "expected a result, got %v", message)
+	content, ok := result["content"].([]any)
+	require.True(t, ok)
+	require.NotEmpty(t, content)
+	return content[0].(map[string]any)["text"].(string)
+}
+
+func Test_StdioTransport_ConcurrentRequests(t *testing.T) {
+	release := make(chan struct{})
+	s := server.NewMCPServer("test", "1.0", server.WithToolCapabilities(true))
+	s.AddTool(mcp.NewTool("slow"), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		<-release
+		return mcp.NewToolResultText("done"), nil
+	})
+	client := startStdioTransport(t, s)
+
+	// A slow call doesn't hold up the requests after it
+	client.callTool(1, "slow")
+	client.send(`{"jsonrpc":"2.0","id":2,"method":"ping"}`)
+	assert.Equal(t, float64(2), client.receive()["id"])
+
+	close(release)
+	response := client.receive()
+	assert.Equal(t, float64(1), response["id"])
+	assert.Equal(t, "done", resultText(t, response))
+}
+
+func Test_StdioTransport_Completion(t *testing.T) {
+	release := make(chan struct{})
+	s := server.NewMCPServer("test", "1.0")
+	completions := github.NewCompletions(s, func(_ context.Context) (*gogithub.Client, error) {
+		<-release
+		return nil, errors.New("no client")
+	}, toolsets.NewToolsetGroup(false))
+	client := startStdioTransport(t, s, completions.HandleMessage)
+
+	// A completion waiting on GitHub doesn't hold up the requests after it
+	client.send(`{"jsonrpc":"2.0","id":1,"method":"completion/complete","params":{"ref":{"type":"ref/prompt","name":"p"},"argument":{"name":"owner","value":""}}}`)
+	client.send(`{"jsonrpc":"2.0","id":2,"method":"ping"}`)
+	assert.Equal(t, float64(2), client.receive()["id"])
+
+	close(release)
+	response := client.receive()
+	assert.Equal(t, float64(1), response["id"])
+	assert.Contains(t, response, "error")
+}
+
+func Test_StdioTransport_Cancellation(t *testing.T) {
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	s := server.NewMCPServer("test", "1.0", server.WithToolCapabilities(true))
+	s.AddTool(mcp.NewTool("wait"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		close(started)
+		<-ctx.Done()
+		close(stopped)
+		return mcp.NewToolResultText("partial"), nil
+	})
+	client := startStdioTransport(t, s)
+
+	client.callTool(1, "wait")
+	<-started
+	client.send(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":1,"reason":"user cancelled"}}`)
+
+	// The handler sees the cancellation, and the cancelled request is not answered
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the handler was not cancelled")
+	}
+	client.send(`{"jsonrpc":"2.0","id":2,"method":"ping"}`)
+	assert.Equal(t, float64(2), client.receive()["id"])
+	client.expectNothing()
+}
+
+func Test_StdioTransport_Sampling(t *testing.T) {
+	s := server.NewMCPServer("test", "1.0", server.WithToolCapabilities(true))
+	s.AddTool(mcp.NewTool("summarize"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := server.ServerFromContext(ctx).RequestSampling(ctx, mcp.CreateMessageRequest{
+			CreateMessageParams: mcp.CreateMessageParams{
+				Messages:  []mcp.SamplingMessage{{Role: mcp.RoleUser, Content: mcp.NewTextContent("Summarize this")}},
+				MaxTokens: 100,
+			},
+		})
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		text, ok := result.Content.(mcp.TextContent)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("unexpected content %T", result.Content)), nil
+		}
+		return mcp.NewToolResultText("summary: " + text.Text), nil
+	})
+	client := startStdioTransport(t, s)
+
+	client.callTool(1, "summarize")
+
+	// The sampling request goes to the client, and its response back to the tool
+	request := client.receive()
+	assert.Equal(t, string(mcp.MethodSamplingCreateMessage), request["method"])
+	id, err := json.Marshal(request["id"])
+	require.NoError(t, err)
+	client.send(fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":{"role":"assistant","content":{"type":"text","text":"It's short."},"model":"test-model"}}`, id))
+
+	response := client.receive()
+	assert.Equal(t, float64(1), response["id"])
+	assert.Equal(t, "summary: It's short.", resultText(t, response))
+}
+
+func Test_StdioTransport_ProgressToken(t *testing.T) {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+			&gogithub.Issue{Number: gogithub.Ptr(1), Title: gogithub.Ptr("Bug")},
+		),
+		mock.WithRequestMatch(
+			mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+			[]*gogithub.IssueComment{{Body: gogithub.Ptr("Same here")}},
+		),
+	)
+	s := server.NewMCPServer("test", "1.0", server.WithResourceCapabilities(false, false))
+	s.AddResourceTemplate(github.GetIssueResource(func(_ context.Context) (*gogithub.Client, error) {
+		return gogithub.NewClient(mockedClient), nil
+	}, translations.NullTranslationHelper))
+	client := startStdioTransport(t, s)
+
+	// mcp-go drops the _meta of resources/read, so the progress token reaches the handler through the transport
+	client.send(`{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"repo://owner/repo/issues/1","_meta":{"progressToken":"token-1"}}}`)
+
+	var progress []string
+	var response map[string]any
+	for response == nil || len(progress) < 2 {
+		message := client.receive()
+		if message["method"] == github.MethodProgress {
+			params := message["params"].(map[string]any)
+			assert.Equal(t, "token-1", params["progressToken"])
+			progress = append(progress, params["message"].(string))
+			continue
+		}
+		response = message
+	}
+	assert.Equal(t, float64(1), response["id"])
+	assert.Equal(t, []string{"Got issue #1", "Fetched 1 comments"}, progress)
+}
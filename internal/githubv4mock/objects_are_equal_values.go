@@ -44,6 +44,20 @@ func objectsAreEqualValues(expected, actual any) bool {
 		return false
 	}
 
+	// There is a modification to compare typed slices with the []any that lists are decoded into from the
+	// request body, element by element, e.g. []githubv4.IssueTimelineItemsItemType{"LABELED_EVENT"} with []any{"LABELED_EVENT"}.
+	if actualElems, ok := actual.([]any); ok && expectedValue.Kind() == reflect.Slice {
+		if expectedValue.Len() != len(actualElems) {
+			return false
+		}
+		for i, actualElem := range actualElems {
+			if !objectsAreEqualValues(expectedValue.Index(i).Interface(), actualElem) {
+				return false
+			}
+		}
+		return true
+	}
+
 	expectedType := expectedValue.Type()
 	actualType := actualValue.Type()
 	if !expectedType.ConvertibleTo(actualType) {
@@ -0,0 +1,775 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// BranchProtection is a flattened view of the classic branch protection settings of a branch.
+type BranchProtection struct {
+	Branch                         string                      `json:"branch"`
+	Protected                      bool                        `json:"protected"`
+	RequiredStatusChecks           *ProtectionStatusChecks     `json:"required_status_checks,omitempty"`
+	RequiredPullRequestReviews     *ProtectionPullRequestRules `json:"required_pull_request_reviews,omitempty"`
+	Restrictions                   *ProtectionRestrictions     `json:"restrictions,omitempty"`
+	EnforceAdmins                  bool                        `json:"enforce_admins"`
+	RequireLinearHistory           bool                        `json:"require_linear_history"`
+	AllowForcePushes               bool                        `json:"allow_force_pushes"`
+	AllowDeletions                 bool                        `json:"allow_deletions"`
+	RequiredConversationResolution bool                        `json:"required_conversation_resolution"`
+	RequiredSignatures             bool                        `json:"required_signatures"`
+	LockBranch                     bool                        `json:"lock_branch"`
+}
+
+// ProtectionStatusChecks lists the status checks that must pass before merging into a protected branch.
+type ProtectionStatusChecks struct {
+	Strict   bool     `json:"strict"`
+	Contexts []string `json:"contexts"`
+}
+
+// ProtectionPullRequestRules describes the pull request reviews required by branch protection.
+type ProtectionPullRequestRules struct {
+	RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+	DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+	RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+	RequireLastPushApproval      bool `json:"require_last_push_approval"`
+}
+
+// ProtectionRestrictions lists who is allowed to push to a protected branch.
+type ProtectionRestrictions struct {
+	Users []string `json:"users"`
+	Teams []string `json:"teams"`
+	Apps  []string `json:"apps"`
+}
+
+// BranchRule is a single rule that applies to a branch, together with the ruleset it comes from.
+type BranchRule struct {
+	Type              string          `json:"type"`
+	RulesetSourceType string          `json:"ruleset_source_type,omitempty"`
+	RulesetSource     string          `json:"ruleset_source,omitempty"`
+	RulesetID         int64           `json:"ruleset_id,omitempty"`
+	Parameters        json.RawMessage `json:"parameters,omitempty"`
+}
+
+// RequiredStatusCheck is a status check that must pass before merging, and where the requirement comes from.
+type RequiredStatusCheck struct {
+	Context   string `json:"context"`
+	AppID     *int64 `json:"app_id,omitempty"`
+	Source    string `json:"source"`
+	RulesetID int64  `json:"ruleset_id,omitempty"`
+	Strict    bool   `json:"strict"`
+}
+
+func newBranchProtection(branch string, p *github.Protection) BranchProtection {
+	protection := BranchProtection{
+		Branch:    branch,
+		Protected: true,
+	}
+
+	if checks := p.GetRequiredStatusChecks(); checks != nil {
+		protection.RequiredStatusChecks = &ProtectionStatusChecks{
+			Strict:   checks.Strict,
+			Contexts: statusCheckContexts(checks),
+		}
+	}
+	if reviews := p.GetRequiredPullRequestReviews(); reviews != nil {
+		protection.RequiredPullRequestReviews = &ProtectionPullRequestRules{
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			RequireLastPushApproval:      reviews.RequireLastPushApproval,
+		}
+	}
+	if restrictions := p.GetRestrictions(); restrictions != nil {
+		protection.Restrictions = &ProtectionRestrictions{
+			Users: restrictionLogins(restrictions.Users),
+			Teams: restrictionTeamSlugs(restrictions.Teams),
+			Apps:  restrictionAppSlugs(restrictions.Apps),
+		}
+	}
+
+	protection.EnforceAdmins = p.EnforceAdmins != nil && p.EnforceAdmins.Enabled
+	protection.RequireLinearHistory = p.RequireLinearHistory != nil && p.RequireLinearHistory.Enabled
+	protection.AllowForcePushes = p.AllowForcePushes != nil && p.AllowForcePushes.Enabled
+	protection.AllowDeletions = p.AllowDeletions != nil && p.AllowDeletions.Enabled
+	protection.RequiredConversationResolution = p.RequiredConversationResolution != nil && p.RequiredConversationResolution.Enabled
+	protection.RequiredSignatures = p.GetRequiredSignatures().GetEnabled()
+	protection.LockBranch = p.GetLockBranch().GetEnabled()
+
+	return protection
+}
+
+// statusCheckContexts returns the required check names, whichever of the legacy contexts or the checks list is populated.
+func statusCheckContexts(checks *github.RequiredStatusChecks) []string {
+	contexts := []string{}
+	if checks.Checks != nil {
+		for _, check := range *checks.Checks {
+			contexts = append(contexts, check.Context)
+		}
+		return contexts
+	}
+	if checks.Contexts != nil {
+		contexts = append(contexts, *checks.Contexts...)
+	}
+	return contexts
+}
+
+func restrictionLogins(users []*github.User) []string {
+	logins := make([]string, 0, len(users))
+	for _, user := range users {
+		logins = append(logins, user.GetLogin())
+	}
+	return logins
+}
+
+func restrictionTeamSlugs(teams []*github.Team) []string {
+	slugs := make([]string, 0, len(teams))
+	for _, team := range teams {
+		slugs = append(slugs, team.GetSlug())
+	}
+	return slugs
+}
+
+func restrictionAppSlugs(apps []*github.App) []string {
+	slugs := make([]string, 0, len(apps))
+	for _, app := range apps {
+		slugs = append(slugs, app.GetSlug())
+	}
+	return slugs
+}
+
+// GetBranchProtection creates a tool to get the classic branch protection settings of a branch.
+func GetBranchProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_branch_protection",
+			mcp.WithDescription(t("TOOL_GET_BRANCH_PROTECTION_DESCRIPTION", "Get the branch protection settings of a branch, such as required reviews, required status checks and push restrictions. Useful to explain why a push or merge was rejected. Rulesets can also restrict a branch, see get_rules_for_branch.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_BRANCH_PROTECTION_USER_TITLE", "Get branch protection"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := requiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			protection, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			closeResponse(resp)
+			if errors.Is(err, github.ErrBranchNotProtected) {
+				return MarshalledTextResult(BranchProtection{Branch: branch}), nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get branch protection: %w", err)
+			}
+
+			return MarshalledTextResult(newBranchProtection(branch, protection)), nil
+		}
+}
+
+// ListRepositoryRulesets creates a tool to list the rulesets of a repository.
+func ListRepositoryRulesets(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repository_rulesets",
+			mcp.WithDescription(t("TOOL_LIST_REPOSITORY_RULESETS_DESCRIPTION", "List the rulesets of a repository. The list does not include the rules themselves, use get_repository_ruleset for those.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPOSITORY_RULESETS_USER_TITLE", "List repository rulesets"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithBoolean("include_parents",
+				mcp.Description("Include rulesets configured at higher levels, such as the organization (default true)"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeParents, ok, err := OptionalParamOK[bool](request, "include_parents")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				includeParents = true
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			rulesets, resp, err := client.Repositories.GetAllRulesets(ctx, owner, repo, &github.RepositoryListRulesetsOptions{
+				IncludesParents: github.Ptr(includeParents),
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list rulesets: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list rulesets: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(rulesets), nil
+		}
+}
+
+// GetRepositoryRuleset creates a tool to get a single ruleset of a repository, including its rules.
+func GetRepositoryRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_ruleset",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_RULESET_DESCRIPTION", "Get a ruleset of a repository, including its conditions, rules and bypass actors")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_RULESET_USER_TITLE", "Get repository ruleset"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("ruleset_id",
+				mcp.Required(),
+				mcp.Description("ID of the ruleset"),
+			),
+			mcp.WithBoolean("include_parents",
+				mcp.Description("Also look up rulesets configured at higher levels, such as the organization (default true)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rulesetID, err := RequiredInt(request, "ruleset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeParents, ok, err := OptionalParamOK[bool](request, "include_parents")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				includeParents = true
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ruleset, resp, err := client.Repositories.GetRuleset(ctx, owner, repo, int64(rulesetID), includeParents)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get ruleset: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get ruleset: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(ruleset), nil
+		}
+}
+
+// GetRulesForBranch creates a tool to list the ruleset rules that are in effect for a branch.
+func GetRulesForBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_rules_for_branch",
+			mcp.WithDescription(t("TOOL_GET_RULES_FOR_BRANCH_DESCRIPTION", "List the ruleset rules in effect for a branch, whichever ruleset (repository or organization) they come from. Unlike branch protection, this is readable by anyone with read access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RULES_FOR_BRANCH_USER_TITLE", "Get rules for branch"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name. The branch does not need to exist, which is useful to check the rules for a branch that is about to be created."),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := requiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The typed client decodes the rules into one field per rule type, which loses the
+			// order and the "type" of each rule. Decode the raw list instead.
+			u := fmt.Sprintf("repos/%s/%s/rules/branches/%s?page=%d&per_page=%d", owner, repo, url.PathEscape(branch), pagination.page, pagination.perPage)
+			req, err := client.NewRequest(http.MethodGet, u, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			rules := []BranchRule{}
+			resp, err := client.Do(ctx, req, &rules)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get rules for branch: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get rules for branch: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(rules), nil
+		}
+}
+
+// GetRequiredStatusChecks creates a tool to list every status check required to merge into a branch.
+func GetRequiredStatusChecks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_required_status_checks",
+			mcp.WithDescription(t("TOOL_GET_REQUIRED_STATUS_CHECKS_DESCRIPTION", "List the status checks that must pass before a pull request can be merged into a branch, from both branch protection and rulesets")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REQUIRED_STATUS_CHECKS_USER_TITLE", "Get required status checks"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := requiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			checks := []RequiredStatusCheck{}
+
+			// A 404 means either that the branch is not protected or that protection does not
+			// require status checks. Both leave the rulesets as the only source of requirements.
+			protectionChecks, resp, err := client.Repositories.GetRequiredStatusChecks(ctx, owner, repo, branch)
+			closeResponse(resp)
+			switch {
+			case err == nil:
+				if protectionChecks.Checks != nil {
+					for _, check := range *protectionChecks.Checks {
+						checks = append(checks, RequiredStatusCheck{
+							Context: check.Context,
+							AppID:   check.AppID,
+							Source:  "branch_protection",
+							Strict:  protectionChecks.Strict,
+						})
+					}
+				} else {
+					for _, name := range statusCheckContexts(protectionChecks) {
+						checks = append(checks, RequiredStatusCheck{
+							Context: name,
+							Source:  "branch_protection",
+							Strict:  protectionChecks.Strict,
+						})
+					}
+				}
+			case errors.Is(err, github.ErrBranchNotProtected), resp != nil && resp.StatusCode == http.StatusNotFound:
+			default:
+				return nil, fmt.Errorf("failed to get required status checks: %w", err)
+			}
+
+			rules, resp, err := client.Repositories.GetRulesForBranch(ctx, owner, repo, branch, &github.ListOptions{PerPage: 100})
+			closeResponse(resp)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get rules for branch: %w", err)
+			}
+			for _, rule := range rules.RequiredStatusChecks {
+				for _, check := range rule.Parameters.RequiredStatusChecks {
+					checks = append(checks, RequiredStatusCheck{
+						Context:   check.Context,
+						AppID:     check.IntegrationID,
+						Source:    "ruleset",
+						RulesetID: rule.RulesetID,
+						Strict:    rule.Parameters.StrictRequiredStatusChecksPolicy,
+					})
+				}
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"branch": branch,
+				"checks": checks,
+			}), nil
+		}
+}
+
+// UpdateBranchProtection creates a tool to change the classic branch protection settings of a branch.
+func UpdateBranchProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_branch_protection",
+			mcp.WithDescription(t("TOOL_UPDATE_BRANCH_PROTECTION_DESCRIPTION", "Update the branch protection settings of a branch, protecting it if it is not protected yet. Only the given settings are changed, the others are kept as they are. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_UPDATE_BRANCH_PROTECTION_USER_TITLE", "Update branch protection"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+			mcp.WithArray("required_status_checks",
+				mcp.Description("Status check contexts that must pass before merging. An empty list stops requiring status checks."),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithBoolean("strict",
+				mcp.Description("Require branches to be up to date with the base branch before merging"),
+			),
+			mcp.WithBoolean("enforce_admins",
+				mcp.Description("Apply the protection to repository administrators too"),
+			),
+			mcp.WithBoolean("require_pull_request_reviews",
+				mcp.Description("Require changes to go through a pull request. Set to false to stop requiring pull requests."),
+			),
+			mcp.WithNumber("required_approving_review_count",
+				mcp.Description("Number of approving reviews required, from 0 to 6. Implies require_pull_request_reviews."),
+				mcp.Min(0),
+				mcp.Max(6),
+			),
+			mcp.WithBoolean("dismiss_stale_reviews",
+				mcp.Description("Dismiss approving reviews when new commits are pushed. Implies require_pull_request_reviews."),
+			),
+			mcp.WithBoolean("require_code_owner_reviews",
+				mcp.Description("Require a review from code owners. Implies require_pull_request_reviews."),
+			),
+			mcp.WithBoolean("require_linear_history",
+				mcp.Description("Prevent merge commits from being pushed"),
+			),
+			mcp.WithBoolean("allow_force_pushes",
+				mcp.Description("Allow force pushes by anyone with push access"),
+			),
+			mcp.WithBoolean("allow_deletions",
+				mcp.Description("Allow the branch to be deleted by anyone with push access"),
+			),
+			mcp.WithBoolean("required_conversation_resolution",
+				mcp.Description("Require all review conversations to be resolved before merging"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := requiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The API replaces the whole protection on every update, so start from the current
+			// settings to keep the ones that were not asked to change.
+			current, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			closeResponse(resp)
+			if errors.Is(err, github.ErrBranchNotProtected) {
+				current = &github.Protection{}
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to get branch protection: %w", err)
+			}
+
+			protectionRequest, err := branchProtectionRequest(request, current)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			protection, resp, err := client.Repositories.UpdateBranchProtection(ctx, owner, repo, branch, protectionRequest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update branch protection: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to update branch protection: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(newBranchProtection(branch, protection)), nil
+		}
+}
+
+// branchProtectionRequest builds the full protection to send, applying the settings given in the
+// request on top of the current protection.
+func branchProtectionRequest(request mcp.CallToolRequest, current *github.Protection) (*github.ProtectionRequest, error) {
+	existing := newBranchProtection("", current)
+	protectionRequest := &github.ProtectionRequest{
+		EnforceAdmins:                  existing.EnforceAdmins,
+		RequireLinearHistory:           github.Ptr(existing.RequireLinearHistory),
+		AllowForcePushes:               github.Ptr(existing.AllowForcePushes),
+		AllowDeletions:                 github.Ptr(existing.AllowDeletions),
+		RequiredConversationResolution: github.Ptr(existing.RequiredConversationResolution),
+	}
+
+	if checks := current.GetRequiredStatusChecks(); checks != nil {
+		protectionRequest.RequiredStatusChecks = &github.RequiredStatusChecks{
+			Strict: checks.Strict,
+			Checks: checks.Checks,
+		}
+		if checks.Checks == nil {
+			protectionRequest.RequiredStatusChecks.Contexts = checks.Contexts
+		}
+	}
+	if _, ok := request.GetArguments()["required_status_checks"]; ok {
+		contexts, err := OptionalStringArrayParam(request, "required_status_checks")
+		if err != nil {
+			return nil, err
+		}
+		if len(contexts) == 0 {
+			protectionRequest.RequiredStatusChecks = nil
+		} else {
+			strict := false
+			if protectionRequest.RequiredStatusChecks != nil {
+				strict = protectionRequest.RequiredStatusChecks.Strict
+			}
+			checks := make([]*github.RequiredStatusCheck, 0, len(contexts))
+			for _, name := range contexts {
+				checks = append(checks, &github.RequiredStatusCheck{Context: name})
+			}
+			protectionRequest.RequiredStatusChecks = &github.RequiredStatusChecks{
+				Strict: strict,
+				Checks: &checks,
+			}
+		}
+	}
+	strict, ok, err := OptionalParamOK[bool](request, "strict")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if protectionRequest.RequiredStatusChecks == nil {
+			return nil, errors.New("strict requires the branch to have required status checks")
+		}
+		protectionRequest.RequiredStatusChecks.Strict = strict
+	}
+
+	if reviews := current.GetRequiredPullRequestReviews(); reviews != nil {
+		protectionRequest.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+			RequireLastPushApproval:      github.Ptr(reviews.RequireLastPushApproval),
+		}
+		if dismissal := reviews.DismissalRestrictions; dismissal != nil {
+			users := restrictionLogins(dismissal.Users)
+			teams := restrictionTeamSlugs(dismissal.Teams)
+			apps := restrictionAppSlugs(dismissal.Apps)
+			protectionRequest.RequiredPullRequestReviews.DismissalRestrictionsRequest = &github.DismissalRestrictionsRequest{
+				Users: &users,
+				Teams: &teams,
+				Apps:  &apps,
+			}
+		}
+		if bypass := reviews.BypassPullRequestAllowances; bypass != nil {
+			protectionRequest.RequiredPullRequestReviews.BypassPullRequestAllowancesRequest = &github.BypassPullRequestAllowancesRequest{
+				Users: restrictionLogins(bypass.Users),
+				Teams: restrictionTeamSlugs(bypass.Teams),
+				Apps:  restrictionAppSlugs(bypass.Apps),
+			}
+		}
+	}
+	requireReviews, requireReviewsSet, err := OptionalParamOK[bool](request, "require_pull_request_reviews")
+	if err != nil {
+		return nil, err
+	}
+	reviews := protectionRequest.RequiredPullRequestReviews
+	if reviews == nil {
+		reviews = &github.PullRequestReviewsEnforcementRequest{}
+	}
+	reviewSettingsGiven := false
+	if _, ok := request.GetArguments()["required_approving_review_count"]; ok {
+		count, err := OptionalIntParam(request, "required_approving_review_count")
+		if err != nil {
+			return nil, err
+		}
+		reviews.RequiredApprovingReviewCount = count
+		reviewSettingsGiven = true
+	}
+	for param, field := range map[string]*bool{
+		"dismiss_stale_reviews":      &reviews.DismissStaleReviews,
+		"require_code_owner_reviews": &reviews.RequireCodeOwnerReviews,
+	} {
+		value, ok, err := OptionalParamOK[bool](request, param)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			*field = value
+			reviewSettingsGiven = true
+		}
+	}
+	switch {
+	case requireReviewsSet && !requireReviews:
+		if reviewSettingsGiven {
+			return nil, errors.New("review settings cannot be given when require_pull_request_reviews is false")
+		}
+		protectionRequest.RequiredPullRequestReviews = nil
+	case requireReviewsSet || reviewSettingsGiven:
+		protectionRequest.RequiredPullRequestReviews = reviews
+	}
+
+	if restrictions := current.GetRestrictions(); restrictions != nil {
+		protectionRequest.Restrictions = &github.BranchRestrictionsRequest{
+			Users: restrictionLogins(restrictions.Users),
+			Teams: restrictionTeamSlugs(restrictions.Teams),
+			Apps:  restrictionAppSlugs(restrictions.Apps),
+		}
+	}
+
+	enforceAdmins, ok, err := OptionalParamOK[bool](request, "enforce_admins")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		protectionRequest.EnforceAdmins = enforceAdmins
+	}
+	for param, field := range map[string]**bool{
+		"require_linear_history":           &protectionRequest.RequireLinearHistory,
+		"allow_force_pushes":               &protectionRequest.AllowForcePushes,
+		"allow_deletions":                  &protectionRequest.AllowDeletions,
+		"required_conversation_resolution": &protectionRequest.RequiredConversationResolution,
+	} {
+		value, ok, err := OptionalParamOK[bool](request, param)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			*field = github.Ptr(value)
+		}
+	}
+
+	return protectionRequest, nil
+}
@@ -0,0 +1,534 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var branchNotProtectedHandler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = w.Write([]byte(`{"message": "Branch not protected"}`))
+})
+
+func Test_GetBranchProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetBranchProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_branch_protection", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	mockProtection := &github.Protection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Strict: true,
+			Checks: &[]*github.RequiredStatusCheck{{Context: "ci/build"}, {Context: "ci/lint"}},
+		},
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+			RequiredApprovingReviewCount: 2,
+			RequireCodeOwnerReviews:      true,
+		},
+		EnforceAdmins: &github.AdminEnforcement{Enabled: true},
+		Restrictions: &github.BranchRestrictions{
+			Users: []*github.User{{Login: github.Ptr("octocat")}},
+			Teams: []*github.Team{{Slug: github.Ptr("maintainers")}},
+		},
+		RequireLinearHistory: &github.RequireLinearHistory{Enabled: true},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedResult BranchProtection
+		expectedErrMsg string
+	}{
+		{
+			name: "protected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockProtection,
+				),
+			),
+			expectedResult: BranchProtection{
+				Branch:    "main",
+				Protected: true,
+				RequiredStatusChecks: &ProtectionStatusChecks{
+					Strict:   true,
+					Contexts: []string{"ci/build", "ci/lint"},
+				},
+				RequiredPullRequestReviews: &ProtectionPullRequestRules{
+					RequiredApprovingReviewCount: 2,
+					RequireCodeOwnerReviews:      true,
+				},
+				Restrictions: &ProtectionRestrictions{
+					Users: []string{"octocat"},
+					Teams: []string{"maintainers"},
+					Apps:  []string{},
+				},
+				EnforceAdmins:        true,
+				RequireLinearHistory: true,
+			},
+		},
+		{
+			name: "unprotected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					branchNotProtectedHandler,
+				),
+			),
+			expectedResult: BranchProtection{Branch: "main"},
+		},
+		{
+			name: "branch not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Branch not found"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get branch protection",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetBranchProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			}))
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returned BranchProtection
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_ListRepositoryRulesets(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepositoryRulesets(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_repository_rulesets", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "include_parents")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposRulesetsByOwnerByRepo,
+			expectQueryParams(t, map[string]string{
+				"includes_parents": "false",
+				"page":             "1",
+				"per_page":         "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.RepositoryRuleset{
+					{ID: github.Ptr(int64(42)), Name: "main protection", Source: "owner/repo", Enforcement: github.RulesetEnforcementActive},
+				}),
+			),
+		),
+	))
+	_, handler := ListRepositoryRulesets(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":           "owner",
+		"repo":            "repo",
+		"include_parents": false,
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+
+	var returned []*github.RepositoryRuleset
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	require.Len(t, returned, 1)
+	assert.Equal(t, int64(42), returned[0].GetID())
+	assert.Equal(t, "main protection", returned[0].Name)
+}
+
+func Test_GetRepositoryRuleset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_repository_ruleset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "ruleset_id")
+	assert.Contains(t, tool.InputSchema.Properties, "include_parents")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ruleset_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+			expectQueryParams(t, map[string]string{
+				"includes_parents": "true",
+			}).andThen(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{
+						"id": 42,
+						"name": "main protection",
+						"source": "owner/repo",
+						"enforcement": "active",
+						"rules": [
+							{"type": "deletion"},
+							{"type": "pull_request", "parameters": {"required_approving_review_count": 1, "dismiss_stale_reviews_on_push": false, "require_code_owner_review": false, "require_last_push_approval": false, "required_review_thread_resolution": false}}
+						]
+					}`))
+				}),
+			),
+		),
+	))
+	_, handler := GetRepositoryRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":      "owner",
+		"repo":       "repo",
+		"ruleset_id": float64(42),
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+
+	var returned github.RepositoryRuleset
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	assert.Equal(t, int64(42), returned.GetID())
+	require.NotNil(t, returned.Rules)
+	assert.NotNil(t, returned.Rules.Deletion)
+	require.NotNil(t, returned.Rules.PullRequest)
+	assert.Equal(t, 1, returned.Rules.PullRequest.RequiredApprovingReviewCount)
+}
+
+func Test_GetRulesForBranch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRulesForBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_rules_for_branch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+			expectPath(t, "/repos/owner/repo/rules/branches/main").andThen(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`[
+						{"type": "non_fast_forward", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 42},
+						{"type": "required_status_checks", "ruleset_source_type": "Organization", "ruleset_source": "owner", "ruleset_id": 7, "parameters": {"required_status_checks": [{"context": "ci/build"}], "strict_required_status_checks_policy": true}}
+					]`))
+				}),
+			),
+		),
+	))
+	_, handler := GetRulesForBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":  "owner",
+		"repo":   "repo",
+		"branch": "main",
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+
+	var returned []BranchRule
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	require.Len(t, returned, 2)
+	assert.Equal(t, "non_fast_forward", returned[0].Type)
+	assert.Equal(t, int64(42), returned[0].RulesetID)
+	assert.Empty(t, returned[0].Parameters)
+	assert.Equal(t, "required_status_checks", returned[1].Type)
+	assert.Equal(t, "Organization", returned[1].RulesetSourceType)
+	assert.JSONEq(t, `{"required_status_checks": [{"context": "ci/build"}], "strict_required_status_checks_policy": true}`, string(returned[1].Parameters))
+}
+
+func Test_GetRequiredStatusChecks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRequiredStatusChecks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_required_status_checks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	rulesHandler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[
+			{"type": "deletion", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 42},
+			{"type": "required_status_checks", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 42, "parameters": {"required_status_checks": [{"context": "security/scan", "integration_id": 15368}], "strict_required_status_checks_policy": false}}
+		]`))
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectedChecks []RequiredStatusCheck
+	}{
+		{
+			name: "branch protection and rulesets",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					&github.RequiredStatusChecks{
+						Strict:   true,
+						Contexts: &[]string{"ci/build"},
+					},
+				),
+				mock.WithRequestMatchHandler(mock.GetReposRulesBranchesByOwnerByRepoByBranch, rulesHandler),
+			),
+			expectedChecks: []RequiredStatusCheck{
+				{Context: "ci/build", Source: "branch_protection", Strict: true},
+				{Context: "security/scan", AppID: github.Ptr(int64(15368)), Source: "ruleset", RulesetID: 42},
+			},
+		},
+		{
+			name: "unprotected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					branchNotProtectedHandler,
+				),
+				mock.WithRequestMatchHandler(mock.GetReposRulesBranchesByOwnerByRepoByBranch, rulesHandler),
+			),
+			expectedChecks: []RequiredStatusCheck{
+				{Context: "security/scan", AppID: github.Ptr(int64(15368)), Source: "ruleset", RulesetID: 42},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRequiredStatusChecks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			}))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returned struct {
+				Branch string                `json:"branch"`
+				Checks []RequiredStatusCheck `json:"checks"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, "main", returned.Branch)
+			assert.Equal(t, tc.expectedChecks, returned.Checks)
+		})
+	}
+}
+
+func Test_UpdateBranchProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateBranchProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_branch_protection", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "required_status_checks")
+	assert.Contains(t, tool.InputSchema.Properties, "strict")
+	assert.Contains(t, tool.InputSchema.Properties, "enforce_admins")
+	assert.Contains(t, tool.InputSchema.Properties, "require_pull_request_reviews")
+	assert.Contains(t, tool.InputSchema.Properties, "required_approving_review_count")
+	assert.Contains(t, tool.InputSchema.Properties, "require_linear_history")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	currentProtection := &github.Protection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Strict:   true,
+			Contexts: &[]string{"ci/build"},
+		},
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+			RequiredApprovingReviewCount: 1,
+			DismissStaleReviews:          true,
+		},
+		EnforceAdmins: &github.AdminEnforcement{Enabled: false},
+		Restrictions: &github.BranchRestrictions{
+			Teams: []*github.Team{{Slug: github.Ptr("maintainers")}},
+		},
+		AllowDeletions: &github.AllowDeletions{Enabled: false},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "changes only the given settings",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					currentProtection,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposBranchesProtectionByOwnerByRepoByBranch,
+					expectRequestBody(t, map[string]any{
+						"required_status_checks": map[string]any{
+							"strict":   true,
+							"contexts": []any{"ci/build"},
+						},
+						"required_pull_request_reviews": map[string]any{
+							"dismiss_stale_reviews":           true,
+							"require_code_owner_reviews":      false,
+							"required_approving_review_count": float64(2),
+							"require_last_push_approval":      false,
+						},
+						"enforce_admins": true,
+						"restrictions": map[string]any{
+							"users": []any{},
+							"teams": []any{"maintainers"},
+							"apps":  []any{},
+						},
+						"required_linear_history":          false,
+						"allow_force_pushes":               false,
+						"allow_deletions":                  false,
+						"required_conversation_resolution": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, currentProtection),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":                           "owner",
+				"repo":                            "repo",
+				"branch":                          "main",
+				"enforce_admins":                  true,
+				"required_approving_review_count": float64(2),
+			},
+		},
+		{
+			name: "protects an unprotected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					branchNotProtectedHandler,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposBranchesProtectionByOwnerByRepoByBranch,
+					expectRequestBody(t, map[string]any{
+						"required_status_checks": map[string]any{
+							"strict": true,
+							"checks": []any{
+								map[string]any{"context": "ci/build"},
+								map[string]any{"context": "ci/test"},
+							},
+						},
+						"required_pull_request_reviews":    nil,
+						"enforce_admins":                   false,
+						"restrictions":                     nil,
+						"required_linear_history":          true,
+						"allow_force_pushes":               false,
+						"allow_deletions":                  false,
+						"required_conversation_resolution": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Protection{}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":                  "owner",
+				"repo":                   "repo",
+				"branch":                 "main",
+				"required_status_checks": []any{"ci/build", "ci/test"},
+				"strict":                 true,
+				"require_linear_history": true,
+			},
+		},
+		{
+			name: "strict without status checks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					branchNotProtectedHandler,
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"strict": true,
+			},
+			expectToolError: true,
+			expectedErrMsg:  "strict requires the branch to have required status checks",
+		},
+		{
+			name: "review settings while disabling reviews",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					currentProtection,
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":                        "owner",
+				"repo":                         "repo",
+				"branch":                       "main",
+				"require_pull_request_reviews": false,
+				"dismiss_stale_reviews":        true,
+			},
+			expectToolError: true,
+			expectedErrMsg:  "review settings cannot be given when require_pull_request_reviews is false",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateBranchProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Equal(t, tc.expectedErrMsg, textContent.Text)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			var returned BranchProtection
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.True(t, returned.Protected)
+			assert.Equal(t, "main", returned.Branch)
+		})
+	}
+}
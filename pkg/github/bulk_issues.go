@@ -0,0 +1,472 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+const (
+	// bulkIssueConcurrency bounds how many issues bulk_update_issues edits at the same time, to stay clear of
+	// the secondary rate limits GitHub applies to bursts of writes.
+	bulkIssueConcurrency = 5
+	// maxBulkIssueCount bounds max_count, the number of issues a single bulk_update_issues call edits.
+	maxBulkIssueCount = 100
+	// maxBulkIssueTargets bounds how many issues a search query may resolve to, which is also the most
+	// results the search API returns for a query.
+	maxBulkIssueTargets = 1000
+)
+
+const (
+	BulkOperationAddLabels    = "add_labels"
+	BulkOperationRemoveLabels = "remove_labels"
+	BulkOperationSetMilestone = "set_milestone"
+	BulkOperationAssign       = "assign"
+	BulkOperationClose        = "close"
+	BulkOperationComment      = "comment"
+)
+
+// BulkIssueResult is the outcome of a bulk operation on a single issue.
+type BulkIssueResult struct {
+	Number int    `json:"number"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// bulkContinuation is what a continuation token of bulk_update_issues holds: the issues left to process. The
+// issues are resolved once, so that resuming is not thrown off by edits that change the results of a query.
+type bulkContinuation struct {
+	Owner     string `json:"owner"`
+	Repo      string `json:"repo"`
+	Operation string `json:"operation"`
+	// Arguments identifies the arguments of the operation, which continuing must repeat
+	Arguments string `json:"arguments"`
+	Remaining []int  `json:"remaining"`
+	// TotalCount is the number of issues the query matched, if it matched more than could be resolved
+	TotalCount int `json:"total_count,omitempty"`
+}
+
+func encodeBulkContinuation(c bulkContinuation) (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeBulkContinuation(token string) (bulkContinuation, error) {
+	var c bulkContinuation
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, errors.New("invalid continuation_token")
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, errors.New("invalid continuation_token")
+	}
+	return c, nil
+}
+
+// bulkArgumentsHash identifies the arguments of an operation, so that a continuation token only applies the
+// change it was returned for to the rest of the issues.
+func bulkArgumentsHash(args any) string {
+	data, _ := json.Marshal(args)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16])
+}
+
+// bulkIssueOperation applies an operation to a single issue.
+type bulkIssueOperation func(ctx context.Context, client *github.Client, owner, repo string, number int) error
+
+// closeResponse closes the body of a response, which may be nil when the request failed.
+func closeResponse(resp *github.Response) {
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+}
+
+// bulkIssueOperationParams reads the parameters of the requested operation and returns a function applying it,
+// and the hash of the parameters.
+func bulkIssueOperationParams(request mcp.CallToolRequest, operation string) (bulkIssueOperation, string, error) {
+	switch operation {
+	case BulkOperationAddLabels, BulkOperationRemoveLabels:
+		labels, err := OptionalStringArrayParam(request, "labels")
+		if err != nil {
+			return nil, "", err
+		}
+		if len(labels) == 0 {
+			return nil, "", fmt.Errorf("labels are required for the %s operation", operation)
+		}
+		if operation == BulkOperationAddLabels {
+			return func(ctx context.Context, client *github.Client, owner, repo string, number int) error {
+				_, resp, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, number, labels)
+				closeResponse(resp)
+				return err
+			}, bulkArgumentsHash(labels), nil
+		}
+		return func(ctx context.Context, client *github.Client, owner, repo string, number int) error {
+			for _, label := range labels {
+				resp, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, number, label)
+				closeResponse(resp)
+				// The label not being on the issue is the outcome we want anyway.
+				if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+					return err
+				}
+			}
+			return nil
+		}, bulkArgumentsHash(labels), nil
+	case BulkOperationSetMilestone:
+		milestone, ok, err := OptionalParamOK[float64](request, "milestone")
+		if err != nil {
+			return nil, "", err
+		}
+		if !ok {
+			return nil, "", errors.New("milestone is required for the set_milestone operation, use 0 to clear the milestone")
+		}
+		number := int(milestone)
+		return func(ctx context.Context, client *github.Client, owner, repo string, issueNumber int) error {
+			var resp *github.Response
+			var err error
+			if number == 0 {
+				_, resp, err = client.Issues.RemoveMilestone(ctx, owner, repo, issueNumber)
+			} else {
+				_, resp, err = client.Issues.Edit(ctx, owner, repo, issueNumber, &github.IssueRequest{Milestone: &number})
+			}
+			closeResponse(resp)
+			return err
+		}, bulkArgumentsHash(number), nil
+	case BulkOperationAssign:
+		assignees, err := OptionalStringArrayParam(request, "assignees")
+		if err != nil {
+			return nil, "", err
+		}
+		if len(assignees) == 0 {
+			return nil, "", errors.New("assignees are required for the assign operation")
+		}
+		return func(ctx context.Context, client *github.Client, owner, repo string, number int) error {
+			_, resp, err := client.Issues.AddAssignees(ctx, owner, repo, number, assignees)
+			closeResponse(resp)
+			return err
+		}, bulkArgumentsHash(assignees), nil
+	case BulkOperationClose:
+		stateReason, err := OptionalParam[string](request, "state_reason")
+		if err != nil {
+			return nil, "", err
+		}
+		if stateReason == "" {
+			stateReason = "completed"
+		}
+		if stateReason != "completed" && stateReason != "not_planned" {
+			return nil, "", fmt.Errorf("state_reason must be completed or not_planned, got %q", stateReason)
+		}
+		return func(ctx context.Context, client *github.Client, owner, repo string, number int) error {
+			_, resp, err := client.Issues.Edit(ctx, owner, repo, number, &github.IssueRequest{
+				State:       github.Ptr("closed"),
+				StateReason: github.Ptr(stateReason),
+			})
+			closeResponse(resp)
+			return err
+		}, bulkArgumentsHash(stateReason), nil
+	case BulkOperationComment:
+		body, err := OptionalParam[string](request, "body")
+		if err != nil {
+			return nil, "", err
+		}
+		if body == "" {
+			return nil, "", errors.New("body is required for the comment operation")
+		}
+		return func(ctx context.Context, client *github.Client, owner, repo string, number int) error {
+			_, resp, err := client.Issues.CreateComment(ctx, owner, repo, number, &github.IssueComment{Body: github.Ptr(body)})
+			closeResponse(resp)
+			return err
+		}, bulkArgumentsHash(body), nil
+	default:
+		return nil, "", fmt.Errorf("unknown operation %q", operation)
+	}
+}
+
+// resolveBulkIssueQuery returns the numbers of the issues in a repository matching a search query, at most
+// maxBulkIssueTargets of them, and the total number of issues the query matched.
+func resolveBulkIssueQuery(ctx context.Context, client *github.Client, owner, repo, query string) ([]int, int, error) {
+	q := fmt.Sprintf("repo:%s/%s is:issue %s", owner, repo, query)
+	opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 100}}
+
+	var numbers []int
+	for {
+		result, resp, err := client.Search.Issues(ctx, q, opts)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to search issues: %w", err)
+		}
+		_ = resp.Body.Close()
+		for _, issue := range result.Issues {
+			numbers = append(numbers, issue.GetNumber())
+		}
+		if resp.NextPage == 0 || len(numbers) >= maxBulkIssueTargets {
+			numbers = numbers[:min(len(numbers), maxBulkIssueTargets)]
+			return numbers, max(result.GetTotal(), len(numbers)), nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// runBulkIssueOperation applies an operation to the given issues with bounded concurrency, reporting each issue
+// as it is done. It returns the results of the issues it got to, and the issues it did not get to because the
+// context was cancelled.
+func runBulkIssueOperation(ctx context.Context, client *github.Client, owner, repo string, numbers []int, op bulkIssueOperation, progress *progressReporter) ([]BulkIssueResult, []int) {
+	results := make([]BulkIssueResult, len(numbers))
+	started := 0
+
+	sem := make(chan struct{}, bulkIssueConcurrency)
+	var wg sync.WaitGroup
+dispatch:
+	for i, number := range numbers {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			<-sem
+			break dispatch
+		}
+		started++
+
+		wg.Add(1)
+		go func(i, number int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i] = BulkIssueResult{Number: number, Status: "ok"}
+			if err := op(ctx, client, owner, repo, number); err != nil {
+				results[i].Status = "failed"
+				results[i].Error = err.Error()
+			}
+			progress.Step(fmt.Sprintf("Issue #%d: %s", number, results[i].Status))
+		}(i, number)
+	}
+	wg.Wait()
+
+	return results[:started], numbers[started:]
+}
+
+// BulkUpdateIssues creates a tool to apply the same change to many issues at once.
+func BulkUpdateIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("bulk_update_issues",
+			mcp.WithDescription(t("TOOL_BULK_UPDATE_ISSUES_DESCRIPTION", "Apply the same change to many issues of a GitHub repository at once: add or remove labels, set the milestone, assign, close or comment. The issues are given as a list of numbers or as a search query. At most max_count issues are changed per call; when more issues match, the result holds a continuation_token to pass back, with the same operation and arguments, to process the rest. Every issue's outcome is reported, and failures do not stop the other issues from being processed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_BULK_UPDATE_ISSUES_USER_TITLE", "Bulk update issues"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("operation",
+				mcp.Required(),
+				mcp.Description("The change to apply to every issue"),
+				mcp.Enum(BulkOperationAddLabels, BulkOperationRemoveLabels, BulkOperationSetMilestone, BulkOperationAssign, BulkOperationClose, BulkOperationComment),
+			),
+			mcp.WithNumber("max_count",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("The most issues to change in this call (min 1, max %d)", maxBulkIssueCount)),
+				mcp.Min(1),
+				mcp.Max(maxBulkIssueCount),
+			),
+			mcp.WithArray("issue_numbers",
+				mcp.Description("Numbers of the issues to change. Exactly one of issue_numbers, query and continuation_token is required"),
+				mcp.Items(
+					map[string]any{
+						"type": "number",
+					},
+				),
+			),
+			mcp.WithString("query",
+				mcp.Description(fmt.Sprintf("Search query selecting the issues to change, using GitHub issues search syntax. The repository and is:issue qualifiers are added automatically. At most %d matching issues are changed; when more match, the result has truncated set and the total_count of matching issues", maxBulkIssueTargets)),
+			),
+			mcp.WithString("continuation_token",
+				mcp.Description("Token returned by a previous call, to continue with the issues it did not get to"),
+			),
+			mcp.WithArray("labels",
+				mcp.Description("Labels to add or remove, for the add_labels and remove_labels operations"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithNumber("milestone",
+				mcp.Description("Milestone number for the set_milestone operation, 0 clears the milestone"),
+			),
+			mcp.WithArray("assignees",
+				mcp.Description("Usernames to assign, for the assign operation"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithString("state_reason",
+				mcp.Description("Reason for closing, for the close operation, defaults to completed"),
+				mcp.Enum("completed", "not_planned"),
+			),
+			mcp.WithString("body",
+				mcp.Description("Comment text, for the comment operation"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			operation, err := requiredParam[string](request, "operation")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxCount, err := RequiredInt(request, "max_count")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxCount < 1 || maxCount > maxBulkIssueCount {
+				return mcp.NewToolResultError(fmt.Sprintf("max_count must be between 1 and %d", maxBulkIssueCount)), nil
+			}
+			numbers, err := OptionalIntArrayParam(request, "issue_numbers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query, err := OptionalParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			token, err := OptionalParam[string](request, "continuation_token")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			given := 0
+			for _, set := range []bool{len(numbers) > 0, query != "", token != ""} {
+				if set {
+					given++
+				}
+			}
+			if given != 1 {
+				return mcp.NewToolResultError("exactly one of issue_numbers, query and continuation_token is required"), nil
+			}
+
+			op, arguments, err := bulkIssueOperationParams(request, operation)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// totalCount is set when a query matched more issues than it could be resolved to
+			var totalCount int
+			switch {
+			case token != "":
+				continuation, err := decodeBulkContinuation(token)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if continuation.Owner != owner || continuation.Repo != repo || continuation.Operation != operation {
+					return mcp.NewToolResultError(fmt.Sprintf("continuation_token is for the %s operation on %s/%s", continuation.Operation, continuation.Owner, continuation.Repo)), nil
+				}
+				if continuation.Arguments != arguments {
+					return mcp.NewToolResultError(fmt.Sprintf("continuation_token is for the %s operation with other arguments, pass the same ones as the call that returned it", continuation.Operation)), nil
+				}
+				numbers = continuation.Remaining
+				totalCount = continuation.TotalCount
+			case query != "":
+				var total int
+				numbers, total, err = resolveBulkIssueQuery(ctx, client, owner, repo, query)
+				if err != nil {
+					return nil, err
+				}
+				if total > len(numbers) {
+					totalCount = total
+				}
+			}
+
+			// Drop duplicates, so that no issue gets commented on twice.
+			seen := make(map[int]bool, len(numbers))
+			unique := make([]int, 0, len(numbers))
+			for _, number := range numbers {
+				if !seen[number] {
+					seen[number] = true
+					unique = append(unique, number)
+				}
+			}
+			numbers = unique
+
+			batch, remaining := numbers, []int(nil)
+			if len(numbers) > maxCount {
+				batch, remaining = numbers[:maxCount], numbers[maxCount:]
+			}
+
+			progress := newProgressReporter(ctx, request, len(batch))
+			results, unattempted := runBulkIssueOperation(ctx, client, owner, repo, batch, op, progress)
+			remaining = append(unattempted, remaining...)
+
+			succeeded, failed := 0, 0
+			for _, result := range results {
+				if result.Status == "ok" {
+					succeeded++
+				} else {
+					failed++
+				}
+			}
+
+			response := map[string]any{
+				"operation": operation,
+				"matched":   len(numbers),
+				"succeeded": succeeded,
+				"failed":    failed,
+				"remaining": len(remaining),
+				"results":   results,
+			}
+			if totalCount > 0 {
+				// The issues past the first maxBulkIssueTargets are not changed, not even by continuing
+				response["truncated"] = true
+				response["total_count"] = totalCount
+			}
+			if len(remaining) > 0 {
+				token, err := encodeBulkContinuation(bulkContinuation{
+					Owner:      owner,
+					Repo:       repo,
+					Operation:  operation,
+					Arguments:  arguments,
+					Remaining:  remaining,
+					TotalCount: totalCount,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to encode continuation token: %w", err)
+				}
+				response["continuation_token"] = token
+				// A cancelled call is not answered, so the token is also kept for the cancellation report
+				reportContinuation(ctx, fmt.Sprintf("To continue with the %d issues it did not get to, call bulk_update_issues again with the same arguments and continuation_token: %s", len(remaining), token))
+			}
+
+			return MarshalledTextResult(response), nil
+		}
+}
@@ -0,0 +1,449 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bulkResponse struct {
+	Operation         string            `json:"operation"`
+	Matched           int               `json:"matched"`
+	Succeeded         int               `json:"succeeded"`
+	Failed            int               `json:"failed"`
+	Remaining         int               `json:"remaining"`
+	Results           []BulkIssueResult `json:"results"`
+	ContinuationToken string            `json:"continuation_token"`
+	Truncated         bool              `json:"truncated"`
+	TotalCount        int               `json:"total_count"`
+}
+
+func Test_BulkUpdateIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := BulkUpdateIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "bulk_update_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "issue_numbers")
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "continuation_token")
+	assert.Contains(t, tool.InputSchema.Properties, "labels")
+	assert.Contains(t, tool.InputSchema.Properties, "milestone")
+	assert.Contains(t, tool.InputSchema.Properties, "assignees")
+	assert.Contains(t, tool.InputSchema.Properties, "state_reason")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "operation", "max_count"})
+
+	// issueNumberFromPath returns the issue number of a /repos/{owner}/{repo}/issues/{number}/... path.
+	issueNumberFromPath := func(path string) string {
+		return strings.Split(strings.TrimPrefix(path, "/repos/owner/repo/issues/"), "/")[0]
+	}
+
+	t.Run("partial failure and continuation", func(t *testing.T) {
+		var mu sync.Mutex
+		var labelled []string
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+				expectRequestBody(t, []any{"triage"}).andThen(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						number := issueNumberFromPath(r.URL.Path)
+						if number == "2" {
+							w.WriteHeader(http.StatusForbidden)
+							_, _ = w.Write([]byte(`{"message": "Must have push access"}`))
+							return
+						}
+						mu.Lock()
+						labelled = append(labelled, number)
+						mu.Unlock()
+						mockResponse(t, http.StatusOK, []*github.Label{{Name: github.Ptr("triage")}})(w, r)
+					}),
+				),
+			),
+		))
+		_, handler := BulkUpdateIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":         "owner",
+			"repo":          "repo",
+			"operation":     "add_labels",
+			"labels":        []any{"triage"},
+			"issue_numbers": []any{float64(1), float64(2), float64(3), float64(1), float64(4)},
+			"max_count":     float64(3),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		var first bulkResponse
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &first))
+		assert.Equal(t, 4, first.Matched)
+		assert.Equal(t, 2, first.Succeeded)
+		assert.Equal(t, 1, first.Failed)
+		assert.Equal(t, 1, first.Remaining)
+		require.Len(t, first.Results, 3)
+		assert.Equal(t, BulkIssueResult{Number: 1, Status: "ok"}, first.Results[0])
+		assert.Equal(t, 2, first.Results[1].Number)
+		assert.Equal(t, "failed", first.Results[1].Status)
+		assert.Contains(t, first.Results[1].Error, "Must have push access")
+		assert.Equal(t, BulkIssueResult{Number: 3, Status: "ok"}, first.Results[2])
+		require.NotEmpty(t, first.ContinuationToken)
+
+		result, err = handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":              "owner",
+			"repo":               "repo",
+			"operation":          "add_labels",
+			"labels":             []any{"triage"},
+			"continuation_token": first.ContinuationToken,
+			"max_count":          float64(3),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		var second bulkResponse
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &second))
+		assert.Equal(t, []BulkIssueResult{{Number: 4, Status: "ok"}}, second.Results)
+		assert.Equal(t, 0, second.Remaining)
+		assert.Empty(t, second.ContinuationToken)
+
+		assert.ElementsMatch(t, []string{"1", "3", "4"}, labelled)
+	})
+
+	t.Run("close issues matching a query", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetSearchIssues,
+				expectQueryParams(t, map[string]string{
+					"q":        "repo:owner/repo is:issue is:open label:stale",
+					"per_page": "100",
+				}).andThen(
+					mockResponse(t, http.StatusOK, &github.IssuesSearchResult{
+						Total:  github.Ptr(2),
+						Issues: []*github.Issue{{Number: github.Ptr(7)}, {Number: github.Ptr(8)}},
+					}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+				expectRequestBody(t, map[string]any{
+					"state":        "closed",
+					"state_reason": "not_planned",
+				}).andThen(
+					mockResponse(t, http.StatusOK, &github.Issue{State: github.Ptr("closed")}),
+				),
+			),
+		))
+		_, handler := BulkUpdateIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"operation":    "close",
+			"state_reason": "not_planned",
+			"query":        "is:open label:stale",
+			"max_count":    float64(10),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		var response bulkResponse
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		assert.Equal(t, 2, response.Succeeded)
+		assert.Equal(t, []BulkIssueResult{{Number: 7, Status: "ok"}, {Number: 8, Status: "ok"}}, response.Results)
+		assert.Empty(t, response.ContinuationToken)
+		assert.False(t, response.Truncated)
+	})
+
+	t.Run("query matching more issues than can be resolved", func(t *testing.T) {
+		// The query matches 1200 issues, of which the search API only returns the first 1000
+		var searches int
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetSearchIssues,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					searches++
+					page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+					page = max(page, 1)
+					issues := make([]*github.Issue, 0, 100)
+					for i := (page-1)*100 + 1; i <= page*100; i++ {
+						issues = append(issues, &github.Issue{Number: github.Ptr(i)})
+					}
+					w.Header().Set("Link", fmt.Sprintf(`<https://api.github.com/search/issues?page=%d>; rel="next"`, page+1))
+					mockResponse(t, http.StatusOK, &github.IssuesSearchResult{Total: github.Ptr(1200), Issues: issues})(w, r)
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+				mockResponse(t, http.StatusOK, &github.Issue{State: github.Ptr("closed")}),
+			),
+		))
+		_, handler := BulkUpdateIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":     "owner",
+			"repo":      "repo",
+			"operation": "close",
+			"query":     "is:open",
+			"max_count": float64(1),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		var first bulkResponse
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &first))
+		assert.Equal(t, 10, searches)
+		assert.Equal(t, 1000, first.Matched)
+		assert.Equal(t, 999, first.Remaining)
+		assert.True(t, first.Truncated)
+		assert.Equal(t, 1200, first.TotalCount)
+
+		// Continuing still reports that the query was truncated
+		result, err = handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":              "owner",
+			"repo":               "repo",
+			"operation":          "close",
+			"continuation_token": first.ContinuationToken,
+			"max_count":          float64(1),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		var second bulkResponse
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &second))
+		assert.Equal(t, []BulkIssueResult{{Number: 2, Status: "ok"}}, second.Results)
+		assert.True(t, second.Truncated)
+		assert.Equal(t, 1200, second.TotalCount)
+	})
+
+	t.Run("removing a label that is not set succeeds", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Label does not exist"}`))
+				}),
+			),
+		))
+		_, handler := BulkUpdateIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":         "owner",
+			"repo":          "repo",
+			"operation":     "remove_labels",
+			"labels":        []any{"stale"},
+			"issue_numbers": []any{float64(5)},
+			"max_count":     float64(1),
+		}))
+		require.NoError(t, err)
+
+		var response bulkResponse
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		assert.Equal(t, []BulkIssueResult{{Number: 5, Status: "ok"}}, response.Results)
+	})
+
+	t.Run("comment on issues", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+				expectRequestBody(t, map[string]any{"body": "Closing in favour of #1"}).andThen(
+					mockResponse(t, http.StatusCreated, &github.IssueComment{ID: github.Ptr(int64(1))}),
+				),
+			),
+		))
+		_, handler := BulkUpdateIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":         "owner",
+			"repo":          "repo",
+			"operation":     "comment",
+			"body":          "Closing in favour of #1",
+			"issue_numbers": []any{float64(2), float64(3)},
+			"max_count":     float64(2),
+		}))
+		require.NoError(t, err)
+
+		var response bulkResponse
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		assert.Equal(t, 2, response.Succeeded)
+	})
+
+	validationTests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectedErrMsg string
+	}{
+		{
+			name: "no issues given",
+			requestArgs: map[string]any{
+				"operation": "add_labels",
+				"labels":    []any{"bug"},
+				"max_count": float64(10),
+			},
+			expectedErrMsg: "exactly one of issue_numbers, query and continuation_token is required",
+		},
+		{
+			name: "both issue numbers and query given",
+			requestArgs: map[string]any{
+				"operation":     "add_labels",
+				"labels":        []any{"bug"},
+				"issue_numbers": []any{float64(1)},
+				"query":         "is:open",
+				"max_count":     float64(10),
+			},
+			expectedErrMsg: "exactly one of issue_numbers, query and continuation_token is required",
+		},
+		{
+			name: "max count missing",
+			requestArgs: map[string]any{
+				"operation":     "add_labels",
+				"labels":        []any{"bug"},
+				"issue_numbers": []any{float64(1)},
+			},
+			expectedErrMsg: "missing required parameter: max_count",
+		},
+		{
+			name: "max count too large",
+			requestArgs: map[string]any{
+				"operation":     "add_labels",
+				"labels":        []any{"bug"},
+				"issue_numbers": []any{float64(1)},
+				"max_count":     float64(500),
+			},
+			expectedErrMsg: "max_count must be between 1 and 100",
+		},
+		{
+			name: "labels missing",
+			requestArgs: map[string]any{
+				"operation":     "add_labels",
+				"issue_numbers": []any{float64(1)},
+				"max_count":     float64(10),
+			},
+			expectedErrMsg: "labels are required for the add_labels operation",
+		},
+		{
+			name: "milestone missing",
+			requestArgs: map[string]any{
+				"operation":     "set_milestone",
+				"issue_numbers": []any{float64(1)},
+				"max_count":     float64(10),
+			},
+			expectedErrMsg: "milestone is required for the set_milestone operation",
+		},
+		{
+			name: "continuation token for another operation",
+			requestArgs: map[string]any{
+				"operation": "close",
+				"continuation_token": func() string {
+					token, _ := encodeBulkContinuation(bulkContinuation{Owner: "owner", Repo: "repo", Operation: "comment", Remaining: []int{1}})
+					return token
+				}(),
+				"max_count": float64(10),
+			},
+			expectedErrMsg: "continuation_token is for the comment operation on owner/repo",
+		},
+		{
+			name: "continuation token with other arguments",
+			requestArgs: map[string]any{
+				"operation": "add_labels",
+				"labels":    []any{"wontfix"},
+				"continuation_token": func() string {
+					token, _ := encodeBulkContinuation(bulkContinuation{Owner: "owner", Repo: "repo", Operation: "add_labels", Arguments: bulkArgumentsHash([]string{"triage"}), Remaining: []int{1}})
+					return token
+				}(),
+				"max_count": float64(10),
+			},
+			expectedErrMsg: "continuation_token is for the add_labels operation with other arguments",
+		},
+		{
+			name: "invalid continuation token",
+			requestArgs: map[string]any{
+				"operation":          "close",
+				"continuation_token": "not a token",
+				"max_count":          float64(10),
+			},
+			expectedErrMsg: "invalid continuation_token",
+		},
+	}
+
+	for _, tc := range validationTests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient())
+			_, handler := BulkUpdateIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			tc.requestArgs["owner"] = "owner"
+			tc.requestArgs["repo"] = "repo"
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+		})
+	}
+}
+
+func Test_RunBulkIssueOperationCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, unattempted := runBulkIssueOperation(ctx, nil, "owner", "repo", []int{1, 2, 3},
+		func(context.Context, *github.Client, string, string, int) error {
+			t.Fatal("operation should not run once the context is cancelled")
+			return nil
+		}, newProgressReporter(ctx, mcp.CallToolRequest{}, 3))
+	assert.Empty(t, results)
+	assert.Equal(t, []int{1, 2, 3}, unattempted)
+}
+
+func Test_BulkUpdateIssuesCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The call is cancelled while the first issues are being labelled
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				cancel()
+				mockResponse(t, http.StatusOK, []*github.Label{{Name: github.Ptr("triage")}})(w, r)
+			}),
+		),
+	))
+	_, handler := BulkUpdateIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	numbers := []any{}
+	for i := 1; i <= bulkIssueConcurrency+3; i++ {
+		numbers = append(numbers, float64(i))
+	}
+	result, err := CancellationMiddleware(handler)(ctx, createMCPRequest(map[string]any{
+		"owner":         "owner",
+		"repo":          "repo",
+		"operation":     "add_labels",
+		"labels":        []any{"triage"},
+		"issue_numbers": numbers,
+		"max_count":     float64(len(numbers)),
+	}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+
+	// The report of the cancelled call holds the token to continue with the issues it did not get to
+	text := getTextResult(t, result).Text
+	_, token, ok := strings.Cut(text, "continuation_token: ")
+	require.True(t, ok, text)
+	continuation, err := decodeBulkContinuation(token)
+	require.NoError(t, err)
+	assert.Equal(t, "add_labels", continuation.Operation)
+	assert.Equal(t, bulkArgumentsHash([]string{"triage"}), continuation.Arguments)
+	assert.Contains(t, continuation.Remaining, len(numbers))
+}
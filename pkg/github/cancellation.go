@@ -0,0 +1,151 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type writeTrackerKey struct{}
+
+// writeTracker records the requests a tool call makes that change something on GitHub, so that a
+// cancelled call can tell the client whether its side effects had already happened.
+type writeTracker struct {
+	mu        sync.Mutex
+	applied   []string // requests GitHub accepted
+	uncertain []string // requests whose response never arrived
+	// continuation tells how to pick up where the call left off, for the tools that can
+	continuation string
+}
+
+func withWriteTracker(ctx context.Context) (context.Context, *writeTracker) {
+	tracker := &writeTracker{}
+	return context.WithValue(ctx, writeTrackerKey{}, tracker), tracker
+}
+
+func (w *writeTracker) record(description string, resp *http.Response, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	switch {
+	case err != nil:
+		w.uncertain = append(w.uncertain, description)
+	case resp.StatusCode < http.StatusBadRequest:
+		w.applied = append(w.applied, description)
+	}
+}
+
+// reportContinuation records how to continue a tool call that is cut short, so that the report of a
+// cancelled call can include it.
+func reportContinuation(ctx context.Context, continuation string) {
+	if tracker, ok := ctx.Value(writeTrackerKey{}).(*writeTracker); ok {
+		tracker.mu.Lock()
+		defer tracker.mu.Unlock()
+		tracker.continuation = continuation
+	}
+}
+
+// sentChanges reports whether any requests that change something were sent.
+func (w *writeTracker) sentChanges() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.applied) > 0 || len(w.uncertain) > 0
+}
+
+// cancelledMessage describes what a cancelled call had already done.
+func (w *writeTracker) cancelledMessage() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var b strings.Builder
+	switch {
+	case len(w.applied) == 0 && len(w.uncertain) == 0:
+		b.WriteString("The request was cancelled before any changes were made on GitHub.")
+	case len(w.applied) > 0:
+		fmt.Fprintf(&b, "The request was cancelled after some changes had already been made on GitHub:\n- %s", strings.Join(w.applied, "\n- "))
+	default:
+		b.WriteString("The request was cancelled before any changes were confirmed by GitHub.")
+	}
+	if len(w.uncertain) > 0 {
+		fmt.Fprintf(&b, "\nThese changes were sent but may or may not have been applied:\n- %s", strings.Join(w.uncertain, "\n- "))
+	}
+	if w.continuation != "" {
+		fmt.Fprintf(&b, "\n%s", w.continuation)
+	}
+	return b.String()
+}
+
+// WriteTrackingTransport records the requests that change something on GitHub with the tool call
+// that made them. REST requests other than GET and HEAD count as changes, as do GraphQL mutations.
+type WriteTrackingTransport struct {
+	Transport http.RoundTripper
+}
+
+func (t *WriteTrackingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	tracker, ok := req.Context().Value(writeTrackerKey{}).(*writeTracker)
+	if !ok || !isWriteRequest(req) {
+		return t.Transport.RoundTrip(req)
+	}
+
+	resp, err := t.Transport.RoundTrip(req)
+	tracker.record(fmt.Sprintf("%s %s", req.Method, req.URL.Path), resp, err)
+	return resp, err
+}
+
+func isWriteRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	if !strings.HasSuffix(req.URL.Path, "/graphql") || req.GetBody == nil {
+		return true
+	}
+
+	// GraphQL reads are POSTs too, so look at the operation being sent
+	body, err := req.GetBody()
+	if err != nil {
+		return true
+	}
+	defer func() { _ = body.Close() }()
+	var query struct {
+		Query string `json:"query"`
+	}
+	b, err := io.ReadAll(body)
+	if err != nil || json.Unmarshal(b, &query) != nil {
+		return true
+	}
+	return strings.HasPrefix(strings.TrimSpace(query.Query), "mutation")
+}
+
+// CancellationMiddleware discards the result of a tool call the client cancelled, which may only
+// be partial, and instead reports whether the call had already changed anything on GitHub. Since a
+// cancelled call is not answered, the report is also sent to the client as a log message, at error
+// level if changes had been sent. Tools that can be continued add how to do so to the report.
+func CancellationMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, tracker := withWriteTracker(ctx)
+		result, err := next(ctx, request)
+		if ctx.Err() == nil {
+			return result, err
+		}
+
+		message := tracker.cancelledMessage()
+		if s := server.ServerFromContext(ctx); s != nil {
+			level := mcp.LoggingLevelInfo
+			if tracker.sentChanges() {
+				level = mcp.LoggingLevelError
+			}
+			_ = s.SendLogMessageToClient(ctx, mcp.NewLoggingMessageNotification(level, "github-mcp-server", map[string]any{
+				"tool":    request.Params.Name,
+				"message": message,
+			}))
+		}
+		return mcp.NewToolResultError(message), nil
+	}
+}
@@ -0,0 +1,120 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func Test_CancellationMiddleware(t *testing.T) {
+	// The upstream answers POSTs to /created, and never answers anything else
+	client := &http.Client{Transport: &WriteTrackingTransport{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/created" {
+				return &http.Response{StatusCode: http.StatusCreated, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+			}
+			return nil, errors.New("connection reset")
+		}),
+	}}
+	send := func(ctx context.Context, method, path string) {
+		req, err := http.NewRequestWithContext(ctx, method, "https://api.github.com"+path, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}
+
+	tests := []struct {
+		name            string
+		cancel          bool
+		requests        [][2]string
+		expectedError   bool
+		expectedMessage string
+	}{
+		{
+			name:            "passes the result through when not cancelled",
+			requests:        [][2]string{{http.MethodPost, "/created"}},
+			expectedMessage: "partial result",
+		},
+		{
+			name:            "reports that nothing changed",
+			cancel:          true,
+			requests:        [][2]string{{http.MethodGet, "/created"}},
+			expectedError:   true,
+			expectedMessage: "The request was cancelled before any changes were made on GitHub.",
+		},
+		{
+			name:            "reports changes already made",
+			cancel:          true,
+			requests:        [][2]string{{http.MethodPost, "/created"}},
+			expectedError:   true,
+			expectedMessage: "The request was cancelled after some changes had already been made on GitHub:\n- POST /created",
+		},
+		{
+			name:          "reports changes that may have been made",
+			cancel:        true,
+			requests:      [][2]string{{http.MethodPost, "/created"}, {http.MethodDelete, "/lost"}},
+			expectedError: true,
+			expectedMessage: "The request was cancelled after some changes had already been made on GitHub:\n- POST /created\n" +
+				"These changes were sent but may or may not have been applied:\n- DELETE /lost",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			handler := CancellationMiddleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				for _, request := range tc.requests {
+					send(ctx, request[0], request[1])
+				}
+				if tc.cancel {
+					cancel()
+				}
+				return mcp.NewToolResultText("partial result"), nil
+			})
+
+			result, err := handler(ctx, mcp.CallToolRequest{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedError, result.IsError)
+			assert.Equal(t, tc.expectedMessage, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_IsWriteRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		url      string
+		body     string
+		expected bool
+	}{
+		{name: "REST read", method: http.MethodGet, url: "https://api.github.com/repos/o/r", expected: false},
+		{name: "REST write", method: http.MethodPatch, url: "https://api.github.com/repos/o/r/issues/1", expected: true},
+		{name: "GraphQL query", method: http.MethodPost, url: "https://api.github.com/graphql", body: `{"query":"query{viewer{login}}"}`, expected: false},
+		{name: "GraphQL mutation", method: http.MethodPost, url: "https://api.github.com/graphql", body: `{"query":"mutation($input:AddCommentInput!){addComment(input:$input){clientMutationId}}"}`, expected: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, isWriteRequest(req))
+		})
+	}
+}
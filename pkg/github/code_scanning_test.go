@@ -164,6 +164,8 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 						"state":     "open",
 						"severity":  "high",
 						"tool_name": "codeql",
+						"page":      "1",
+						"per_page":  "30",
 					}).andThen(
 						mockResponse(t, http.StatusOK, mockAlerts),
 					),
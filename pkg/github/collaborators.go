@@ -0,0 +1,638 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// repositoryRoles are the built-in roles that can be granted to a collaborator or a team on a repository.
+// Organizations can define custom roles on top of these, which are accepted by name as well.
+var repositoryRoles = []string{"pull", "triage", "push", "maintain", "admin"}
+
+// Collaborator is a user with access to a repository, and the role they have on it.
+type Collaborator struct {
+	Login       string          `json:"login"`
+	HTMLURL     string          `json:"html_url,omitempty"`
+	RoleName    string          `json:"role_name,omitempty"`
+	Permissions map[string]bool `json:"permissions,omitempty"`
+}
+
+// RepositoryInvitation is a pending invitation to collaborate on a repository.
+type RepositoryInvitation struct {
+	ID          int64  `json:"id"`
+	Invitee     string `json:"invitee,omitempty"`
+	Inviter     string `json:"inviter,omitempty"`
+	Permissions string `json:"permissions"`
+	CreatedAt   string `json:"created_at,omitempty"`
+	Expired     bool   `json:"expired"`
+	HTMLURL     string `json:"html_url,omitempty"`
+}
+
+func newRepositoryInvitation(invitation *github.RepositoryInvitation) RepositoryInvitation {
+	result := RepositoryInvitation{
+		ID:          invitation.GetID(),
+		Invitee:     invitation.GetInvitee().GetLogin(),
+		Inviter:     invitation.GetInviter().GetLogin(),
+		Permissions: invitation.GetPermissions(),
+		Expired:     invitation.GetExpired(),
+		HTMLURL:     invitation.GetHTMLURL(),
+	}
+	if invitation.CreatedAt != nil {
+		result.CreatedAt = invitation.CreatedAt.Format(time.RFC3339)
+	}
+	return result
+}
+
+// ListCollaborators creates a tool to list the collaborators of a repository with their roles.
+func ListCollaborators(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_collaborators",
+			mcp.WithDescription(t("TOOL_LIST_COLLABORATORS_DESCRIPTION", "List the users with access to a repository, and the role each of them has. For organization repositories this includes organization members with access through a team or a base permission.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_COLLABORATORS_USER_TITLE", "List collaborators"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("affiliation",
+				mcp.Description("Filter collaborators by how they are affiliated with the repository"),
+				mcp.Enum("outside", "direct", "all"),
+			),
+			mcp.WithString("permission",
+				mcp.Description("Only list collaborators with this permission"),
+				mcp.Enum(repositoryRoles...),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			affiliation, err := OptionalParam[string](request, "affiliation")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := OptionalParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			users, resp, err := client.Repositories.ListCollaborators(ctx, owner, repo, &github.ListCollaboratorsOptions{
+				Affiliation: affiliation,
+				Permission:  permission,
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list collaborators: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list collaborators: %s", string(body))), nil
+			}
+
+			collaborators := make([]Collaborator, 0, len(users))
+			for _, user := range users {
+				collaborators = append(collaborators, Collaborator{
+					Login:       user.GetLogin(),
+					HTMLURL:     user.GetHTMLURL(),
+					RoleName:    user.GetRoleName(),
+					Permissions: user.Permissions,
+				})
+			}
+
+			return MarshalledTextResult(collaborators), nil
+		}
+}
+
+// GetCollaboratorPermission creates a tool to check the permission a user has on a repository.
+func GetCollaboratorPermission(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_collaborator_permission",
+			mcp.WithDescription(t("TOOL_GET_COLLABORATOR_PERMISSION_DESCRIPTION", "Check the permission a user has on a repository, for example before assigning them to an issue or requesting their review. permission is one of admin, write, read or none; role_name is the exact role, such as maintain or triage.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COLLABORATOR_PERMISSION_USER_TITLE", "Get collaborator permission"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("GitHub login of the user"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := requiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			level, resp, err := client.Repositories.GetPermissionLevel(ctx, owner, repo, username)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get collaborator permission: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get collaborator permission: %s", string(body))), nil
+			}
+
+			permission := level.GetPermission()
+			return MarshalledTextResult(map[string]any{
+				"username":   username,
+				"permission": permission,
+				"role_name":  level.GetRoleName(),
+				"can_write":  permission == "write" || permission == "admin",
+			}), nil
+		}
+}
+
+// ListRepositoryInvitations creates a tool to list the pending collaborator invitations of a repository.
+func ListRepositoryInvitations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repository_invitations",
+			mcp.WithDescription(t("TOOL_LIST_REPOSITORY_INVITATIONS_DESCRIPTION", "List the pending invitations to collaborate on a repository. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPOSITORY_INVITATIONS_USER_TITLE", "List repository invitations"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			invitations, resp, err := client.Repositories.ListInvitations(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repository invitations: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list repository invitations: %s", string(body))), nil
+			}
+
+			result := make([]RepositoryInvitation, 0, len(invitations))
+			for _, invitation := range invitations {
+				result = append(result, newRepositoryInvitation(invitation))
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// ListRepositoryTeams creates a tool to list the teams with access to a repository.
+func ListRepositoryTeams(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repository_teams",
+			mcp.WithDescription(t("TOOL_LIST_REPOSITORY_TEAMS_DESCRIPTION", "List the teams with access to an organization repository, and the permission each of them has")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPOSITORY_TEAMS_USER_TITLE", "List repository teams"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			teams, resp, err := client.Repositories.ListTeams(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repository teams: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list repository teams: %s", string(body))), nil
+			}
+
+			type RepositoryTeam struct {
+				Slug       string `json:"slug"`
+				Name       string `json:"name"`
+				Permission string `json:"permission"`
+				HTMLURL    string `json:"html_url,omitempty"`
+			}
+
+			result := make([]RepositoryTeam, 0, len(teams))
+			for _, team := range teams {
+				result = append(result, RepositoryTeam{
+					Slug:       team.GetSlug(),
+					Name:       team.GetName(),
+					Permission: team.GetPermission(),
+					HTMLURL:    team.GetHTMLURL(),
+				})
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// AddCollaborator creates a tool to add a collaborator to a repository, or change the role of an existing one.
+func AddCollaborator(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_collaborator",
+			mcp.WithDescription(t("TOOL_ADD_COLLABORATOR_DESCRIPTION", "Invite a user to collaborate on a repository, or change the role of an existing collaborator. New collaborators get an invitation they need to accept. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_ADD_COLLABORATOR_USER_TITLE", "Add collaborator"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("GitHub login of the user"),
+			),
+			mcp.WithString("permission",
+				mcp.Description("Role to grant: pull, triage, push, maintain, admin, or the name of a custom organization role (default push)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := requiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := OptionalParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			invitation, resp, err := client.Repositories.AddCollaborator(ctx, owner, repo, username, &github.RepositoryAddCollaboratorOptions{
+				Permission: permission,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to add collaborator: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			switch resp.StatusCode {
+			case http.StatusCreated:
+				return MarshalledTextResult(map[string]any{
+					"status": "invited",
+					"invitation": newRepositoryInvitation(&github.RepositoryInvitation{
+						ID:          invitation.ID,
+						Invitee:     invitation.Invitee,
+						Inviter:     invitation.Inviter,
+						Permissions: invitation.Permissions,
+						CreatedAt:   invitation.CreatedAt,
+						HTMLURL:     invitation.HTMLURL,
+					}),
+				}), nil
+			case http.StatusNoContent:
+				// The user already had access, either directly or through an organization base permission.
+				return MarshalledTextResult(map[string]any{
+					"status": "updated",
+				}), nil
+			default:
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to add collaborator: %s", string(body))), nil
+			}
+		}
+}
+
+// RemoveCollaborator creates a tool to remove a collaborator from a repository.
+func RemoveCollaborator(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_collaborator",
+			mcp.WithDescription(t("TOOL_REMOVE_COLLABORATOR_DESCRIPTION", "Remove a collaborator from a repository. Access the user has through a team or as an organization member is not affected. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_COLLABORATOR_USER_TITLE", "Remove collaborator"),
+				ReadOnlyHint:    toBoolPtr(false),
+				DestructiveHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("GitHub login of the collaborator"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := requiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.RemoveCollaborator(ctx, owner, repo, username)
+			if err != nil {
+				return nil, fmt.Errorf("failed to remove collaborator: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to remove collaborator: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("removed %s from %s/%s", username, owner, repo)), nil
+		}
+}
+
+// SetTeamRepositoryAccess creates a tool to grant a team access to a repository, or change the access it has.
+func SetTeamRepositoryAccess(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_team_repository_access",
+			mcp.WithDescription(t("TOOL_SET_TEAM_REPOSITORY_ACCESS_DESCRIPTION", "Grant an organization team access to a repository, or change the role it has. Requires admin access to the repository and maintainer access to the team.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_SET_TEAM_REPOSITORY_ACCESS_USER_TITLE", "Set team repository access"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("org",
+				mcp.Description("Organization the team belongs to (defaults to owner)"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Slug of the team"),
+			),
+			mcp.WithString("permission",
+				mcp.Description("Role to grant: pull, triage, push, maintain, admin, or the name of a custom organization role (default push)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			org, err := OptionalParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if org == "" {
+				org = owner
+			}
+			teamSlug, err := requiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := OptionalParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if permission == "" {
+				permission = "push"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Teams.AddTeamRepoBySlug(ctx, org, teamSlug, owner, repo, &github.TeamAddTeamRepoOptions{
+				Permission: permission,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to set team repository access: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to set team repository access: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("granted team %s/%s %s access to %s/%s", org, teamSlug, permission, owner, repo)), nil
+		}
+}
+
+// RemoveTeamRepositoryAccess creates a tool to remove the access a team has to a repository.
+func RemoveTeamRepositoryAccess(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_team_repository_access",
+			mcp.WithDescription(t("TOOL_REMOVE_TEAM_REPOSITORY_ACCESS_DESCRIPTION", "Remove the access an organization team has to a repository. Team members may keep access through other teams or as collaborators.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_TEAM_REPOSITORY_ACCESS_USER_TITLE", "Remove team repository access"),
+				ReadOnlyHint:    toBoolPtr(false),
+				DestructiveHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("org",
+				mcp.Description("Organization the team belongs to (defaults to owner)"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Slug of the team"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			org, err := OptionalParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if org == "" {
+				org = owner
+			}
+			teamSlug, err := requiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Teams.RemoveTeamRepoBySlug(ctx, org, teamSlug, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to remove team repository access: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to remove team repository access: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("removed team %s/%s from %s/%s", org, teamSlug, owner, repo)), nil
+		}
+}
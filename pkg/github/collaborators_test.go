@@ -0,0 +1,353 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListCollaborators(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCollaborators(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_collaborators", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "affiliation")
+	assert.Contains(t, tool.InputSchema.Properties, "permission")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposCollaboratorsByOwnerByRepo,
+			expectQueryParams(t, map[string]string{
+				"affiliation": "direct",
+				"permission":  "push",
+				"page":        "1",
+				"per_page":    "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.User{
+					{
+						Login:       github.Ptr("alice"),
+						RoleName:    github.Ptr("maintain"),
+						Permissions: map[string]bool{"pull": true, "triage": true, "push": true, "maintain": true, "admin": false},
+					},
+				}),
+			),
+		),
+	))
+	_, handler := ListCollaborators(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":       "owner",
+		"repo":        "repo",
+		"affiliation": "direct",
+		"permission":  "push",
+	}))
+	require.NoError(t, err)
+
+	var returned []Collaborator
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned, 1)
+	assert.Equal(t, "alice", returned[0].Login)
+	assert.Equal(t, "maintain", returned[0].RoleName)
+	assert.True(t, returned[0].Permissions["push"])
+	assert.False(t, returned[0].Permissions["admin"])
+}
+
+func Test_GetCollaboratorPermission(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCollaboratorPermission(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_collaborator_permission", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "username"})
+
+	tests := []struct {
+		name           string
+		level          *github.RepositoryPermissionLevel
+		expectedResult string
+	}{
+		{
+			name: "maintainer can write",
+			level: &github.RepositoryPermissionLevel{
+				Permission: github.Ptr("write"),
+				RoleName:   github.Ptr("maintain"),
+			},
+			expectedResult: `{"username": "alice", "permission": "write", "role_name": "maintain", "can_write": true}`,
+		},
+		{
+			name: "triager cannot write",
+			level: &github.RepositoryPermissionLevel{
+				Permission: github.Ptr("read"),
+				RoleName:   github.Ptr("triage"),
+			},
+			expectedResult: `{"username": "alice", "permission": "read", "role_name": "triage", "can_write": false}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCollaboratorsPermissionByOwnerByRepoByUsername, tc.level),
+			))
+			_, handler := GetCollaboratorPermission(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"username": "alice",
+			}))
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expectedResult, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_ListRepositoryInvitations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepositoryInvitations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_repository_invitations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposInvitationsByOwnerByRepo,
+			[]*github.RepositoryInvitation{
+				{
+					ID:          github.Ptr(int64(7)),
+					Invitee:     &github.User{Login: github.Ptr("bob")},
+					Inviter:     &github.User{Login: github.Ptr("alice")},
+					Permissions: github.Ptr("write"),
+					CreatedAt:   &github.Timestamp{Time: createdAt},
+					Expired:     github.Ptr(true),
+				},
+			},
+		),
+	))
+	_, handler := ListRepositoryInvitations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+
+	var returned []RepositoryInvitation
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, []RepositoryInvitation{
+		{
+			ID:          7,
+			Invitee:     "bob",
+			Inviter:     "alice",
+			Permissions: "write",
+			CreatedAt:   "2025-01-02T03:04:05Z",
+			Expired:     true,
+		},
+	}, returned)
+}
+
+func Test_ListRepositoryTeams(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepositoryTeams(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_repository_teams", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposTeamsByOwnerByRepo,
+			[]*github.Team{
+				{Slug: github.Ptr("maintainers"), Name: github.Ptr("Maintainers"), Permission: github.Ptr("maintain")},
+			},
+		),
+	))
+	_, handler := ListRepositoryTeams(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"slug": "maintainers", "name": "Maintainers", "permission": "maintain"}]`, getTextResult(t, result).Text)
+}
+
+func Test_AddCollaborator(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddCollaborator(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_collaborator", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.Contains(t, tool.InputSchema.Properties, "permission")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "username"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectedResult string
+	}{
+		{
+			name: "new collaborator is invited",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					expectRequestBody(t, map[string]any{"permission": "triage"}).andThen(
+						mockResponse(t, http.StatusCreated, &github.CollaboratorInvitation{
+							ID:          github.Ptr(int64(7)),
+							Invitee:     &github.User{Login: github.Ptr("bob")},
+							Inviter:     &github.User{Login: github.Ptr("alice")},
+							Permissions: github.Ptr("triage"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"username":   "bob",
+				"permission": "triage",
+			},
+			expectedResult: `{"status": "invited", "invitation": {"id": 7, "invitee": "bob", "inviter": "alice", "permissions": "triage", "expired": false}}`,
+		},
+		{
+			name: "existing collaborator is updated",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					expectRequestBody(t, map[string]any{}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"username": "bob",
+			},
+			expectedResult: `{"status": "updated"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddCollaborator(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			require.False(t, result.IsError, textContent.Text)
+			assert.JSONEq(t, tc.expectedResult, textContent.Text)
+		})
+	}
+}
+
+func Test_RemoveCollaborator(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveCollaborator(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "remove_collaborator", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "username"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposCollaboratorsByOwnerByRepoByUsername,
+			expectPath(t, "/repos/owner/repo/collaborators/bob").andThen(
+				mockResponse(t, http.StatusNoContent, nil),
+			),
+		),
+	))
+	_, handler := RemoveCollaborator(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":    "owner",
+		"repo":     "repo",
+		"username": "bob",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "removed bob from owner/repo", getTextResult(t, result).Text)
+}
+
+func Test_SetTeamRepositoryAccess(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetTeamRepositoryAccess(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_team_repository_access", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "permission")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "team_slug"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PutOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+			expectPath(t, "/orgs/owner/teams/maintainers/repos/owner/repo").andThen(
+				expectRequestBody(t, map[string]any{"permission": "push"}).andThen(
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+		),
+	))
+	_, handler := SetTeamRepositoryAccess(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":     "owner",
+		"repo":      "repo",
+		"team_slug": "maintainers",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "granted team owner/maintainers push access to owner/repo", getTextResult(t, result).Text)
+}
+
+func Test_RemoveTeamRepositoryAccess(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveTeamRepositoryAccess(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "remove_team_repository_access", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "team_slug"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+			expectPath(t, "/orgs/acme/teams/maintainers/repos/owner/repo").andThen(
+				mockResponse(t, http.StatusNoContent, nil),
+			),
+		),
+	))
+	_, handler := RemoveTeamRepositoryAccess(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":     "owner",
+		"repo":      "repo",
+		"org":       "acme",
+		"team_slug": "maintainers",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "removed team acme/maintainers from owner/repo", getTextResult(t, result).Text)
+}
@@ -0,0 +1,282 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	MethodCompletionComplete = "completion/complete"
+
+	// maxCompletionValues is the most values a completion result may hold.
+	maxCompletionValues = 100
+
+	// completionCacheTTL is how long looked up completion values are reused within a session.
+	completionCacheTTL = 5 * time.Minute
+)
+
+// Completions suggests values for the arguments of prompts and resource templates: the user's
+// login and organizations for owner, their recently pushed repositories for repo, branches and
+// tags of the repository, and toolset names. Values looked up from GitHub are cached per session.
+type Completions struct {
+	server    *server.MCPServer
+	getClient GetClientFn
+	toolsets  *toolsets.ToolsetGroup
+
+	mu    sync.Mutex
+	cache map[string]map[string]cachedCompletion // by session ID, then lookup
+}
+
+type cachedCompletion struct {
+	values    []string
+	fetchedAt time.Time
+}
+
+// NewCompletions creates completion support for the prompts and resource templates offered by s.
+// Toolset names are taken from tsg.
+func NewCompletions(s *server.MCPServer, getClient GetClientFn, tsg *toolsets.ToolsetGroup) *Completions {
+	return &Completions{
+		server:    s,
+		getClient: getClient,
+		toolsets:  tsg,
+		cache:     make(map[string]map[string]cachedCompletion),
+	}
+}
+
+// ForgetSession drops the values cached for a session, e.g. when the session ends.
+func (c *Completions) ForgetSession(sessionID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.cache, sessionID)
+}
+
+// HandleMessage answers completion/complete requests, which the MCP server does not route to a
+// handler itself, and adds the completions capability to the server's answer to initialize, which
+// it has no option for. It reports whether message was one of these.
+func (c *Completions) HandleMessage(ctx context.Context, sessionID string, message json.RawMessage) (mcp.JSONRPCMessage, bool) {
+	var request struct {
+		ID     mcp.RequestId `json:"id"`
+		Method string        `json:"method"`
+		Params struct {
+			Argument struct {
+				Name  string `json:"name"`
+				Value string `json:"value"`
+			} `json:"argument"`
+			// Context holds the values of the other arguments that have already been given
+			Context struct {
+				Arguments map[string]string `json:"arguments"`
+			} `json:"context"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(message, &request); err != nil {
+		return nil, false
+	}
+	if request.Method == string(mcp.MethodInitialize) {
+		return c.initialize(ctx, message), true
+	}
+	if request.Method != MethodCompletionComplete {
+		return nil, false
+	}
+
+	values, err := c.Complete(ctx, sessionID, request.Params.Argument.Name, request.Params.Argument.Value, request.Params.Context.Arguments)
+	if err != nil {
+		return mcp.NewJSONRPCError(request.ID, mcp.INTERNAL_ERROR, err.Error(), nil), true
+	}
+
+	result := mcp.CompleteResult{}
+	result.Completion.Values = values
+	result.Completion.Total = len(values)
+	if len(values) > maxCompletionValues {
+		result.Completion.Values = values[:maxCompletionValues]
+		result.Completion.HasMore = true
+	}
+	return mcp.JSONRPCResponse{JSONRPC: mcp.JSONRPC_VERSION, ID: request.ID, Result: result}, true
+}
+
+// Complete returns the suggestions for the argument called name that start with value.
+// arguments holds the other arguments already given, which branch, tag and repo suggestions depend on.
+func (c *Completions) Complete(ctx context.Context, sessionID, name, value string, arguments map[string]string) ([]string, error) {
+	var candidates []string
+	var err error
+	owner, repo := arguments["owner"], arguments["repo"]
+
+	switch name {
+	case "owner":
+		candidates, err = c.owners(ctx, sessionID)
+	case "repo":
+		candidates, err = c.repos(ctx, sessionID, owner)
+	case "branch":
+		if owner == "" || repo == "" {
+			return []string{}, nil
+		}
+		candidates, err = c.cached(ctx, sessionID, "branches:"+owner+"/"+repo, func(ctx context.Context, client *github.Client) ([]string, error) {
+			branches, _, err := client.Repositories.ListBranches(ctx, owner, repo, &github.BranchListOptions{
+				ListOptions: github.ListOptions{PerPage: 100},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list branches: %w", err)
+			}
+			names := make([]string, 0, len(branches))
+			for _, branch := range branches {
+				names = append(names, branch.GetName())
+			}
+			return names, nil
+		})
+	case "tag":
+		if owner == "" || repo == "" {
+			return []string{}, nil
+		}
+		candidates, err = c.cached(ctx, sessionID, "tags:"+owner+"/"+repo, func(ctx context.Context, client *github.Client) ([]string, error) {
+			tags, _, err := client.Repositories.ListTags(ctx, owner, repo, &github.ListOptions{PerPage: 100})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list tags: %w", err)
+			}
+			names := make([]string, 0, len(tags))
+			for _, tag := range tags {
+				names = append(names, tag.GetName())
+			}
+			return names, nil
+		})
+	case "toolset":
+		for toolsetName := range c.toolsets.Toolsets {
+			candidates = append(candidates, toolsetName)
+		}
+		sort.Strings(candidates)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []string{}
+	for _, candidate := range candidates {
+		if strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(value)) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches, nil
+}
+
+// initializeResult is the answer to initialize with the completions capability added.
+type initializeResult struct {
+	mcp.InitializeResult
+	Capabilities serverCapabilities `json:"capabilities"`
+}
+
+type serverCapabilities struct {
+	mcp.ServerCapabilities
+	Completions *struct{} `json:"completions,omitempty"`
+}
+
+// initialize has the server answer an initialize request, and adds the completions capability to
+// the capabilities it advertises.
+func (c *Completions) initialize(ctx context.Context, message json.RawMessage) mcp.JSONRPCMessage {
+	response := c.server.HandleMessage(ctx, message)
+	initialized, ok := response.(mcp.JSONRPCResponse)
+	if !ok {
+		return response
+	}
+	result, ok := initialized.Result.(mcp.InitializeResult)
+	if !ok {
+		return response
+	}
+	initialized.Result = initializeResult{
+		InitializeResult: result,
+		Capabilities: serverCapabilities{
+			ServerCapabilities: result.Capabilities,
+			Completions:        &struct{}{},
+		},
+	}
+	return initialized
+}
+
+// owners returns the user's login, followed by the organizations they belong to.
+func (c *Completions) owners(ctx context.Context, sessionID string) ([]string, error) {
+	return c.cached(ctx, sessionID, "owners", func(ctx context.Context, client *github.Client) ([]string, error) {
+		user, _, err := client.Users.Get(ctx, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		orgs, _, err := client.Organizations.List(ctx, "", &github.ListOptions{PerPage: 100})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list organizations: %w", err)
+		}
+		owners := []string{user.GetLogin()}
+		for _, org := range orgs {
+			owners = append(owners, org.GetLogin())
+		}
+		return owners, nil
+	})
+}
+
+// repos returns the names of the repositories the user most recently pushed to, most recent first.
+// If owner is given, only that owner's repositories are returned.
+func (c *Completions) repos(ctx context.Context, sessionID, owner string) ([]string, error) {
+	fullNames, err := c.cached(ctx, sessionID, "repos", func(ctx context.Context, client *github.Client) ([]string, error) {
+		repos, _, err := client.Repositories.ListByAuthenticatedUser(ctx, &github.RepositoryListByAuthenticatedUserOptions{
+			Sort:        "pushed",
+			ListOptions: github.ListOptions{PerPage: 100},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories: %w", err)
+		}
+		fullNames := make([]string, 0, len(repos))
+		for _, repo := range repos {
+			fullNames = append(fullNames, repo.GetFullName())
+		}
+		return fullNames, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, fullName := range fullNames {
+		repoOwner, name, _ := strings.Cut(fullName, "/")
+		if owner != "" && !strings.EqualFold(owner, repoOwner) {
+			continue
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// cached returns the values stored for a session under key, looking them up with fetch if they
+// are missing or have expired.
+func (c *Completions) cached(ctx context.Context, sessionID, key string, fetch func(context.Context, *github.Client) ([]string, error)) ([]string, error) {
+	c.mu.Lock()
+	entry, ok := c.cache[sessionID][key]
+	c.mu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < completionCacheTTL {
+		return entry.values, nil
+	}
+
+	client, err := c.getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+	values, err := fetch(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cache[sessionID] == nil {
+		c.cache[sessionID] = make(map[string]cachedCompletion)
+	}
+	c.cache[sessionID][key] = cachedCompletion{values: values, fetchedAt: time.Now()}
+	return values, nil
+}
@@ -0,0 +1,192 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Completions_Complete(t *testing.T) {
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("issues", "Issues"))
+	tsg.AddToolset(toolsets.NewToolset("pull_requests", "Pull requests"))
+	tsg.AddToolset(toolsets.NewToolset("repos", "Repositories"))
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetUser,
+			&github.User{Login: github.Ptr("octocat")},
+		),
+		mock.WithRequestMatch(
+			mock.GetUserOrgs,
+			[]*github.Organization{{Login: github.Ptr("octo-org")}, {Login: github.Ptr("github")}},
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetUserRepos,
+			expectQueryParams(t, map[string]string{"sort": "pushed", "per_page": "100"}).andThen(
+				mockResponse(t, http.StatusOK, []*github.Repository{
+					{FullName: github.Ptr("octocat/hello-world")},
+					{FullName: github.Ptr("octo-org/octo-repo")},
+					{FullName: github.Ptr("octocat/spoon-knife")},
+				}),
+			),
+		),
+		mock.WithRequestMatch(
+			mock.GetReposBranchesByOwnerByRepo,
+			[]*github.Branch{{Name: github.Ptr("main")}, {Name: github.Ptr("feature")}},
+		),
+		mock.WithRequestMatch(
+			mock.GetReposTagsByOwnerByRepo,
+			[]*github.RepositoryTag{{Name: github.Ptr("v1.0.0")}, {Name: github.Ptr("v1.1.0")}},
+		),
+	)
+	c := NewCompletions(server.NewMCPServer("test", "1.0"), stubGetClientFn(github.NewClient(mockedClient)), tsg)
+
+	tests := []struct {
+		name      string
+		argument  string
+		value     string
+		arguments map[string]string
+		expected  []string
+	}{
+		{
+			name:     "owners include the user and their organizations",
+			argument: "owner",
+			value:    "OCTO",
+			expected: []string{"octocat", "octo-org"},
+		},
+		{
+			name:     "repos are most recently pushed first",
+			argument: "repo",
+			expected: []string{"hello-world", "octo-repo", "spoon-knife"},
+		},
+		{
+			name:      "repos are filtered by owner",
+			argument:  "repo",
+			arguments: map[string]string{"owner": "octocat"},
+			expected:  []string{"hello-world", "spoon-knife"},
+		},
+		{
+			name:      "branches",
+			argument:  "branch",
+			value:     "fe",
+			arguments: map[string]string{"owner": "octocat", "repo": "hello-world"},
+			expected:  []string{"feature"},
+		},
+		{
+			name:     "branches need the repository",
+			argument: "branch",
+			expected: []string{},
+		},
+		{
+			name:      "tags",
+			argument:  "tag",
+			value:     "v1.1",
+			arguments: map[string]string{"owner": "octocat", "repo": "hello-world"},
+			expected:  []string{"v1.1.0"},
+		},
+		{
+			name:     "toolsets",
+			argument: "toolset",
+			value:    "p",
+			expected: []string{"pull_requests"},
+		},
+		{
+			name:     "unknown argument",
+			argument: "pullNumber",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := c.Complete(context.Background(), "session", tc.argument, tc.value, tc.arguments)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, values)
+		})
+	}
+
+	// Looked up values are cached for the session, so the mocks, which only answer once, are not called again
+	values, err := c.Complete(context.Background(), "session", "owner", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"octocat", "octo-org", "github"}, values)
+
+	c.ForgetSession("session")
+	_, err = c.Complete(context.Background(), "session", "owner", "", nil)
+	require.Error(t, err)
+}
+
+func Test_Completions_HandleMessage(t *testing.T) {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetUser,
+			&github.User{Login: github.Ptr("octocat")},
+		),
+		mock.WithRequestMatch(
+			mock.GetUserOrgs,
+			[]*github.Organization{{Login: github.Ptr("octo-org")}},
+		),
+	)
+	c := NewCompletions(server.NewMCPServer("test", "1.0"), stubGetClientFn(github.NewClient(mockedClient)), toolsets.NewToolsetGroup(false))
+
+	response, handled := c.HandleMessage(context.Background(), "session", json.RawMessage(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "completion/complete",
+		"params": {
+			"ref": {"type": "ref/prompt", "name": "review_pull_request"},
+			"argument": {"name": "owner", "value": "octo-"}
+		}
+	}`))
+	require.True(t, handled)
+	require.IsType(t, mcp.JSONRPCResponse{}, response)
+	result, ok := response.(mcp.JSONRPCResponse).Result.(mcp.CompleteResult)
+	require.True(t, ok)
+	assert.Equal(t, []string{"octo-org"}, result.Completion.Values)
+	assert.Equal(t, 1, result.Completion.Total)
+	assert.False(t, result.Completion.HasMore)
+
+	_, handled = c.HandleMessage(context.Background(), "session", json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"ping"}`))
+	assert.False(t, handled)
+}
+
+func Test_Completions_AdvertisedAtInitialize(t *testing.T) {
+	c := NewCompletions(server.NewMCPServer("test", "1.0", server.WithToolCapabilities(true)), stubGetClientFn(nil), toolsets.NewToolsetGroup(false))
+
+	response, handled := c.HandleMessage(context.Background(), "session", json.RawMessage(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "initialize",
+		"params": {
+			"protocolVersion": "2025-03-26",
+			"capabilities": {},
+			"clientInfo": {"name": "test", "version": "1.0"}
+		}
+	}`))
+	require.True(t, handled)
+
+	b, err := json.Marshal(response)
+	require.NoError(t, err)
+	var initialized struct {
+		Result struct {
+			ProtocolVersion string                     `json:"protocolVersion"`
+			Capabilities    map[string]json.RawMessage `json:"capabilities"`
+			ServerInfo      mcp.Implementation         `json:"serverInfo"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(b, &initialized))
+	assert.Equal(t, "2025-03-26", initialized.Result.ProtocolVersion)
+	assert.Equal(t, "test", initialized.Result.ServerInfo.Name)
+	assert.JSONEq(t, `{}`, string(initialized.Result.Capabilities["completions"]))
+	// The capabilities the server advertises itself are kept
+	assert.JSONEq(t, `{"listChanged":true}`, string(initialized.Result.Capabilities["tools"]))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -55,6 +56,13 @@ func EnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t t
 			// s.sendNotificationToAllClients("notifications/tools/list_changed", nil)
 			s.AddTools(toolset.GetActiveTools()...)
 
+			// Offer any prompts that needed this toolset and have nothing else left to wait for
+			for _, prompt := range toolsetGroup.GetAvailablePrompts() {
+				if slices.Contains(prompt.RequiredToolsets, toolsetName) {
+					s.AddPrompt(prompt.Prompt, prompt.Handler)
+				}
+			}
+
 			return mcp.NewToolResultText(fmt.Sprintf("Toolset %s enabled", toolsetName)), nil
 		}
 }
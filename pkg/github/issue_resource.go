@@ -0,0 +1,164 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetIssueResource defines the resource template and handler for reading an issue and its comments as markdown.
+func GetIssueResource(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"repo://{owner}/{repo}/issues/{number}", // Resource template
+			t("RESOURCE_ISSUE_DESCRIPTION", "Issue with its comments"),
+			mcp.WithTemplateMIMEType("text/markdown"),
+		),
+		IssueResourceHandler(getClient)
+}
+
+// IssueResourceHandler returns a handler function for issue resource requests.
+func IssueResourceHandler(getClient GetClientFn) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		owner, repo, number, err := resourceIssueArguments(request)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		// The number of pages of comments isn't known up front, so there is no total
+		progress := newRequestProgressReporter(ctx, 0)
+
+		issue, _, err := client.Issues.Get(ctx, owner, repo, number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue: %w", err)
+		}
+		progress.Step(fmt.Sprintf("Got issue #%d", number))
+
+		comments, err := listAllIssueComments(ctx, client, owner, repo, number, progress)
+		if err != nil {
+			return nil, err
+		}
+
+		var b strings.Builder
+		writeIssueMarkdown(&b, issue)
+		writeIssueCommentsMarkdown(&b, comments)
+
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "text/markdown",
+				Text:     b.String(),
+			},
+		}, nil
+	}
+}
+
+// resourceArgument returns the value of a resource template variable.
+// The matcher gives each variable as a []string, with one element for simple variables.
+func resourceArgument(request mcp.ReadResourceRequest, name string) (string, error) {
+	v, ok := request.Params.Arguments[name].([]string)
+	if !ok || len(v) == 0 || v[0] == "" {
+		return "", fmt.Errorf("%s is required", name)
+	}
+	return v[0], nil
+}
+
+// resourceIssueArguments returns the owner, repo and number variables shared by the issue and pull request templates.
+func resourceIssueArguments(request mcp.ReadResourceRequest) (owner, repo string, number int, err error) {
+	if owner, err = resourceArgument(request, "owner"); err != nil {
+		return "", "", 0, err
+	}
+	if repo, err = resourceArgument(request, "repo"); err != nil {
+		return "", "", 0, err
+	}
+	n, err := resourceArgument(request, "number")
+	if err != nil {
+		return "", "", 0, err
+	}
+	number, err = strconv.Atoi(n)
+	if err != nil || number <= 0 {
+		return "", "", 0, errors.New("number must be a positive integer")
+	}
+	return owner, repo, number, nil
+}
+
+func listAllIssueComments(ctx context.Context, client *github.Client, owner, repo string, number int, progress *progressReporter) ([]*github.IssueComment, error) {
+	opts := &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	var all []*github.IssueComment
+	for {
+		comments, resp, err := client.Issues.ListComments(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue comments: %w", err)
+		}
+		all = append(all, comments...)
+		progress.Step(fmt.Sprintf("Fetched %d comments", len(all)))
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func writeIssueMarkdown(b *strings.Builder, issue *github.Issue) {
+	fmt.Fprintf(b, "# %s (#%d)\n\n", issue.GetTitle(), issue.GetNumber())
+	fmt.Fprintf(b, "- **State:** %s\n", issue.GetState())
+	if reason := issue.GetStateReason(); reason != "" {
+		fmt.Fprintf(b, "- **State reason:** %s\n", reason)
+	}
+	fmt.Fprintf(b, "- **Author:** @%s\n", issue.GetUser().GetLogin())
+	fmt.Fprintf(b, "- **Created:** %s\n", formatResourceTime(issue.GetCreatedAt()))
+	if len(issue.Labels) > 0 {
+		labels := make([]string, 0, len(issue.Labels))
+		for _, label := range issue.Labels {
+			labels = append(labels, label.GetName())
+		}
+		fmt.Fprintf(b, "- **Labels:** %s\n", strings.Join(labels, ", "))
+	}
+	if len(issue.Assignees) > 0 {
+		assignees := make([]string, 0, len(issue.Assignees))
+		for _, assignee := range issue.Assignees {
+			assignees = append(assignees, "@"+assignee.GetLogin())
+		}
+		fmt.Fprintf(b, "- **Assignees:** %s\n", strings.Join(assignees, ", "))
+	}
+	if issue.Milestone != nil {
+		fmt.Fprintf(b, "- **Milestone:** %s\n", issue.GetMilestone().GetTitle())
+	}
+	fmt.Fprintf(b, "- **URL:** %s\n\n", issue.GetHTMLURL())
+	writeMarkdownBody(b, issue.GetBody())
+}
+
+func writeIssueCommentsMarkdown(b *strings.Builder, comments []*github.IssueComment) {
+	fmt.Fprintf(b, "## Comments (%d)\n\n", len(comments))
+	for _, comment := range comments {
+		fmt.Fprintf(b, "### @%s on %s\n\n", comment.GetUser().GetLogin(), formatResourceTime(comment.GetCreatedAt()))
+		writeMarkdownBody(b, comment.GetBody())
+	}
+}
+
+func writeMarkdownBody(b *strings.Builder, body string) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		body = "_No description provided._"
+	}
+	b.WriteString(body)
+	b.WriteString("\n\n")
+}
+
+func formatResourceTime(ts github.Timestamp) string {
+	return ts.UTC().Format(time.RFC3339)
+}
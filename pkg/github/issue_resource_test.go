@@ -0,0 +1,155 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetIssueResource(t *testing.T) {
+	tmpl, _ := GetIssueResource(nil, translations.NullTranslationHelper)
+	require.Equal(t, "repo://{owner}/{repo}/issues/{number}", tmpl.URITemplate.Raw())
+	require.Equal(t, "text/markdown", tmpl.MIMEType)
+}
+
+func Test_IssueResourceHandler(t *testing.T) {
+	created := github.Timestamp{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	mockIssue := &github.Issue{
+		Number:    github.Ptr(42),
+		Title:     github.Ptr("Crash on startup"),
+		Body:      github.Ptr("It crashes."),
+		State:     github.Ptr("open"),
+		HTMLURL:   github.Ptr("https://github.com/owner/repo/issues/42"),
+		User:      &github.User{Login: github.Ptr("reporter")},
+		CreatedAt: &created,
+		Labels:    []*github.Label{{Name: github.Ptr("bug")}},
+		Assignees: []*github.User{{Login: github.Ptr("maintainer")}},
+	}
+	mockComments := []*github.IssueComment{
+		{
+			Body:      github.Ptr("Can you share the logs?"),
+			User:      &github.User{Login: github.Ptr("maintainer")},
+			CreatedAt: &created,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    string
+		expectedText   []string
+		unexpectedText []string
+	}{
+		{
+			name: "renders issue with comments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockIssue,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					expectQueryParams(t, map[string]string{"per_page": "100"}).andThen(
+						mockResponse(t, http.StatusOK, mockComments),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"number": []string{"42"},
+			},
+			expectedText: []string{
+				"# Crash on startup (#42)",
+				"- **Author:** @reporter",
+				"- **Labels:** bug",
+				"- **Assignees:** @maintainer",
+				"It crashes.",
+				"## Comments (1)",
+				"### @maintainer on 2024-05-01T12:00:00Z",
+				"Can you share the logs?",
+			},
+			unexpectedText: []string{"Milestone"},
+		},
+		{
+			name:         "missing number",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+			},
+			expectError: "number is required",
+		},
+		{
+			name:         "invalid number",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"number": []string{"abc"},
+			},
+			expectError: "number must be a positive integer",
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"number": []string{"999"},
+			},
+			expectError: "failed to get issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			handler := IssueResourceHandler(stubGetClientFn(client))
+
+			request := mcp.ReadResourceRequest{
+				Params: struct {
+					URI       string         `json:"uri"`
+					Arguments map[string]any `json:"arguments,omitempty"`
+				}{
+					URI:       "repo://owner/repo/issues/42",
+					Arguments: tc.requestArgs,
+				},
+			}
+
+			resp, err := handler(context.TODO(), request)
+
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, resp, 1)
+			content, ok := resp[0].(mcp.TextResourceContents)
+			require.True(t, ok)
+			assert.Equal(t, "text/markdown", content.MIMEType)
+			assert.Equal(t, "repo://owner/repo/issues/42", content.URI)
+			for _, expected := range tc.expectedText {
+				assert.Contains(t, content.Text, expected)
+			}
+			for _, unexpected := range tc.unexpectedText {
+				assert.NotContains(t, content.Text, unexpected)
+			}
+		})
+	}
+}
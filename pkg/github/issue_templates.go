@@ -0,0 +1,437 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+// issueTemplateDirs are the directories GitHub looks for issue templates in, in order of precedence.
+var issueTemplateDirs = []string{".github/ISSUE_TEMPLATE", "ISSUE_TEMPLATE"}
+
+// IssueTemplate is an issue template or issue form of a repository.
+type IssueTemplate struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	File        string `json:"file"`
+	// Kind is "form" for YAML issue forms and "markdown" for Markdown templates.
+	Kind      string               `json:"kind"`
+	Title     string               `json:"title,omitempty"`
+	Labels    []string             `json:"labels,omitempty"`
+	Assignees []string             `json:"assignees,omitempty"`
+	Fields    []IssueTemplateField `json:"fields,omitempty"`
+	Body      string               `json:"body,omitempty"`
+}
+
+// IssueTemplateField is an input of an issue form. Markdown elements of the form are not fields,
+// since they are only shown while filling in the form and are not part of the issue body.
+type IssueTemplateField struct {
+	ID              string   `json:"id"`
+	Type            string   `json:"type"`
+	Label           string   `json:"label"`
+	Description     string   `json:"description,omitempty"`
+	Required        bool     `json:"required"`
+	Options         []string `json:"options,omitempty"`
+	RequiredOptions []string `json:"required_options,omitempty"`
+	Multiple        bool     `json:"multiple,omitempty"`
+	Render          string   `json:"render,omitempty"`
+	Default         string   `json:"default,omitempty"`
+}
+
+// yamlStringList is a list of strings in a template, which may also be written as a comma separated string.
+type yamlStringList []string
+
+func (l *yamlStringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = nil
+		for _, s := range strings.Split(node.Value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				*l = append(*l, s)
+			}
+		}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// issueFormOption is an option of a dropdown, written as a string, or of a checkboxes element, written as a mapping.
+type issueFormOption struct {
+	Label    string `yaml:"label"`
+	Required bool   `yaml:"required"`
+}
+
+func (o *issueFormOption) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		o.Label = node.Value
+		return nil
+	}
+	type plain issueFormOption
+	return node.Decode((*plain)(o))
+}
+
+type issueForm struct {
+	Name        string         `yaml:"name"`
+	Description string         `yaml:"description"`
+	Title       string         `yaml:"title"`
+	Labels      yamlStringList `yaml:"labels"`
+	Assignees   yamlStringList `yaml:"assignees"`
+	Body        []struct {
+		Type       string `yaml:"type"`
+		ID         string `yaml:"id"`
+		Attributes struct {
+			Label       string            `yaml:"label"`
+			Description string            `yaml:"description"`
+			Value       string            `yaml:"value"`
+			Render      string            `yaml:"render"`
+			Multiple    bool              `yaml:"multiple"`
+			Options     []issueFormOption `yaml:"options"`
+			Default     *int              `yaml:"default"`
+		} `yaml:"attributes"`
+		Validations struct {
+			Required bool `yaml:"required"`
+		} `yaml:"validations"`
+	} `yaml:"body"`
+}
+
+type markdownTemplateFrontMatter struct {
+	Name      string         `yaml:"name"`
+	About     string         `yaml:"about"`
+	Title     string         `yaml:"title"`
+	Labels    yamlStringList `yaml:"labels"`
+	Assignees yamlStringList `yaml:"assignees"`
+}
+
+// parseIssueForm parses a YAML issue form.
+func parseIssueForm(file string, content []byte) (IssueTemplate, error) {
+	var form issueForm
+	if err := yaml.Unmarshal(content, &form); err != nil {
+		return IssueTemplate{}, fmt.Errorf("failed to parse issue form %s: %w", file, err)
+	}
+
+	template := IssueTemplate{
+		Name:        form.Name,
+		Description: form.Description,
+		File:        file,
+		Kind:        "form",
+		Title:       form.Title,
+		Labels:      form.Labels,
+		Assignees:   form.Assignees,
+	}
+	for _, element := range form.Body {
+		if element.Type == "markdown" {
+			continue
+		}
+		field := IssueTemplateField{
+			ID:          element.ID,
+			Type:        element.Type,
+			Label:       element.Attributes.Label,
+			Description: element.Attributes.Description,
+			Required:    element.Validations.Required,
+			Multiple:    element.Attributes.Multiple,
+			Render:      element.Attributes.Render,
+			Default:     element.Attributes.Value,
+		}
+		if field.ID == "" {
+			field.ID = field.Label
+		}
+		for _, option := range element.Attributes.Options {
+			field.Options = append(field.Options, option.Label)
+			if option.Required {
+				field.RequiredOptions = append(field.RequiredOptions, option.Label)
+			}
+		}
+		if d := element.Attributes.Default; d != nil && *d >= 0 && *d < len(field.Options) {
+			field.Default = field.Options[*d]
+		}
+		template.Fields = append(template.Fields, field)
+	}
+	return template, nil
+}
+
+// parseMarkdownTemplate parses a Markdown issue template and its YAML front matter.
+func parseMarkdownTemplate(file string, content []byte) (IssueTemplate, error) {
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	template := IssueTemplate{File: file, Kind: "markdown", Body: text}
+
+	if rest, ok := strings.CutPrefix(text, "---\n"); ok {
+		frontMatter, body, found := strings.Cut(rest, "\n---")
+		if found {
+			var meta markdownTemplateFrontMatter
+			if err := yaml.Unmarshal([]byte(frontMatter), &meta); err != nil {
+				return IssueTemplate{}, fmt.Errorf("failed to parse front matter of %s: %w", file, err)
+			}
+			template.Name = meta.Name
+			template.Description = meta.About
+			template.Title = meta.Title
+			template.Labels = meta.Labels
+			template.Assignees = meta.Assignees
+			// Drop the rest of the closing "---" line.
+			_, body, _ = strings.Cut(body, "\n")
+			template.Body = strings.TrimLeft(body, "\n")
+		}
+	}
+	if template.Name == "" {
+		template.Name = strings.TrimSuffix(file, path.Ext(file))
+	}
+	return template, nil
+}
+
+// loadIssueTemplates reads the issue templates of a repository. Like GitHub, it falls back to the
+// templates of the owner's .github repository when the repository has none of its own. It returns
+// the templates along with the repository they were read from.
+func loadIssueTemplates(ctx context.Context, client *github.Client, owner, repo string) ([]IssueTemplate, string, error) {
+	for _, source := range []string{repo, ".github"} {
+		for _, dir := range issueTemplateDirs {
+			_, entries, resp, err := client.Repositories.GetContents(ctx, owner, source, dir, nil)
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to list issue templates in %s/%s: %w", owner, source, err)
+			}
+			_ = resp.Body.Close()
+
+			var templates []IssueTemplate
+			for _, entry := range entries {
+				name := entry.GetName()
+				ext := strings.ToLower(path.Ext(name))
+				if entry.GetType() != "file" || (ext != ".yml" && ext != ".yaml" && ext != ".md") {
+					continue
+				}
+				// config.yml configures the template chooser, it is not a template itself.
+				if strings.TrimSuffix(strings.ToLower(name), ext) == "config" {
+					continue
+				}
+
+				file, _, resp, err := client.Repositories.GetContents(ctx, owner, source, entry.GetPath(), nil)
+				if err != nil {
+					return nil, "", fmt.Errorf("failed to get issue template %s: %w", entry.GetPath(), err)
+				}
+				_ = resp.Body.Close()
+				content, err := file.GetContent()
+				if err != nil {
+					return nil, "", fmt.Errorf("failed to decode issue template %s: %w", entry.GetPath(), err)
+				}
+
+				var template IssueTemplate
+				if ext == ".md" {
+					template, err = parseMarkdownTemplate(name, []byte(content))
+				} else {
+					template, err = parseIssueForm(name, []byte(content))
+				}
+				if err != nil {
+					return nil, "", err
+				}
+				templates = append(templates, template)
+			}
+			if len(templates) > 0 {
+				return templates, owner + "/" + source, nil
+			}
+		}
+	}
+	return nil, "", nil
+}
+
+// findIssueTemplate looks up a template by its name or by its file name, with or without extension.
+func findIssueTemplate(templates []IssueTemplate, name string) (IssueTemplate, bool) {
+	for _, template := range templates {
+		if strings.EqualFold(template.Name, name) ||
+			strings.EqualFold(template.File, name) ||
+			strings.EqualFold(strings.TrimSuffix(template.File, path.Ext(template.File)), name) {
+			return template, true
+		}
+	}
+	return IssueTemplate{}, false
+}
+
+// fieldValues converts the value given for a dropdown or checkboxes field, a string or a list of strings, to a list.
+func fieldValues(field IssueTemplateField, value any) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if v == "" {
+			return nil, nil
+		}
+		return []string{v}, nil
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("field %q: values must be strings", field.ID)
+			}
+			values = append(values, s)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("field %q: expected a string or a list of strings, got %T", field.ID, value)
+	}
+}
+
+// renderIssueForm validates the values given for the fields of an issue form, keyed by field ID or label,
+// and renders the issue body the way the issue form on github.com does.
+func renderIssueForm(template IssueTemplate, values map[string]any) (string, error) {
+	known := make(map[string]bool, len(template.Fields)*2)
+	for _, field := range template.Fields {
+		known[field.ID] = true
+		known[field.Label] = true
+	}
+	for key := range values {
+		if !known[key] {
+			ids := make([]string, 0, len(template.Fields))
+			for _, field := range template.Fields {
+				ids = append(ids, field.ID)
+			}
+			return "", fmt.Errorf("unknown field %q for template %q, expected one of: %s", key, template.Name, strings.Join(ids, ", "))
+		}
+	}
+
+	var errs []error
+	sections := make([]string, 0, len(template.Fields))
+	for _, field := range template.Fields {
+		value, ok := values[field.ID]
+		if !ok {
+			value, ok = values[field.Label]
+		}
+		if !ok && field.Default != "" {
+			value, ok = field.Default, true
+		}
+
+		var rendered string
+		switch field.Type {
+		case "checkboxes":
+			checked, err := fieldValues(field, value)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			for _, label := range checked {
+				if !slices.Contains(field.Options, label) {
+					errs = append(errs, fmt.Errorf("field %q: %q is not one of the options: %s", field.ID, label, strings.Join(field.Options, ", ")))
+				}
+			}
+			for _, label := range field.RequiredOptions {
+				if !slices.Contains(checked, label) {
+					errs = append(errs, fmt.Errorf("field %q: option %q must be checked", field.ID, label))
+				}
+			}
+			lines := make([]string, 0, len(field.Options))
+			for _, label := range field.Options {
+				mark := " "
+				if slices.Contains(checked, label) {
+					mark = "X"
+				}
+				lines = append(lines, fmt.Sprintf("- [%s] %s", mark, label))
+			}
+			rendered = strings.Join(lines, "\n")
+		case "dropdown":
+			selected, err := fieldValues(field, value)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if len(selected) > 1 && !field.Multiple {
+				errs = append(errs, fmt.Errorf("field %q: only one option can be selected", field.ID))
+			}
+			for _, option := range selected {
+				if !slices.Contains(field.Options, option) {
+					errs = append(errs, fmt.Errorf("field %q: %q is not one of the options: %s", field.ID, option, strings.Join(field.Options, ", ")))
+				}
+			}
+			rendered = strings.Join(selected, ", ")
+		default:
+			s, isString := value.(string)
+			if ok && !isString {
+				errs = append(errs, fmt.Errorf("field %q: expected a string, got %T", field.ID, value))
+				continue
+			}
+			rendered = strings.TrimSpace(s)
+			if rendered != "" && field.Render != "" {
+				rendered = fmt.Sprintf("```%s\n%s\n```", field.Render, rendered)
+			}
+		}
+
+		if rendered == "" {
+			if field.Required {
+				errs = append(errs, fmt.Errorf("field %q (%s) is required", field.ID, field.Label))
+			}
+			rendered = "_No response_"
+		}
+		sections = append(sections, fmt.Sprintf("### %s\n\n%s", field.Label, rendered))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return "", err
+	}
+
+	return strings.Join(sections, "\n\n"), nil
+}
+
+// ListIssueTemplates creates a tool to list the issue templates and issue forms of a repository.
+func ListIssueTemplates(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_issue_templates",
+			mcp.WithDescription(t("TOOL_LIST_ISSUE_TEMPLATES_DESCRIPTION", "List the issue templates and issue forms of a GitHub repository, falling back to those of the owner's .github repository. For issue forms, lists the fields to pass to create_issue in template_fields, and which of them are required.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ISSUE_TEMPLATES_USER_TITLE", "List issue templates"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			templates, source, err := loadIssueTemplates(ctx, client, owner, repo)
+			if err != nil {
+				return nil, err
+			}
+			if templates == nil {
+				templates = []IssueTemplate{}
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"source":    source,
+				"templates": templates,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal issue templates: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,398 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bugReportForm = `name: Bug report
+description: File a bug report
+title: "[Bug]: "
+labels: ["bug", "triage"]
+assignees:
+  - octocat
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for taking the time to fill out this bug report!
+  - type: input
+    id: version
+    attributes:
+      label: Version
+    validations:
+      required: true
+  - type: textarea
+    id: logs
+    attributes:
+      label: Relevant log output
+      render: shell
+  - type: dropdown
+    id: browsers
+    attributes:
+      label: What browsers are you seeing the problem on?
+      multiple: true
+      options:
+        - Firefox
+        - Chrome
+        - Safari
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Code of Conduct
+      options:
+        - label: I agree to follow this project's Code of Conduct
+          required: true
+        - label: I searched for existing issues
+`
+
+const featureRequestTemplate = `---
+name: Feature request
+about: Suggest an idea for this project
+title: ''
+labels: enhancement, needs triage
+assignees: ''
+---
+
+**Is your feature request related to a problem? Please describe.**
+`
+
+// contentsHandler serves the given files, keyed by "owner/repo/path", and their directories from the contents API.
+func contentsHandler(files map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/repos/"), "/", 4)
+		owner, repo, p := parts[0], parts[1], parts[3]
+		prefix := owner + "/" + repo + "/"
+
+		if content, ok := files[prefix+p]; ok {
+			_ = json.NewEncoder(w).Encode(&github.RepositoryContent{
+				Type:    github.Ptr("file"),
+				Name:    github.Ptr(p[strings.LastIndex(p, "/")+1:]),
+				Path:    github.Ptr(p),
+				Content: github.Ptr(content),
+			})
+			return
+		}
+
+		var entries []*github.RepositoryContent
+		for key := range files {
+			name, ok := strings.CutPrefix(key, prefix+p+"/")
+			if ok && !strings.Contains(name, "/") {
+				entries = append(entries, &github.RepositoryContent{
+					Type: github.Ptr("file"),
+					Name: github.Ptr(name),
+					Path: github.Ptr(p + "/" + name),
+				})
+			}
+		}
+		if entries == nil {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(entries)
+	}
+}
+
+func Test_ListIssueTemplates(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListIssueTemplates(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_issue_templates", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name              string
+		files             map[string]string
+		expectedSource    string
+		expectedTemplates []IssueTemplate
+	}{
+		{
+			name: "templates of the repository",
+			files: map[string]string{
+				"owner/repo/.github/ISSUE_TEMPLATE/bug_report.yml":     bugReportForm,
+				"owner/repo/.github/ISSUE_TEMPLATE/feature_request.md": featureRequestTemplate,
+				"owner/repo/.github/ISSUE_TEMPLATE/config.yml":         "blank_issues_enabled: false\n",
+			},
+			expectedSource: "owner/repo",
+			expectedTemplates: []IssueTemplate{
+				{
+					Name:        "Bug report",
+					Description: "File a bug report",
+					File:        "bug_report.yml",
+					Kind:        "form",
+					Title:       "[Bug]: ",
+					Labels:      []string{"bug", "triage"},
+					Assignees:   []string{"octocat"},
+					Fields: []IssueTemplateField{
+						{ID: "version", Type: "input", Label: "Version", Required: true},
+						{ID: "logs", Type: "textarea", Label: "Relevant log output", Render: "shell"},
+						{ID: "browsers", Type: "dropdown", Label: "What browsers are you seeing the problem on?", Multiple: true, Options: []string{"Firefox", "Chrome", "Safari"}},
+						{
+							ID:              "terms",
+							Type:            "checkboxes",
+							Label:           "Code of Conduct",
+							Options:         []string{"I agree to follow this project's Code of Conduct", "I searched for existing issues"},
+							RequiredOptions: []string{"I agree to follow this project's Code of Conduct"},
+						},
+					},
+				},
+				{
+					Name:        "Feature request",
+					Description: "Suggest an idea for this project",
+					File:        "feature_request.md",
+					Kind:        "markdown",
+					Labels:      []string{"enhancement", "needs triage"},
+					Body:        "**Is your feature request related to a problem? Please describe.**\n",
+				},
+			},
+		},
+		{
+			name: "falls back to the templates of the owner's .github repository",
+			files: map[string]string{
+				"owner/.github/ISSUE_TEMPLATE/feature_request.md": featureRequestTemplate,
+			},
+			expectedSource: "owner/.github",
+			expectedTemplates: []IssueTemplate{
+				{
+					Name:        "Feature request",
+					Description: "Suggest an idea for this project",
+					File:        "feature_request.md",
+					Kind:        "markdown",
+					Labels:      []string{"enhancement", "needs triage"},
+					Body:        "**Is your feature request related to a problem? Please describe.**\n",
+				},
+			},
+		},
+		{
+			name:              "no templates",
+			files:             map[string]string{},
+			expectedTemplates: []IssueTemplate{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					contentsHandler(tc.files),
+				),
+			))
+			_, handler := ListIssueTemplates(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response struct {
+				Source    string          `json:"source"`
+				Templates []IssueTemplate `json:"templates"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedSource, response.Source)
+			assert.ElementsMatch(t, tc.expectedTemplates, response.Templates)
+		})
+	}
+}
+
+func Test_CreateIssueFromTemplate(t *testing.T) {
+	files := map[string]string{
+		"owner/repo/.github/ISSUE_TEMPLATE/bug_report.yml":     bugReportForm,
+		"owner/repo/.github/ISSUE_TEMPLATE/feature_request.md": featureRequestTemplate,
+	}
+	mockIssue := &github.Issue{
+		Number:  github.Ptr(42),
+		Title:   github.Ptr("[Bug]: Crash on start"),
+		State:   github.Ptr("open"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/issues/42"),
+	}
+
+	tests := []struct {
+		name                string
+		requestArgs         map[string]any
+		expectedRequestBody map[string]any
+		expectedErrMsg      string
+	}{
+		{
+			name: "issue form is rendered and its labels and assignees applied",
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Crash on start",
+				"labels":   []any{"bug", "p1"},
+				"template": "Bug report",
+				"template_fields": map[string]any{
+					"version":  "1.2.3",
+					"logs":     "panic: oops\n",
+					"browsers": []any{"Firefox", "Safari"},
+					"terms":    []any{"I agree to follow this project's Code of Conduct"},
+				},
+			},
+			expectedRequestBody: map[string]any{
+				"title": "[Bug]: Crash on start",
+				"body": "### Version\n\n1.2.3\n\n" +
+					"### Relevant log output\n\n```shell\npanic: oops\n```\n\n" +
+					"### What browsers are you seeing the problem on?\n\nFirefox, Safari\n\n" +
+					"### Code of Conduct\n\n- [X] I agree to follow this project's Code of Conduct\n- [ ] I searched for existing issues",
+				"labels":    []any{"bug", "p1", "triage"},
+				"assignees": []any{"octocat"},
+			},
+		},
+		{
+			name: "optional fields without a value are rendered as no response",
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "[Bug]: Crash on start",
+				"template": "bug_report",
+				"template_fields": map[string]any{
+					"version": "1.2.3",
+					"terms":   "I agree to follow this project's Code of Conduct",
+				},
+			},
+			expectedRequestBody: map[string]any{
+				"title": "[Bug]: Crash on start",
+				"body": "### Version\n\n1.2.3\n\n" +
+					"### Relevant log output\n\n_No response_\n\n" +
+					"### What browsers are you seeing the problem on?\n\n_No response_\n\n" +
+					"### Code of Conduct\n\n- [X] I agree to follow this project's Code of Conduct\n- [ ] I searched for existing issues",
+				"labels":    []any{"bug", "triage"},
+				"assignees": []any{"octocat"},
+			},
+		},
+		{
+			name: "markdown template body is used",
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Dark mode",
+				"template": "feature_request.md",
+			},
+			expectedRequestBody: map[string]any{
+				"title":     "Dark mode",
+				"body":      "**Is your feature request related to a problem? Please describe.**\n",
+				"labels":    []any{"enhancement", "needs triage"},
+				"assignees": []any{},
+			},
+		},
+		{
+			name: "missing required fields",
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"title":           "Crash on start",
+				"template":        "Bug report",
+				"template_fields": map[string]any{},
+			},
+			expectedErrMsg: `field "version" (Version) is required`,
+		},
+		{
+			name: "required checkbox not checked",
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"title":           "Crash on start",
+				"template":        "Bug report",
+				"template_fields": map[string]any{"version": "1.2.3"},
+			},
+			expectedErrMsg: `field "terms": option "I agree to follow this project's Code of Conduct" must be checked`,
+		},
+		{
+			name: "unknown dropdown option",
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Crash on start",
+				"template": "Bug report",
+				"template_fields": map[string]any{
+					"version":  "1.2.3",
+					"browsers": "Lynx",
+					"terms":    []any{"I agree to follow this project's Code of Conduct"},
+				},
+			},
+			expectedErrMsg: `field "browsers": "Lynx" is not one of the options: Firefox, Chrome, Safari`,
+		},
+		{
+			name: "unknown field",
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"title":           "Crash on start",
+				"template":        "Bug report",
+				"template_fields": map[string]any{"os": "linux"},
+			},
+			expectedErrMsg: `unknown field "os" for template "Bug report"`,
+		},
+		{
+			name: "body cannot be combined with an issue form",
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Crash on start",
+				"body":     "It crashes",
+				"template": "Bug report",
+			},
+			expectedErrMsg: "body cannot be set when creating an issue from an issue form",
+		},
+		{
+			name: "template not found",
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "Crash on start",
+				"template": "Question",
+			},
+			expectedErrMsg: `issue template "Question" not found, available templates:`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			createHandler := mockResponse(t, http.StatusCreated, mockIssue)
+			if tc.expectedRequestBody != nil {
+				createHandler = expectRequestBody(t, tc.expectedRequestBody).andThen(createHandler)
+			}
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					contentsHandler(files),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesByOwnerByRepo,
+					createHandler,
+				),
+			))
+			_, handler := CreateIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var returnedIssue github.Issue
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedIssue))
+			assert.Equal(t, *mockIssue.Number, *returnedIssue.Number)
+		})
+	}
+}
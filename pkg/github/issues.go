@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -21,6 +22,9 @@ import (
 func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_issue",
 			mcp.WithDescription(t("TOOL_GET_ISSUE_DESCRIPTION", "Get details of a specific issue in a GitHub repository.")),
+			mcp.WithOutputSchema[MinimalIssue](),
+			WithFieldSelection(),
+			WithOutputFormat(),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_ISSUE_USER_TITLE", "Get issue details"),
 				ReadOnlyHint: toBoolPtr(true),
@@ -70,117 +74,13 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue: %s", string(body))), nil
 			}
 
-			// Create simplified user
-			type SimplifiedUser struct {
-				Login     string `json:"login,omitempty"`
-				AvatarURL string `json:"avatar_url,omitempty"`
-				HTMLURL   string `json:"html_url,omitempty"`
-			}
-
-			// Create simplified label
-			type SimplifiedLabel struct {
-				Name        string `json:"name,omitempty"`
-				Color       string `json:"color,omitempty"`
-				Description string `json:"description,omitempty"`
-			}
-
-			// Create simplified issue representation
-			type SimplifiedIssue struct {
-				ID               int64             `json:"id,omitempty"`
-				NodeID           string            `json:"node_id,omitempty"`
-				Number           int               `json:"number"`
-				Title            string            `json:"title,omitempty"`
-				State            string            `json:"state,omitempty"`
-				HTMLURL          string            `json:"html_url,omitempty"`
-				Body             string            `json:"body,omitempty"`
-				User             *SimplifiedUser   `json:"user,omitempty"`
-				Labels           []SimplifiedLabel `json:"labels,omitempty"`
-				Assignees        []*SimplifiedUser `json:"assignees,omitempty"`
-				Comments         int               `json:"comments"`
-				CreatedAt        string            `json:"created_at,omitempty"`
-				UpdatedAt        string            `json:"updated_at,omitempty"`
-				ClosedAt         string            `json:"closed_at,omitempty"`
-				PullRequestLinks *struct {
-					URL string `json:"url,omitempty"`
-				} `json:"pull_request,omitempty"`
-			}
-
-			// Process users
-			var user *SimplifiedUser
-			if issue.User != nil {
-				user = &SimplifiedUser{
-					Login:     issue.User.GetLogin(),
-					AvatarURL: issue.User.GetAvatarURL(),
-					HTMLURL:   issue.User.GetHTMLURL(),
-				}
-			}
-
-			// Process assignees
-			assignees := make([]*SimplifiedUser, 0, len(issue.Assignees))
-			for _, assignee := range issue.Assignees {
-				if assignee != nil {
-					assignees = append(assignees, &SimplifiedUser{
-						Login:     assignee.GetLogin(),
-						AvatarURL: assignee.GetAvatarURL(),
-						HTMLURL:   assignee.GetHTMLURL(),
-					})
-				}
-			}
-
-			// Process labels
-			labels := make([]SimplifiedLabel, 0, len(issue.Labels))
-			for _, label := range issue.Labels {
-				labels = append(labels, SimplifiedLabel{
-					Name:        label.GetName(),
-					Color:       label.GetColor(),
-					Description: label.GetDescription(),
-				})
-			}
-
-			// Format dates as strings
-			var closedAtStr string
-			if issue.ClosedAt != nil && !issue.ClosedAt.IsZero() {
-				closedAtStr = issue.ClosedAt.Format(time.RFC3339)
-			}
-
-			// Create pull request link info if available
-			var prLinks *struct {
-				URL string `json:"url,omitempty"`
-			}
-
-			if issue.PullRequestLinks != nil {
-				prLinks = &struct {
-					URL string `json:"url,omitempty"`
-				}{
-					URL: issue.PullRequestLinks.GetURL(),
-				}
-			}
-
-			// Create simplified issue
-			simplifiedIssue := SimplifiedIssue{
-				ID:               issue.GetID(),
-				NodeID:           issue.GetNodeID(),
-				Number:           issue.GetNumber(),
-				Title:            issue.GetTitle(),
-				State:            issue.GetState(),
-				HTMLURL:          issue.GetHTMLURL(),
-				Body:             issue.GetBody(),
-				User:             user,
-				Labels:           labels,
-				Assignees:        assignees,
-				Comments:         issue.GetComments(),
-				CreatedAt:        issue.GetCreatedAt().Format(time.RFC3339),
-				UpdatedAt:        issue.GetUpdatedAt().Format(time.RFC3339),
-				ClosedAt:         closedAtStr,
-				PullRequestLinks: prLinks,
-			}
-
-			r, err := json.Marshal(simplifiedIssue)
+			minimalIssue := newMinimalIssue(issue)
+			r, err := json.Marshal(minimalIssue)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal simplified issue: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return mcp.NewToolResultStructured(minimalIssue, string(r)), nil
 		}
 }
 
@@ -300,6 +200,9 @@ func AddIssueComment(getClient GetClientFn, t translations.TranslationHelperFunc
 func SearchIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("search_issues",
 			mcp.WithDescription(t("TOOL_SEARCH_ISSUES_DESCRIPTION", "Search for issues in GitHub repositories.")),
+			mcp.WithOutputSchema[MinimalSearchResult[MinimalIssue]](),
+			WithFieldSelection(),
+			WithOutputFormat(),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_SEARCH_ISSUES_USER_TITLE", "Search issues"),
 				ReadOnlyHint: toBoolPtr(true),
@@ -366,6 +269,7 @@ func SearchIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return nil, fmt.Errorf("failed to search issues: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
 
 			if resp.StatusCode != http.StatusOK {
 				body, err := io.ReadAll(resp.Body)
@@ -376,148 +280,21 @@ func SearchIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			}
 
 			// Create a simplified version of the response with essential fields only
-			type SimplifiedUser struct {
-				Login     string `json:"login,omitempty"`
-				AvatarURL string `json:"avatar_url,omitempty"`
-				HTMLURL   string `json:"html_url,omitempty"`
-			}
-
-			type SimplifiedLabel struct {
-				Name        string `json:"name,omitempty"`
-				Description string `json:"description,omitempty"`
-			}
-
-			type SimplifiedIssue struct {
-				ID         int64             `json:"id,omitempty"`
-				NodeID     string            `json:"node_id,omitempty"`
-				Number     int               `json:"number"`
-				Title      string            `json:"title,omitempty"`
-				State      string            `json:"state,omitempty"`
-				HTMLURL    string            `json:"html_url,omitempty"`
-				Body       string            `json:"body,omitempty"`
-				User       *SimplifiedUser   `json:"user,omitempty"`
-				Labels     []SimplifiedLabel `json:"labels,omitempty"`
-				Assignee   *SimplifiedUser   `json:"assignee,omitempty"`
-				Comments   int               `json:"comments"`
-				CreatedAt  string            `json:"created_at,omitempty"`
-				UpdatedAt  string            `json:"updated_at,omitempty"`
-				ClosedAt   string            `json:"closed_at,omitempty"`
-				Repository *struct {
-					FullName string `json:"full_name,omitempty"`
-					HTMLURL  string `json:"html_url,omitempty"`
-				} `json:"repository,omitempty"`
-				PullRequestLinks *struct {
-					URL string `json:"url,omitempty"`
-				} `json:"pull_request,omitempty"`
-			}
-
-			type SimplifiedIssuesResponse struct {
-				TotalCount        int               `json:"total_count"`
-				IncompleteResults bool              `json:"incomplete_results"`
-				Items             []SimplifiedIssue `json:"items"`
-			}
-
-			simplifiedResult := SimplifiedIssuesResponse{
+			minimalResult := MinimalSearchResult[MinimalIssue]{
 				TotalCount:        result.GetTotal(),
 				IncompleteResults: result.GetIncompleteResults(),
-				Items:             make([]SimplifiedIssue, 0, len(result.Issues)),
+				Items:             make([]MinimalIssue, 0, len(result.Issues)),
 			}
-
 			for _, issue := range result.Issues {
-				// Process user
-				var user *SimplifiedUser
-				if issue.User != nil {
-					user = &SimplifiedUser{
-						Login:     issue.User.GetLogin(),
-						AvatarURL: issue.User.GetAvatarURL(),
-						HTMLURL:   issue.User.GetHTMLURL(),
-					}
-				}
-
-				// Process assignee
-				var assignee *SimplifiedUser
-				if issue.Assignee != nil {
-					assignee = &SimplifiedUser{
-						Login:     issue.Assignee.GetLogin(),
-						AvatarURL: issue.Assignee.GetAvatarURL(),
-						HTMLURL:   issue.Assignee.GetHTMLURL(),
-					}
-				}
-
-				// Process labels
-				labels := make([]SimplifiedLabel, 0)
-				if issue.Labels != nil {
-					for _, label := range issue.Labels {
-						labels = append(labels, SimplifiedLabel{
-							Name:        label.GetName(),
-							Description: label.GetDescription(),
-						})
-					}
-				}
-
-				// Format dates as strings
-				var closedAtStr string
-				if issue.ClosedAt != nil && !issue.ClosedAt.IsZero() {
-					closedAtStr = issue.ClosedAt.Format(time.RFC3339)
-				}
-
-				// Create repository info if available
-				var repo *struct {
-					FullName string `json:"full_name,omitempty"`
-					HTMLURL  string `json:"html_url,omitempty"`
-				}
-
-				if issue.Repository != nil {
-					repo = &struct {
-						FullName string `json:"full_name,omitempty"`
-						HTMLURL  string `json:"html_url,omitempty"`
-					}{
-						FullName: issue.Repository.GetFullName(),
-						HTMLURL:  issue.Repository.GetHTMLURL(),
-					}
-				}
-
-				// Create pull request link info if available
-				var prLinks *struct {
-					URL string `json:"url,omitempty"`
-				}
-
-				if issue.PullRequestLinks != nil {
-					prLinks = &struct {
-						URL string `json:"url,omitempty"`
-					}{
-						URL: issue.PullRequestLinks.GetURL(),
-					}
-				}
-
-				simplifiedIssue := SimplifiedIssue{
-					ID:               issue.GetID(),
-					NodeID:           issue.GetNodeID(),
-					Number:           issue.GetNumber(),
-					Title:            issue.GetTitle(),
-					State:            issue.GetState(),
-					HTMLURL:          issue.GetHTMLURL(),
-					Body:             issue.GetBody(),
-					User:             user,
-					Labels:           labels,
-					Assignee:         assignee,
-					Comments:         issue.GetComments(),
-					CreatedAt:        issue.GetCreatedAt().Format(time.RFC3339),
-					UpdatedAt:        issue.GetUpdatedAt().Format(time.RFC3339),
-					ClosedAt:         closedAtStr,
-					Repository:       repo,
-					PullRequestLinks: prLinks,
-				}
-
-				simplifiedResult.Items = append(simplifiedResult.Items, simplifiedIssue)
+				minimalResult.Items = append(minimalResult.Items, newMinimalIssue(issue))
 			}
 
-			r, err := json.Marshal(simplifiedResult)
+			r, err := json.Marshal(minimalResult)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal simplified response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return mcp.NewToolResultStructured(minimalResult, string(r)), nil
 		}
 }
 
@@ -525,6 +302,7 @@ func SearchIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (
 func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("create_issue",
 			mcp.WithDescription(t("TOOL_CREATE_ISSUE_DESCRIPTION", "Create a new issue in a GitHub repository.")),
+			mcp.WithOutputSchema[MinimalIssue](),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_CREATE_ISSUE_USER_TITLE", "Open new issue"),
 				ReadOnlyHint: toBoolPtr(false),
@@ -563,6 +341,12 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.WithNumber("milestone",
 				mcp.Description("Milestone number"),
 			),
+			mcp.WithString("template",
+				mcp.Description("Name or file name of an issue template or issue form to create the issue from, as returned by list_issue_templates. The template's title prefix, labels and assignees are applied automatically"),
+			),
+			mcp.WithObject("template_fields",
+				mcp.Description("Values of the fields of the issue form given in template, keyed by field ID. Dropdowns take an option or a list of options, checkboxes take the list of options to check. The issue body is rendered from these values, so body must not be set"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -607,6 +391,70 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				milestoneNum = &milestone
 			}
 
+			templateName, err := OptionalParam[string](request, "template")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			templateFields, err := OptionalParam[map[string]any](request, "template_fields")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if templateFields != nil && templateName == "" {
+				return mcp.NewToolResultError("template_fields requires template to be set"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if templateName != "" {
+				templates, _, err := loadIssueTemplates(ctx, client, owner, repo)
+				if err != nil {
+					return nil, err
+				}
+				template, ok := findIssueTemplate(templates, templateName)
+				if !ok {
+					names := make([]string, 0, len(templates))
+					for _, candidate := range templates {
+						names = append(names, candidate.Name)
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("issue template %q not found, available templates: %s", templateName, strings.Join(names, ", "))), nil
+				}
+
+				switch template.Kind {
+				case "form":
+					if body != "" {
+						return mcp.NewToolResultError("body cannot be set when creating an issue from an issue form, pass the field values in template_fields instead"), nil
+					}
+					body, err = renderIssueForm(template, templateFields)
+					if err != nil {
+						return mcp.NewToolResultError(err.Error()), nil
+					}
+				default:
+					if templateFields != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("template %q is not an issue form, template_fields can only be used with issue forms", template.Name)), nil
+					}
+					if body == "" {
+						body = template.Body
+					}
+				}
+
+				if template.Title != "" && !strings.HasPrefix(title, template.Title) {
+					title = template.Title + title
+				}
+				for _, label := range template.Labels {
+					if !slices.Contains(labels, label) {
+						labels = append(labels, label)
+					}
+				}
+				for _, assignee := range template.Assignees {
+					if !slices.Contains(assignees, assignee) {
+						assignees = append(assignees, assignee)
+					}
+				}
+			}
+
 			// Create the issue request
 			issueRequest := &github.IssueRequest{
 				Title:     github.Ptr(title),
@@ -616,10 +464,6 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				Milestone: milestoneNum,
 			}
 
-			client, err := getClient(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
-			}
 			issue, resp, err := client.Issues.Create(ctx, owner, repo, issueRequest)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create issue: %w", err)
@@ -634,115 +478,13 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return mcp.NewToolResultError(fmt.Sprintf("failed to create issue: %s", string(body))), nil
 			}
 
-			// Create simplified types
-			type SimplifiedUser struct {
-				Login     string `json:"login,omitempty"`
-				AvatarURL string `json:"avatar_url,omitempty"`
-				HTMLURL   string `json:"html_url,omitempty"`
-			}
-
-			type SimplifiedLabel struct {
-				Name        string `json:"name,omitempty"`
-				Color       string `json:"color,omitempty"`
-				Description string `json:"description,omitempty"`
-			}
-
-			type SimplifiedIssue struct {
-				ID               int64             `json:"id,omitempty"`
-				NodeID           string            `json:"node_id,omitempty"`
-				Number           int               `json:"number"`
-				Title            string            `json:"title,omitempty"`
-				State            string            `json:"state,omitempty"`
-				HTMLURL          string            `json:"html_url,omitempty"`
-				Body             string            `json:"body,omitempty"`
-				User             *SimplifiedUser   `json:"user,omitempty"`
-				Labels           []SimplifiedLabel `json:"labels,omitempty"`
-				Assignees        []*SimplifiedUser `json:"assignees,omitempty"`
-				Comments         int               `json:"comments"`
-				CreatedAt        string            `json:"created_at,omitempty"`
-				UpdatedAt        string            `json:"updated_at,omitempty"`
-				ClosedAt         string            `json:"closed_at,omitempty"`
-				PullRequestLinks *struct {
-					URL string `json:"url,omitempty"`
-				} `json:"pull_request,omitempty"`
-			}
-
-			// Process user
-			var user *SimplifiedUser
-			if issue.User != nil {
-				user = &SimplifiedUser{
-					Login:     issue.User.GetLogin(),
-					AvatarURL: issue.User.GetAvatarURL(),
-					HTMLURL:   issue.User.GetHTMLURL(),
-				}
-			}
-
-			// Process assignees (output)
-			assigneesOut := make([]*SimplifiedUser, 0, len(issue.Assignees))
-			for _, assignee := range issue.Assignees {
-				if assignee != nil {
-					assigneesOut = append(assigneesOut, &SimplifiedUser{
-						Login:     assignee.GetLogin(),
-						AvatarURL: assignee.GetAvatarURL(),
-						HTMLURL:   assignee.GetHTMLURL(),
-					})
-				}
-			}
-
-			// Process labels (output)
-			labelsOut := make([]SimplifiedLabel, 0, len(issue.Labels))
-			for _, label := range issue.Labels {
-				labelsOut = append(labelsOut, SimplifiedLabel{
-					Name:        label.GetName(),
-					Color:       label.GetColor(),
-					Description: label.GetDescription(),
-				})
-			}
-
-			// Format dates as strings
-			var closedAtStr string
-			if issue.ClosedAt != nil && !issue.ClosedAt.IsZero() {
-				closedAtStr = issue.ClosedAt.Format(time.RFC3339)
-			}
-
-			// Create pull request link info if available
-			var prLinks *struct {
-				URL string `json:"url,omitempty"`
-			}
-
-			if issue.PullRequestLinks != nil {
-				prLinks = &struct {
-					URL string `json:"url,omitempty"`
-				}{
-					URL: issue.PullRequestLinks.GetURL(),
-				}
-			}
-
-			// Create simplified issue
-			simplifiedIssue := SimplifiedIssue{
-				ID:               issue.GetID(),
-				NodeID:           issue.GetNodeID(),
-				Number:           issue.GetNumber(),
-				Title:            issue.GetTitle(),
-				State:            issue.GetState(),
-				HTMLURL:          issue.GetHTMLURL(),
-				Body:             issue.GetBody(),
-				User:             user,
-				Labels:           labelsOut,
-				Assignees:        assigneesOut,
-				Comments:         issue.GetComments(),
-				CreatedAt:        issue.GetCreatedAt().Format(time.RFC3339),
-				UpdatedAt:        issue.GetUpdatedAt().Format(time.RFC3339),
-				ClosedAt:         closedAtStr,
-				PullRequestLinks: prLinks,
-			}
-
-			r, err := json.Marshal(simplifiedIssue)
+			minimalIssue := newMinimalIssue(issue)
+			r, err := json.Marshal(minimalIssue)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal simplified response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return mcp.NewToolResultStructured(minimalIssue, string(r)), nil
 		}
 }
 
@@ -750,6 +492,9 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_issues",
 			mcp.WithDescription(t("TOOL_LIST_ISSUES_DESCRIPTION", "List issues in a GitHub repository.")),
+			mcp.WithOutputSchema[MinimalList[MinimalIssue]](),
+			WithFieldSelection(),
+			WithOutputFormat(),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_ISSUES_USER_TITLE", "List issues"),
 				ReadOnlyHint: toBoolPtr(true),
@@ -850,6 +595,7 @@ func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return nil, fmt.Errorf("failed to list issues: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
 
 			if resp.StatusCode != http.StatusOK {
 				body, err := io.ReadAll(resp.Body)
@@ -859,120 +605,17 @@ func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list issues: %s", string(body))), nil
 			}
 
-			// Create simplified types
-			type SimplifiedUser struct {
-				Login     string `json:"login,omitempty"`
-				AvatarURL string `json:"avatar_url,omitempty"`
-				HTMLURL   string `json:"html_url,omitempty"`
-			}
-
-			type SimplifiedLabel struct {
-				Name        string `json:"name,omitempty"`
-				Color       string `json:"color,omitempty"`
-				Description string `json:"description,omitempty"`
-			}
-
-			type SimplifiedIssue struct {
-				ID               int64             `json:"id,omitempty"`
-				Number           int               `json:"number"`
-				Title            string            `json:"title,omitempty"`
-				State            string            `json:"state,omitempty"`
-				HTMLURL          string            `json:"html_url,omitempty"`
-				Body             string            `json:"body,omitempty"`
-				User             *SimplifiedUser   `json:"user,omitempty"`
-				Labels           []SimplifiedLabel `json:"labels,omitempty"`
-				Assignees        []*SimplifiedUser `json:"assignees,omitempty"`
-				Comments         int               `json:"comments"`
-				CreatedAt        string            `json:"created_at,omitempty"`
-				UpdatedAt        string            `json:"updated_at,omitempty"`
-				ClosedAt         string            `json:"closed_at,omitempty"`
-				PullRequestLinks *struct {
-					URL string `json:"url,omitempty"`
-				} `json:"pull_request,omitempty"`
-			}
-
-			// Create simplified issues array
-			simplifiedIssues := make([]SimplifiedIssue, 0, len(issues))
-
+			minimalIssues := make([]MinimalIssue, 0, len(issues))
 			for _, issue := range issues {
-				// Process user
-				var user *SimplifiedUser
-				if issue.User != nil {
-					user = &SimplifiedUser{
-						Login:     issue.User.GetLogin(),
-						AvatarURL: issue.User.GetAvatarURL(),
-						HTMLURL:   issue.User.GetHTMLURL(),
-					}
-				}
-
-				// Process assignees
-				assignees := make([]*SimplifiedUser, 0, len(issue.Assignees))
-				for _, assignee := range issue.Assignees {
-					if assignee != nil {
-						assignees = append(assignees, &SimplifiedUser{
-							Login:     assignee.GetLogin(),
-							AvatarURL: assignee.GetAvatarURL(),
-							HTMLURL:   assignee.GetHTMLURL(),
-						})
-					}
-				}
-
-				// Process labels
-				labels := make([]SimplifiedLabel, 0, len(issue.Labels))
-				for _, label := range issue.Labels {
-					labels = append(labels, SimplifiedLabel{
-						Name:        label.GetName(),
-						Color:       label.GetColor(),
-						Description: label.GetDescription(),
-					})
-				}
-
-				// Format dates as strings
-				var closedAtStr string
-				if issue.ClosedAt != nil && !issue.ClosedAt.IsZero() {
-					closedAtStr = issue.ClosedAt.Format(time.RFC3339)
-				}
-
-				// Create pull request link info if available
-				var prLinks *struct {
-					URL string `json:"url,omitempty"`
-				}
-
-				if issue.PullRequestLinks != nil {
-					prLinks = &struct {
-						URL string `json:"url,omitempty"`
-					}{
-						URL: issue.PullRequestLinks.GetURL(),
-					}
-				}
-
-				// Create simplified issue
-				simplifiedIssue := SimplifiedIssue{
-					ID:               issue.GetID(),
-					Number:           issue.GetNumber(),
-					Title:            issue.GetTitle(),
-					State:            issue.GetState(),
-					HTMLURL:          issue.GetHTMLURL(),
-					Body:             issue.GetBody(),
-					User:             user,
-					Labels:           labels,
-					Assignees:        assignees,
-					Comments:         issue.GetComments(),
-					CreatedAt:        issue.GetCreatedAt().Format(time.RFC3339),
-					UpdatedAt:        issue.GetUpdatedAt().Format(time.RFC3339),
-					ClosedAt:         closedAtStr,
-					PullRequestLinks: prLinks,
-				}
-
-				simplifiedIssues = append(simplifiedIssues, simplifiedIssue)
+				minimalIssues = append(minimalIssues, newMinimalIssue(issue))
 			}
 
-			r, err := json.Marshal(simplifiedIssues)
+			r, err := json.Marshal(minimalIssues)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal simplified issues: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return mcp.NewToolResultStructured(MinimalList[MinimalIssue]{Items: minimalIssues}, string(r)), nil
 		}
 }
 
@@ -980,6 +623,7 @@ func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("update_issue",
 			mcp.WithDescription(t("TOOL_UPDATE_ISSUE_DESCRIPTION", "Update an existing issue in a GitHub repository.")),
+			mcp.WithOutputSchema[MinimalIssue](),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_UPDATE_ISSUE_USER_TITLE", "Edit issue"),
 				ReadOnlyHint: toBoolPtr(false),
@@ -1006,6 +650,10 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				mcp.Description("New state"),
 				mcp.Enum("open", "closed"),
 			),
+			mcp.WithString("state_reason",
+				mcp.Description("Reason for the state change. Use 'not_planned' to close an issue as not planned; to close an issue as a duplicate use close_issue_as_duplicate"),
+				mcp.Enum("completed", "not_planned", "reopened"),
+			),
 			mcp.WithArray("labels",
 				mcp.Description("New labels"),
 				mcp.Items(
@@ -1068,6 +716,14 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				issueRequest.State = github.Ptr(state)
 			}
 
+			stateReason, err := OptionalParam[string](request, "state_reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if stateReason != "" {
+				issueRequest.StateReason = github.Ptr(stateReason)
+			}
+
 			// Get labels
 			labels, err := OptionalStringArrayParam(request, "labels")
 			if err != nil {
@@ -1113,113 +769,13 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return mcp.NewToolResultError(fmt.Sprintf("failed to update issue: %s", string(body))), nil
 			}
 
-			// Create simplified types
-			type SimplifiedUser struct {
-				Login     string `json:"login,omitempty"`
-				AvatarURL string `json:"avatar_url,omitempty"`
-				HTMLURL   string `json:"html_url,omitempty"`
-			}
-
-			type SimplifiedLabel struct {
-				Name        string `json:"name,omitempty"`
-				Color       string `json:"color,omitempty"`
-				Description string `json:"description,omitempty"`
-			}
-
-			type SimplifiedIssue struct {
-				ID               int64             `json:"id,omitempty"`
-				NodeID           string            `json:"node_id,omitempty"`
-				Number           int               `json:"number"`
-				Title            string            `json:"title,omitempty"`
-				State            string            `json:"state,omitempty"`
-				HTMLURL          string            `json:"html_url,omitempty"`
-				Body             string            `json:"body,omitempty"`
-				User             *SimplifiedUser   `json:"user,omitempty"`
-				Labels           []SimplifiedLabel `json:"labels,omitempty"`
-				Assignees        []*SimplifiedUser `json:"assignees,omitempty"`
-				Comments         int               `json:"comments"`
-				CreatedAt        string            `json:"created_at,omitempty"`
-				UpdatedAt        string            `json:"updated_at,omitempty"`
-				ClosedAt         string            `json:"closed_at,omitempty"`
-				PullRequestLinks *struct {
-					URL string `json:"url,omitempty"`
-				} `json:"pull_request,omitempty"`
-			}
-
-			// Process user
-			var user *SimplifiedUser
-			if updatedIssue.User != nil {
-				user = &SimplifiedUser{
-					Login:     updatedIssue.User.GetLogin(),
-					AvatarURL: updatedIssue.User.GetAvatarURL(),
-					HTMLURL:   updatedIssue.User.GetHTMLURL(),
-				}
-			}
-
-			// Process assignees (output)
-			assigneesOut := make([]*SimplifiedUser, 0, len(updatedIssue.Assignees))
-			for _, assignee := range updatedIssue.Assignees {
-				if assignee != nil {
-					assigneesOut = append(assigneesOut, &SimplifiedUser{
-						Login:     assignee.GetLogin(),
-						AvatarURL: assignee.GetAvatarURL(),
-						HTMLURL:   assignee.GetHTMLURL(),
-					})
-				}
-			}
-
-			// Process labels (output)
-			labelsOut := make([]SimplifiedLabel, 0, len(updatedIssue.Labels))
-			for _, label := range updatedIssue.Labels {
-				labelsOut = append(labelsOut, SimplifiedLabel{
-					Name:        label.GetName(),
-					Color:       label.GetColor(),
-					Description: label.GetDescription(),
-				})
-			}
-
-			// Format dates as strings
-			var closedAtStr string
-			if updatedIssue.ClosedAt != nil && !updatedIssue.ClosedAt.IsZero() {
-				closedAtStr = updatedIssue.ClosedAt.Format(time.RFC3339)
-			}
-
-			// Create pull request link info if available
-			var prLinks *struct {
-				URL string `json:"url,omitempty"`
-			}
-			if updatedIssue.PullRequestLinks != nil {
-				prLinks = &struct {
-					URL string `json:"url,omitempty"`
-				}{
-					URL: updatedIssue.PullRequestLinks.GetURL(),
-				}
-			}
-
-			// Create simplified issue
-			simplifiedIssue := SimplifiedIssue{
-				ID:               updatedIssue.GetID(),
-				Number:           updatedIssue.GetNumber(),
-				Title:            updatedIssue.GetTitle(),
-				State:            updatedIssue.GetState(),
-				HTMLURL:          updatedIssue.GetHTMLURL(),
-				Body:             updatedIssue.GetBody(),
-				User:             user,
-				Labels:           labelsOut,
-				Assignees:        assigneesOut,
-				Comments:         updatedIssue.GetComments(),
-				CreatedAt:        updatedIssue.GetCreatedAt().Format(time.RFC3339),
-				UpdatedAt:        updatedIssue.GetUpdatedAt().Format(time.RFC3339),
-				ClosedAt:         closedAtStr,
-				PullRequestLinks: prLinks,
-			}
-
-			r, err := json.Marshal(simplifiedIssue)
+			minimalIssue := newMinimalIssue(updatedIssue)
+			r, err := json.Marshal(minimalIssue)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal simplified response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return mcp.NewToolResultStructured(minimalIssue, string(r)), nil
 		}
 }
 
@@ -1227,6 +783,7 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_issue_comments",
 			mcp.WithDescription(t("TOOL_GET_ISSUE_COMMENTS_DESCRIPTION", "Get comments for a specific issue in a GitHub repository.")),
+			WithOutputFormat(),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_ISSUE_COMMENTS_USER_TITLE", "Get issue comments"),
 				ReadOnlyHint: toBoolPtr(true),
@@ -1249,6 +806,7 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 			mcp.WithNumber("per_page",
 				mcp.Description("Number of records per page"),
 			),
+			WithCursorPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -1263,19 +821,15 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			page, err := OptionalIntParamWithDefault(request, "page", 1)
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			perPage, err := OptionalIntParamWithDefault(request, "per_page", 30)
+			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			opts := &github.IssueListCommentsOptions{
 				ListOptions: github.ListOptions{
-					Page:    page,
-					PerPage: perPage,
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
 				},
 			}
 
@@ -1288,6 +842,7 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 				return nil, fmt.Errorf("failed to get issue comments: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
 
 			if resp.StatusCode != http.StatusOK {
 				body, err := io.ReadAll(resp.Body)
@@ -1349,51 +904,571 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
-// mvpDescription is an MVP idea for generating tool descriptions from structured data in a shared format.
-// It is not intended for widespread usage and is not a complete implementation.
-type mvpDescription struct {
-	summary        string
-	outcomes       []string
-	referenceLinks []string
+// issueTimelineEventTypes lists the timeline event types that get_issue_timeline can return, keyed by the name
+// used in the event_types filter and in the output. Each one has a matching fragment in issueTimelineNode.
+var issueTimelineEventTypes = []struct {
+	Name     string
+	TypeName string
+	ItemType githubv4.IssueTimelineItemsItemType
+}{
+	{"comment", "IssueComment", githubv4.IssueTimelineItemsItemTypeIssueComment},
+	{"labeled", "LabeledEvent", githubv4.IssueTimelineItemsItemTypeLabeledEvent},
+	{"unlabeled", "UnlabeledEvent", githubv4.IssueTimelineItemsItemTypeUnlabeledEvent},
+	{"assigned", "AssignedEvent", githubv4.IssueTimelineItemsItemTypeAssignedEvent},
+	{"unassigned", "UnassignedEvent", githubv4.IssueTimelineItemsItemTypeUnassignedEvent},
+	{"milestoned", "MilestonedEvent", githubv4.IssueTimelineItemsItemTypeMilestonedEvent},
+	{"demilestoned", "DemilestonedEvent", githubv4.IssueTimelineItemsItemTypeDemilestonedEvent},
+	{"renamed", "RenamedTitleEvent", githubv4.IssueTimelineItemsItemTypeRenamedTitleEvent},
+	{"closed", "ClosedEvent", githubv4.IssueTimelineItemsItemTypeClosedEvent},
+	{"reopened", "ReopenedEvent", githubv4.IssueTimelineItemsItemTypeReopenedEvent},
+	{"cross_referenced", "CrossReferencedEvent", githubv4.IssueTimelineItemsItemTypeCrossReferencedEvent},
+	{"referenced", "ReferencedEvent", githubv4.IssueTimelineItemsItemTypeReferencedEvent},
+	{"connected", "ConnectedEvent", githubv4.IssueTimelineItemsItemTypeConnectedEvent},
+	{"marked_as_duplicate", "MarkedAsDuplicateEvent", githubv4.IssueTimelineItemsItemTypeMarkedAsDuplicateEvent},
 }
 
-func (d *mvpDescription) String() string {
-	var sb strings.Builder
-	sb.WriteString(d.summary)
-	if len(d.outcomes) > 0 {
-		sb.WriteString("\n\n")
-		sb.WriteString("This tool can help with the following outcomes:\n")
-		for _, outcome := range d.outcomes {
-			sb.WriteString(fmt.Sprintf("- %s\n", outcome))
+type timelineActor struct {
+	Login githubv4.String
+}
+
+// timelineEventFields are the fields shared by every timeline event.
+type timelineEventFields struct {
+	Actor     timelineActor
+	CreatedAt githubv4.DateTime
+}
+
+// timelineSubject is an issue or pull request referenced from a timeline event. The state fields are aliased
+// because IssueState and PullRequestState are different enums, which GraphQL refuses to merge.
+type timelineSubject struct {
+	TypeName githubv4.String `graphql:"__typename"`
+	Issue    struct {
+		Number     githubv4.Int
+		Title      githubv4.String
+		URL        githubv4.String
+		IssueState githubv4.String `graphql:"issueState: state"`
+		Repository struct {
+			NameWithOwner githubv4.String
 		}
-	}
+	} `graphql:"... on Issue"`
+	PullRequest struct {
+		Number           githubv4.Int
+		Title            githubv4.String
+		URL              githubv4.String
+		PullRequestState githubv4.String `graphql:"pullRequestState: state"`
+		Repository       struct {
+			NameWithOwner githubv4.String
+		}
+	} `graphql:"... on PullRequest"`
+}
 
-	if len(d.referenceLinks) > 0 {
-		sb.WriteString("\n\n")
-		sb.WriteString("More information can be found at:\n")
-		for _, link := range d.referenceLinks {
-			sb.WriteString(fmt.Sprintf("- %s\n", link))
+// timelineCloser is the pull request or commit that closed an issue.
+type timelineCloser struct {
+	TypeName    githubv4.String `graphql:"__typename"`
+	PullRequest struct {
+		Number githubv4.Int
+		Title  githubv4.String
+		URL    githubv4.String
+	} `graphql:"... on PullRequest"`
+	Commit struct {
+		OID githubv4.GitObjectID
+		URL githubv4.String
+	} `graphql:"... on Commit"`
+}
+
+type timelineClosedEvent struct {
+	timelineEventFields
+	StateReason githubv4.String
+	Closer      timelineCloser
+}
+
+type timelineCrossReferencedEvent struct {
+	timelineEventFields
+	WillCloseTarget githubv4.Boolean
+	Source          timelineSubject
+}
+
+type issueTimelineNode struct {
+	TypeName     githubv4.String `graphql:"__typename"`
+	IssueComment struct {
+		Author    timelineActor
+		CreatedAt githubv4.DateTime
+		Body      githubv4.String
+		URL       githubv4.String
+	} `graphql:"... on IssueComment"`
+	LabeledEvent struct {
+		timelineEventFields
+		Label struct {
+			Name githubv4.String
 		}
-	}
+	} `graphql:"... on LabeledEvent"`
+	UnlabeledEvent struct {
+		timelineEventFields
+		Label struct {
+			Name githubv4.String
+		}
+	} `graphql:"... on UnlabeledEvent"`
+	AssignedEvent struct {
+		timelineEventFields
+		Assignee struct {
+			Actor timelineActor `graphql:"... on Actor"`
+		}
+	} `graphql:"... on AssignedEvent"`
+	UnassignedEvent struct {
+		timelineEventFields
+		Assignee struct {
+			Actor timelineActor `graphql:"... on Actor"`
+		}
+	} `graphql:"... on UnassignedEvent"`
+	MilestonedEvent struct {
+		timelineEventFields
+		MilestoneTitle githubv4.String
+	} `graphql:"... on MilestonedEvent"`
+	DemilestonedEvent struct {
+		timelineEventFields
+		MilestoneTitle githubv4.String
+	} `graphql:"... on DemilestonedEvent"`
+	RenamedTitleEvent struct {
+		timelineEventFields
+		PreviousTitle githubv4.String
+		CurrentTitle  githubv4.String
+	} `graphql:"... on RenamedTitleEvent"`
+	ClosedEvent          timelineClosedEvent          `graphql:"... on ClosedEvent"`
+	ReopenedEvent        timelineEventFields          `graphql:"... on ReopenedEvent"`
+	CrossReferencedEvent timelineCrossReferencedEvent `graphql:"... on CrossReferencedEvent"`
+	ReferencedEvent      struct {
+		timelineEventFields
+		Commit struct {
+			OID githubv4.GitObjectID
+			URL githubv4.String
+		}
+	} `graphql:"... on ReferencedEvent"`
+	ConnectedEvent struct {
+		timelineEventFields
+		Subject timelineSubject
+	} `graphql:"... on ConnectedEvent"`
+	MarkedAsDuplicateEvent struct {
+		timelineEventFields
+		Canonical timelineSubject
+	} `graphql:"... on MarkedAsDuplicateEvent"`
+}
 
-	return sb.String()
+// issueTimelineQuery fetches a page of the issue timeline together with the closing event and every
+// cross-reference, so that the summary does not depend on which page or event types were requested.
+type issueTimelineQuery struct {
+	Repository struct {
+		Issue struct {
+			Number                         githubv4.Int
+			Title                          githubv4.String
+			State                          githubv4.String
+			StateReason                    githubv4.String
+			URL                            githubv4.String
+			ClosedByPullRequestsReferences struct {
+				Nodes []struct {
+					Number githubv4.Int
+					Title  githubv4.String
+					State  githubv4.String
+					URL    githubv4.String
+				}
+			} `graphql:"closedByPullRequestsReferences(first: 10, includeClosedPrs: true)"`
+			ClosedEvents struct {
+				Nodes []struct {
+					ClosedEvent timelineClosedEvent `graphql:"... on ClosedEvent"`
+				}
+			} `graphql:"closedEvents: timelineItems(last: 1, itemTypes: [CLOSED_EVENT])"`
+			CrossReferences struct {
+				Nodes []struct {
+					CrossReferencedEvent timelineCrossReferencedEvent `graphql:"... on CrossReferencedEvent"`
+				}
+			} `graphql:"crossReferences: timelineItems(first: 100, itemTypes: [CROSS_REFERENCED_EVENT])"`
+			TimelineItems struct {
+				TotalCount githubv4.Int
+				PageInfo   struct {
+					HasNextPage githubv4.Boolean
+					EndCursor   githubv4.String
+				}
+				Nodes []issueTimelineNode
+			} `graphql:"timelineItems(first: $first, after: $after, itemTypes: $itemTypes)"`
+		} `graphql:"issue(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
 }
 
-func AssignCopilotToIssue(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
-	description := mvpDescription{
-		summary: "Assign Copilot to a specific issue in a GitHub repository.",
-		outcomes: []string{
-			"a Pull Request created with source code changes to resolve the issue",
-		},
-		referenceLinks: []string{
-			"https://docs.github.com/en/copilot/using-github-copilot/using-copilot-coding-agent-to-work-on-tasks/about-assigning-tasks-to-copilot",
-		},
+// GetIssueTimeline creates a tool to get the timeline of events for a GitHub issue.
+func GetIssueTimeline(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	eventTypeNames := make([]string, 0, len(issueTimelineEventTypes))
+	for _, eventType := range issueTimelineEventTypes {
+		eventTypeNames = append(eventTypeNames, eventType.Name)
 	}
 
-	return mcp.NewTool("assign_copilot_to_issue",
-			mcp.WithDescription(t("TOOL_ASSIGN_COPILOT_TO_ISSUE_DESCRIPTION", description.String())),
+	return mcp.NewTool("get_issue_timeline",
+			mcp.WithDescription(t("TOOL_GET_ISSUE_TIMELINE_DESCRIPTION", "Get the timeline of a specific issue in a GitHub repository: comments, label and assignee changes, milestones, renames, closures, reopenings and references from commits, issues and pull requests. The summary always reports what closed the issue, the pull requests linked to close it and every issue or pull request that cross-references it, regardless of the page or event types requested.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:          t("TOOL_ASSIGN_COPILOT_TO_ISSUE_USER_TITLE", "Assign Copilot to issue"),
+				Title:        t("TOOL_GET_ISSUE_TIMELINE_USER_TITLE", "Get issue timeline"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number"),
+			),
+			mcp.WithArray("event_types",
+				mcp.Description("Only return these event types (default all)"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+						"enum": eventTypeNames,
+					},
+				),
+			),
+			mcp.WithNumber("per_page",
+				mcp.Description("Number of events per page (max 100)"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			eventTypes, err := OptionalStringArrayParam(request, "event_types")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			perPage, err := OptionalIntParamWithDefault(request, "per_page", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			after, err := OptionalParam[string](request, "after")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if perPage < 1 || perPage > 100 {
+				return mcp.NewToolResultError("per_page must be between 1 and 100"), nil
+			}
+
+			// Always pass an explicit list of item types, so that we never receive events we have no fragment for.
+			itemTypes := make([]githubv4.IssueTimelineItemsItemType, 0, len(issueTimelineEventTypes))
+			names := make(map[string]string, len(issueTimelineEventTypes))
+			for _, eventType := range issueTimelineEventTypes {
+				names[eventType.TypeName] = eventType.Name
+				if len(eventTypes) == 0 {
+					itemTypes = append(itemTypes, eventType.ItemType)
+				}
+			}
+			for _, name := range eventTypes {
+				found := false
+				for _, eventType := range issueTimelineEventTypes {
+					if eventType.Name == name {
+						itemTypes = append(itemTypes, eventType.ItemType)
+						found = true
+						break
+					}
+				}
+				if !found {
+					return mcp.NewToolResultError(fmt.Sprintf("unknown event type %q, expected one of: %s", name, strings.Join(eventTypeNames, ", "))), nil
+				}
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			variables := map[string]any{
+				"owner":     githubv4.String(owner),
+				"name":      githubv4.String(repo),
+				"number":    githubv4.Int(int32(issueNumber)), // #nosec G115 - issue numbers are always small positive integers
+				"first":     githubv4.Int(int32(perPage)),     // #nosec G115 - bounded to 100 above
+				"after":     (*githubv4.String)(nil),
+				"itemTypes": itemTypes,
+			}
+			if after != "" {
+				variables["after"] = githubv4.String(after)
+			}
+
+			var query issueTimelineQuery
+			if err := client.Query(ctx, &query, variables); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue timeline: %v", err)), nil
+			}
+			issue := query.Repository.Issue
+			reportPageInfo(ctx, bool(issue.TimelineItems.PageInfo.HasNextPage), string(issue.TimelineItems.PageInfo.EndCursor))
+
+			type TimelineReference struct {
+				Type       string `json:"type"`
+				Number     int    `json:"number,omitempty"`
+				Title      string `json:"title,omitempty"`
+				State      string `json:"state,omitempty"`
+				Repository string `json:"repository,omitempty"`
+				SHA        string `json:"sha,omitempty"`
+				URL        string `json:"url,omitempty"`
+			}
+
+			type TimelineEvent struct {
+				Type          string             `json:"type"`
+				Actor         string             `json:"actor,omitempty"`
+				CreatedAt     string             `json:"created_at"`
+				URL           string             `json:"url,omitempty"`
+				Body          string             `json:"body,omitempty"`
+				Label         string             `json:"label,omitempty"`
+				Assignee      string             `json:"assignee,omitempty"`
+				Milestone     string             `json:"milestone,omitempty"`
+				PreviousTitle string             `json:"previous_title,omitempty"`
+				CurrentTitle  string             `json:"current_title,omitempty"`
+				StateReason   string             `json:"state_reason,omitempty"`
+				WillClose     bool               `json:"will_close,omitempty"`
+				Reference     *TimelineReference `json:"reference,omitempty"`
+			}
+
+			type CrossReference struct {
+				TimelineReference
+				Actor     string `json:"actor,omitempty"`
+				CreatedAt string `json:"created_at"`
+				WillClose bool   `json:"will_close"`
+			}
+
+			type ClosedBy struct {
+				*TimelineReference
+				Actor       string `json:"actor,omitempty"`
+				ClosedAt    string `json:"closed_at"`
+				StateReason string `json:"state_reason,omitempty"`
+			}
+
+			type TimelineSummary struct {
+				ClosedBy           *ClosedBy           `json:"closed_by,omitempty"`
+				LinkedPullRequests []TimelineReference `json:"linked_pull_requests"`
+				CrossReferences    []CrossReference    `json:"cross_references"`
+			}
+
+			subjectReference := func(subject timelineSubject) *TimelineReference {
+				switch subject.TypeName {
+				case "Issue":
+					return &TimelineReference{
+						Type:       "issue",
+						Number:     int(subject.Issue.Number),
+						Title:      string(subject.Issue.Title),
+						State:      strings.ToLower(string(subject.Issue.IssueState)),
+						Repository: string(subject.Issue.Repository.NameWithOwner),
+						URL:        string(subject.Issue.URL),
+					}
+				case "PullRequest":
+					return &TimelineReference{
+						Type:       "pull_request",
+						Number:     int(subject.PullRequest.Number),
+						Title:      string(subject.PullRequest.Title),
+						State:      strings.ToLower(string(subject.PullRequest.PullRequestState)),
+						Repository: string(subject.PullRequest.Repository.NameWithOwner),
+						URL:        string(subject.PullRequest.URL),
+					}
+				default:
+					return nil
+				}
+			}
+
+			closerReference := func(closer timelineCloser) *TimelineReference {
+				switch closer.TypeName {
+				case "PullRequest":
+					return &TimelineReference{
+						Type:   "pull_request",
+						Number: int(closer.PullRequest.Number),
+						Title:  string(closer.PullRequest.Title),
+						URL:    string(closer.PullRequest.URL),
+					}
+				case "Commit":
+					return &TimelineReference{
+						Type: "commit",
+						SHA:  string(closer.Commit.OID),
+						URL:  string(closer.Commit.URL),
+					}
+				default:
+					return nil
+				}
+			}
+
+			events := make([]TimelineEvent, 0, len(issue.TimelineItems.Nodes))
+			for _, node := range issue.TimelineItems.Nodes {
+				var fields timelineEventFields
+				event := TimelineEvent{Type: names[string(node.TypeName)]}
+				switch node.TypeName {
+				case "IssueComment":
+					fields = timelineEventFields{Actor: node.IssueComment.Author, CreatedAt: node.IssueComment.CreatedAt}
+					event.Body = string(node.IssueComment.Body)
+					event.URL = string(node.IssueComment.URL)
+				case "LabeledEvent":
+					fields = node.LabeledEvent.timelineEventFields
+					event.Label = string(node.LabeledEvent.Label.Name)
+				case "UnlabeledEvent":
+					fields = node.UnlabeledEvent.timelineEventFields
+					event.Label = string(node.UnlabeledEvent.Label.Name)
+				case "AssignedEvent":
+					fields = node.AssignedEvent.timelineEventFields
+					event.Assignee = string(node.AssignedEvent.Assignee.Actor.Login)
+				case "UnassignedEvent":
+					fields = node.UnassignedEvent.timelineEventFields
+					event.Assignee = string(node.UnassignedEvent.Assignee.Actor.Login)
+				case "MilestonedEvent":
+					fields = node.MilestonedEvent.timelineEventFields
+					event.Milestone = string(node.MilestonedEvent.MilestoneTitle)
+				case "DemilestonedEvent":
+					fields = node.DemilestonedEvent.timelineEventFields
+					event.Milestone = string(node.DemilestonedEvent.MilestoneTitle)
+				case "RenamedTitleEvent":
+					fields = node.RenamedTitleEvent.timelineEventFields
+					event.PreviousTitle = string(node.RenamedTitleEvent.PreviousTitle)
+					event.CurrentTitle = string(node.RenamedTitleEvent.CurrentTitle)
+				case "ClosedEvent":
+					fields = node.ClosedEvent.timelineEventFields
+					event.StateReason = strings.ToLower(string(node.ClosedEvent.StateReason))
+					event.Reference = closerReference(node.ClosedEvent.Closer)
+				case "ReopenedEvent":
+					fields = node.ReopenedEvent
+				case "CrossReferencedEvent":
+					fields = node.CrossReferencedEvent.timelineEventFields
+					event.WillClose = bool(node.CrossReferencedEvent.WillCloseTarget)
+					event.Reference = subjectReference(node.CrossReferencedEvent.Source)
+				case "ReferencedEvent":
+					fields = node.ReferencedEvent.timelineEventFields
+					event.Reference = &TimelineReference{
+						Type: "commit",
+						SHA:  string(node.ReferencedEvent.Commit.OID),
+						URL:  string(node.ReferencedEvent.Commit.URL),
+					}
+				case "ConnectedEvent":
+					fields = node.ConnectedEvent.timelineEventFields
+					event.Reference = subjectReference(node.ConnectedEvent.Subject)
+				case "MarkedAsDuplicateEvent":
+					fields = node.MarkedAsDuplicateEvent.timelineEventFields
+					event.Reference = subjectReference(node.MarkedAsDuplicateEvent.Canonical)
+				default:
+					continue
+				}
+				event.Actor = string(fields.Actor.Login)
+				event.CreatedAt = fields.CreatedAt.Format(time.RFC3339)
+				events = append(events, event)
+			}
+
+			summary := TimelineSummary{
+				LinkedPullRequests: make([]TimelineReference, 0, len(issue.ClosedByPullRequestsReferences.Nodes)),
+				CrossReferences:    make([]CrossReference, 0, len(issue.CrossReferences.Nodes)),
+			}
+			// The last closed event only explains the current state if the issue wasn't reopened since.
+			if issue.State == "CLOSED" && len(issue.ClosedEvents.Nodes) > 0 {
+				closed := issue.ClosedEvents.Nodes[len(issue.ClosedEvents.Nodes)-1].ClosedEvent
+				summary.ClosedBy = &ClosedBy{
+					TimelineReference: closerReference(closed.Closer),
+					Actor:             string(closed.Actor.Login),
+					ClosedAt:          closed.CreatedAt.Format(time.RFC3339),
+					StateReason:       strings.ToLower(string(closed.StateReason)),
+				}
+			}
+			for _, pr := range issue.ClosedByPullRequestsReferences.Nodes {
+				summary.LinkedPullRequests = append(summary.LinkedPullRequests, TimelineReference{
+					Type:   "pull_request",
+					Number: int(pr.Number),
+					Title:  string(pr.Title),
+					State:  strings.ToLower(string(pr.State)),
+					URL:    string(pr.URL),
+				})
+			}
+			for _, node := range issue.CrossReferences.Nodes {
+				ref := subjectReference(node.CrossReferencedEvent.Source)
+				if ref == nil {
+					continue
+				}
+				summary.CrossReferences = append(summary.CrossReferences, CrossReference{
+					TimelineReference: *ref,
+					Actor:             string(node.CrossReferencedEvent.Actor.Login),
+					CreatedAt:         node.CrossReferencedEvent.CreatedAt.Format(time.RFC3339),
+					WillClose:         bool(node.CrossReferencedEvent.WillCloseTarget),
+				})
+			}
+
+			result := map[string]any{
+				"issue": map[string]any{
+					"number":       int(issue.Number),
+					"title":        string(issue.Title),
+					"state":        strings.ToLower(string(issue.State)),
+					"state_reason": strings.ToLower(string(issue.StateReason)),
+					"url":          string(issue.URL),
+				},
+				"summary":     summary,
+				"events":      events,
+				"total_count": int(issue.TimelineItems.TotalCount),
+				"page_info": map[string]any{
+					"has_next_page": bool(issue.TimelineItems.PageInfo.HasNextPage),
+					"end_cursor":    string(issue.TimelineItems.PageInfo.EndCursor),
+				},
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal issue timeline: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// mvpDescription is an MVP idea for generating tool descriptions from structured data in a shared format.
+// It is not intended for widespread usage and is not a complete implementation.
+type mvpDescription struct {
+	summary        string
+	outcomes       []string
+	referenceLinks []string
+}
+
+func (d *mvpDescription) String() string {
+	var sb strings.Builder
+	sb.WriteString(d.summary)
+	if len(d.outcomes) > 0 {
+		sb.WriteString("\n\n")
+		sb.WriteString("This tool can help with the following outcomes:\n")
+		for _, outcome := range d.outcomes {
+			sb.WriteString(fmt.Sprintf("- %s\n", outcome))
+		}
+	}
+
+	if len(d.referenceLinks) > 0 {
+		sb.WriteString("\n\n")
+		sb.WriteString("More information can be found at:\n")
+		for _, link := range d.referenceLinks {
+			sb.WriteString(fmt.Sprintf("- %s\n", link))
+		}
+	}
+
+	return sb.String()
+}
+
+func AssignCopilotToIssue(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	description := mvpDescription{
+		summary: "Assign Copilot to a specific issue in a GitHub repository.",
+		outcomes: []string{
+			"a Pull Request created with source code changes to resolve the issue",
+		},
+		referenceLinks: []string{
+			"https://docs.github.com/en/copilot/using-github-copilot/using-copilot-coding-agent-to-work-on-tasks/about-assigning-tasks-to-copilot",
+		},
+	}
+
+	return mcp.NewTool("assign_copilot_to_issue",
+			mcp.WithDescription(t("TOOL_ASSIGN_COPILOT_TO_ISSUE_DESCRIPTION", description.String())),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_ASSIGN_COPILOT_TO_ISSUE_USER_TITLE", "Assign Copilot to issue"),
 				ReadOnlyHint:   toBoolPtr(false),
 				IdempotentHint: toBoolPtr(true),
 			}),
@@ -1454,6 +1529,9 @@ func AssignCopilotToIssue(getGQLClient GetGQLClientFn, t translations.Translatio
 				"endCursor": (*githubv4.String)(nil),
 			}
 
+			// The number of pages of suggested actors isn't known up front, so there is no total
+			progress := newProgressReporter(ctx, request, 0)
+
 			var copilotAssignee *botAssignee
 			for {
 				var query suggestedActorsQuery
@@ -1471,6 +1549,8 @@ func AssignCopilotToIssue(getGQLClient GetGQLClientFn, t translations.Translatio
 					}
 				}
 
+				progress.Step(fmt.Sprintf("Looked for copilot among %d suggested assignees", len(query.Repository.SuggestedActors.Nodes)))
+
 				if !query.Repository.SuggestedActors.PageInfo.HasNextPage {
 					break
 				}
@@ -1507,6 +1587,7 @@ func AssignCopilotToIssue(getGQLClient GetGQLClientFn, t translations.Translatio
 			if err := client.Query(ctx, &getIssueQuery, variables); err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue ID: %v", err)), nil
 			}
+			progress.Step("Got the issue's current assignees")
 
 			// Finally, do the assignment. Just for reference, assigning copilot to an issue that it is already
 			// assigned to seems to have no impact (which is a good thing).
@@ -1533,6 +1614,7 @@ func AssignCopilotToIssue(getGQLClient GetGQLClientFn, t translations.Translatio
 			); err != nil {
 				return nil, fmt.Errorf("failed to replace actors for assignable: %w", err)
 			}
+			progress.Step("Assigned copilot")
 
 			return mcp.NewToolResultText("successfully assigned copilot to issue"), nil
 		}
@@ -1543,6 +1625,487 @@ type ReplaceActorsForAssignableInput struct {
 	ActorIDs     []githubv4.ID `json:"actorIds"`
 }
 
+// issueIDQuery looks up the node ID of an issue, which the GraphQL mutations need.
+type issueIDQuery struct {
+	Repository struct {
+		Issue struct {
+			ID githubv4.ID
+		} `graphql:"issue(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+func getIssueNodeID(ctx context.Context, client *githubv4.Client, owner, repo string, number int) (githubv4.ID, error) {
+	var query issueIDQuery
+	variables := map[string]any{
+		"owner":  githubv4.String(owner),
+		"name":   githubv4.String(repo),
+		"number": githubv4.Int(int32(number)), // #nosec G115 - issue numbers are always small positive integers
+	}
+	if err := client.Query(ctx, &query, variables); err != nil {
+		return nil, fmt.Errorf("failed to get issue %s/%s#%d: %w", owner, repo, number, err)
+	}
+	return query.Repository.Issue.ID, nil
+}
+
+// TransferIssue creates a tool to transfer an issue to another repository.
+func TransferIssue(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("transfer_issue",
+			mcp.WithDescription(t("TOOL_TRANSFER_ISSUE_DESCRIPTION", "Transfer an issue to another repository. The issue gets a new number in the target repository, and the old URL redirects to it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_TRANSFER_ISSUE_USER_TITLE", "Transfer issue"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number to transfer"),
+			),
+			mcp.WithString("target_repo",
+				mcp.Required(),
+				mcp.Description("Name of the repository to transfer the issue to"),
+			),
+			mcp.WithString("target_owner",
+				mcp.Description("Owner of the repository to transfer the issue to, if different from owner"),
+			),
+			mcp.WithBoolean("create_labels_if_missing",
+				mcp.Description("Create labels of the issue that don't exist in the target repository, instead of dropping them"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			targetRepo, err := requiredParam[string](request, "target_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			targetOwner, err := OptionalParam[string](request, "target_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if targetOwner == "" {
+				targetOwner = owner
+			}
+			createLabels, err := OptionalParam[bool](request, "create_labels_if_missing")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			issueID, err := getIssueNodeID(ctx, client, owner, repo, issueNumber)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var repoQuery struct {
+				Repository struct {
+					ID githubv4.ID
+				} `graphql:"repository(owner: $owner, name: $name)"`
+			}
+			if err := client.Query(ctx, &repoQuery, map[string]any{
+				"owner": githubv4.String(targetOwner),
+				"name":  githubv4.String(targetRepo),
+			}); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get repository %s/%s: %v", targetOwner, targetRepo, err)), nil
+			}
+
+			var mutation struct {
+				TransferIssue struct {
+					Issue struct {
+						Number     githubv4.Int
+						URL        githubv4.String
+						Repository struct {
+							NameWithOwner githubv4.String
+						}
+					}
+				} `graphql:"transferIssue(input: $input)"`
+			}
+			input := githubv4.TransferIssueInput{
+				IssueID:               issueID,
+				RepositoryID:          repoQuery.Repository.ID,
+				CreateLabelsIfMissing: githubv4.NewBoolean(githubv4.Boolean(createLabels)),
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to transfer issue: %v", err)), nil
+			}
+
+			transferred := mutation.TransferIssue.Issue
+			r, err := json.Marshal(map[string]any{
+				"number":     int(transferred.Number),
+				"url":        string(transferred.URL),
+				"repository": string(transferred.Repository.NameWithOwner),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal transferred issue: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// LockIssue creates a tool to lock the conversation on an issue.
+func LockIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("lock_issue",
+			mcp.WithDescription(t("TOOL_LOCK_ISSUE_DESCRIPTION", "Lock the conversation on an issue or pull request, so that only collaborators can comment on it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_LOCK_ISSUE_USER_TITLE", "Lock issue conversation"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number to lock"),
+			),
+			mcp.WithString("lock_reason",
+				mcp.Description("Reason for locking, shown on the issue"),
+				mcp.Enum("off-topic", "too heated", "resolved", "spam"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			lockReason, err := OptionalParam[string](request, "lock_reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Issues.Lock(ctx, owner, repo, issueNumber, &github.LockIssueOptions{LockReason: lockReason})
+			if err != nil {
+				return nil, fmt.Errorf("failed to lock issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to lock issue: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("locked conversation on issue #%d", issueNumber)), nil
+		}
+}
+
+// UnlockIssue creates a tool to unlock the conversation on an issue.
+func UnlockIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unlock_issue",
+			mcp.WithDescription(t("TOOL_UNLOCK_ISSUE_DESCRIPTION", "Unlock the conversation on an issue or pull request.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_UNLOCK_ISSUE_USER_TITLE", "Unlock issue conversation"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number to unlock"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Issues.Unlock(ctx, owner, repo, issueNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to unlock issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to unlock issue: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("unlocked conversation on issue #%d", issueNumber)), nil
+		}
+}
+
+// PinIssue creates a tool to pin an issue to the top of its repository's issue list.
+func PinIssue(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("pin_issue",
+			mcp.WithDescription(t("TOOL_PIN_ISSUE_DESCRIPTION", "Pin an issue to the top of its repository's issue list. A repository can have at most three pinned issues.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_PIN_ISSUE_USER_TITLE", "Pin issue"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number to pin"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var mutation struct {
+				PinIssue struct {
+					Issue struct {
+						IsPinned githubv4.Boolean
+					}
+				} `graphql:"pinIssue(input: $input)"`
+			}
+			return mutateIssuePin(ctx, getGQLClient, request, "pin", "pinned", func(client *githubv4.Client, issueID githubv4.ID) error {
+				return client.Mutate(ctx, &mutation, githubv4.PinIssueInput{IssueID: issueID}, nil)
+			})
+		}
+}
+
+// UnpinIssue creates a tool to unpin an issue from its repository's issue list.
+func UnpinIssue(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unpin_issue",
+			mcp.WithDescription(t("TOOL_UNPIN_ISSUE_DESCRIPTION", "Unpin an issue from the top of its repository's issue list.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_UNPIN_ISSUE_USER_TITLE", "Unpin issue"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number to unpin"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var mutation struct {
+				UnpinIssue struct {
+					Issue struct {
+						IsPinned githubv4.Boolean
+					}
+				} `graphql:"unpinIssue(input: $input)"`
+			}
+			return mutateIssuePin(ctx, getGQLClient, request, "unpin", "unpinned", func(client *githubv4.Client, issueID githubv4.ID) error {
+				return client.Mutate(ctx, &mutation, githubv4.UnpinIssueInput{IssueID: issueID}, nil)
+			})
+		}
+}
+
+// mutateIssuePin resolves the issue named by the request and applies a pin or unpin mutation to it.
+func mutateIssuePin(ctx context.Context, getGQLClient GetGQLClientFn, request mcp.CallToolRequest, action, done string, mutate func(*githubv4.Client, githubv4.ID) error) (*mcp.CallToolResult, error) {
+	owner, err := requiredParam[string](request, "owner")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	repo, err := requiredParam[string](request, "repo")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	issueNumber, err := RequiredInt(request, "issue_number")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := getGQLClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+	}
+
+	issueID, err := getIssueNodeID(ctx, client, owner, repo, issueNumber)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err := mutate(client, issueID); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to %s issue: %v", action, err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("%s issue #%d", done, issueNumber)), nil
+}
+
+// CloseIssueInput is the input of the closeIssue mutation. It is declared here rather than using
+// githubv4.CloseIssueInput, which predates closing issues as duplicates.
+type CloseIssueInput struct {
+	IssueID          githubv4.ID                      `json:"issueId"`
+	StateReason      *githubv4.IssueClosedStateReason `json:"stateReason,omitempty"`
+	DuplicateIssueID *githubv4.ID                     `json:"duplicateIssueId,omitempty"`
+}
+
+// issueClosedStateReasonDuplicate is missing from githubv4's IssueClosedStateReason enum.
+const issueClosedStateReasonDuplicate githubv4.IssueClosedStateReason = "DUPLICATE"
+
+// CloseIssueAsDuplicate creates a tool to close an issue as a duplicate of another issue.
+func CloseIssueAsDuplicate(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("close_issue_as_duplicate",
+			mcp.WithDescription(t("TOOL_CLOSE_ISSUE_AS_DUPLICATE_DESCRIPTION", "Close an issue as a duplicate of another issue. The issue is marked as a duplicate in both timelines, and shown as closed as duplicate rather than completed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CLOSE_ISSUE_AS_DUPLICATE_USER_TITLE", "Close issue as duplicate"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the issue to close"),
+			),
+			mcp.WithNumber("duplicate_of",
+				mcp.Required(),
+				mcp.Description("Number of the issue it duplicates"),
+			),
+			mcp.WithString("duplicate_of_repo",
+				mcp.Description("Repository of the issue it duplicates, if it is in another repository of the same owner"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			duplicateOf, err := RequiredInt(request, "duplicate_of")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			duplicateOfRepo, err := OptionalParam[string](request, "duplicate_of_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if duplicateOfRepo == "" {
+				duplicateOfRepo = repo
+			}
+			if duplicateOfRepo == repo && duplicateOf == issueNumber {
+				return mcp.NewToolResultError("an issue cannot be a duplicate of itself"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			issueID, err := getIssueNodeID(ctx, client, owner, repo, issueNumber)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			duplicateID, err := getIssueNodeID(ctx, client, owner, duplicateOfRepo, duplicateOf)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var mutation struct {
+				CloseIssue struct {
+					Issue struct {
+						State       githubv4.String
+						StateReason githubv4.String
+						URL         githubv4.String
+					}
+				} `graphql:"closeIssue(input: $input)"`
+			}
+			stateReason := issueClosedStateReasonDuplicate
+			input := CloseIssueInput{
+				IssueID:          issueID,
+				StateReason:      &stateReason,
+				DuplicateIssueID: &duplicateID,
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to close issue: %v", err)), nil
+			}
+
+			closed := mutation.CloseIssue.Issue
+			r, err := json.Marshal(map[string]any{
+				"number":       issueNumber,
+				"state":        strings.ToLower(string(closed.State)),
+				"state_reason": strings.ToLower(string(closed.StateReason)),
+				"url":          string(closed.URL),
+				"duplicate_of": fmt.Sprintf("%s/%s#%d", owner, duplicateOfRepo, duplicateOf),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal closed issue: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
 // parseISOTimestamp parses an ISO 8601 timestamp string into a time.Time object.
 // Returns the parsed time or an error if parsing fails.
 // Example formats supported: "2023-01-15T14:30:00Z", "2023-01-15"
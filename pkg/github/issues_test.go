@@ -9,8 +9,11 @@ import (
 	"time"
 
 	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
 	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
@@ -21,6 +24,7 @@ func Test_GetIssue(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
 	tool, _ := GetIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "get_issue", tool.Name)
 	assert.NotEmpty(t, tool.Description)
@@ -109,6 +113,12 @@ func Test_GetIssue(t *testing.T) {
 			assert.Equal(t, *tc.expectedIssue.Number, *returnedIssue.Number)
 			assert.Equal(t, *tc.expectedIssue.Title, *returnedIssue.Title)
 			assert.Equal(t, *tc.expectedIssue.Body, *returnedIssue.Body)
+
+			// The structured result holds the same issue
+			structured, ok := result.StructuredContent.(MinimalIssue)
+			require.True(t, ok)
+			assert.Equal(t, *tc.expectedIssue.Number, structured.Number)
+			assert.Equal(t, *tc.expectedIssue.Title, structured.Title)
 		})
 	}
 }
@@ -230,6 +240,7 @@ func Test_SearchIssues(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
 	tool, _ := SearchIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "search_issues", tool.Name)
 	assert.NotEmpty(t, tool.Description)
@@ -255,6 +266,8 @@ func Test_SearchIssues(t *testing.T) {
 				User: &github.User{
 					Login: github.Ptr("user1"),
 				},
+				Assignee:  &github.User{Login: github.Ptr("user2")},
+				Assignees: []*github.User{{Login: github.Ptr("user2")}},
 			},
 			{
 				Number:   github.Ptr(43),
@@ -377,6 +390,14 @@ func Test_SearchIssues(t *testing.T) {
 				assert.Equal(t, *tc.expectedResult.Issues[i].State, *issue.State)
 				assert.Equal(t, *tc.expectedResult.Issues[i].HTMLURL, *issue.HTMLURL)
 				assert.Equal(t, *tc.expectedResult.Issues[i].User.Login, *issue.User.Login)
+				// The first assignee is kept in assignee alongside assignees, as the GitHub API returns it
+				if expected := tc.expectedResult.Issues[i].Assignee; expected != nil {
+					require.NotNil(t, issue.Assignee)
+					assert.Equal(t, expected.GetLogin(), issue.Assignee.GetLogin())
+					require.Len(t, issue.Assignees, 1)
+				} else {
+					assert.Nil(t, issue.Assignee)
+				}
 			}
 		})
 	}
@@ -386,6 +407,7 @@ func Test_CreateIssue(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
 	tool, _ := CreateIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "create_issue", tool.Name)
 	assert.NotEmpty(t, tool.Description)
@@ -396,6 +418,8 @@ func Test_CreateIssue(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "assignees")
 	assert.Contains(t, tool.InputSchema.Properties, "labels")
 	assert.Contains(t, tool.InputSchema.Properties, "milestone")
+	assert.Contains(t, tool.InputSchema.Properties, "template")
+	assert.Contains(t, tool.InputSchema.Properties, "template_fields")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "title"})
 
 	// Setup mock issue for success case
@@ -560,6 +584,7 @@ func Test_ListIssues(t *testing.T) {
 	// Verify tool definition
 	mockClient := github.NewClient(nil)
 	tool, _ := ListIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "list_issues", tool.Name)
 	assert.NotEmpty(t, tool.Description)
@@ -728,6 +753,14 @@ func Test_ListIssues(t *testing.T) {
 				assert.Equal(t, *tc.expectedIssues[i].State, *issue.State)
 				assert.Equal(t, *tc.expectedIssues[i].HTMLURL, *issue.HTMLURL)
 			}
+
+			// The structured result wraps the list in an object
+			structured, ok := result.StructuredContent.(MinimalList[MinimalIssue])
+			require.True(t, ok)
+			require.Len(t, structured.Items, len(tc.expectedIssues))
+			for i, issue := range structured.Items {
+				assert.Equal(t, *tc.expectedIssues[i].Number, issue.Number)
+			}
 		})
 	}
 }
@@ -736,6 +769,7 @@ func Test_UpdateIssue(t *testing.T) {
 	// Verify tool definition
 	mockClient := github.NewClient(nil)
 	tool, _ := UpdateIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "update_issue", tool.Name)
 	assert.NotEmpty(t, tool.Description)
@@ -745,6 +779,7 @@ func Test_UpdateIssue(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "title")
 	assert.Contains(t, tool.InputSchema.Properties, "body")
 	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "state_reason")
 	assert.Contains(t, tool.InputSchema.Properties, "labels")
 	assert.Contains(t, tool.InputSchema.Properties, "assignees")
 	assert.Contains(t, tool.InputSchema.Properties, "milestone")
@@ -828,6 +863,39 @@ func Test_UpdateIssue(t *testing.T) {
 				State:   github.Ptr("open"),
 			},
 		},
+		{
+			name: "close issue as not planned",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"state":        "closed",
+						"state_reason": "not_planned",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{
+							Number:  github.Ptr(123),
+							Title:   github.Ptr("Won't fix"),
+							HTMLURL: github.Ptr("https://github.com/owner/repo/issues/123"),
+							State:   github.Ptr("closed"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(123),
+				"state":        "closed",
+				"state_reason": "not_planned",
+			},
+			expectError: false,
+			expectedIssue: &github.Issue{
+				Number:  github.Ptr(123),
+				Title:   github.Ptr("Won't fix"),
+				HTMLURL: github.Ptr("https://github.com/owner/repo/issues/123"),
+				State:   github.Ptr("closed"),
+			},
+		},
 		{
 			name: "update issue fails with not found",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -1541,3 +1609,786 @@ func TestAssignCopilotToIssue(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetIssueTimeline(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := GetIssueTimeline(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_issue_timeline", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "event_types")
+	assert.Contains(t, tool.InputSchema.Properties, "per_page")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	allItemTypes := make([]githubv4.IssueTimelineItemsItemType, 0, len(issueTimelineEventTypes))
+	for _, eventType := range issueTimelineEventTypes {
+		allItemTypes = append(allItemTypes, eventType.ItemType)
+	}
+
+	closingPR := map[string]any{
+		"__typename": "PullRequest",
+		"number":     7,
+		"title":      "Fix the crash",
+		"url":        "https://github.com/owner/repo/pull/7",
+	}
+	// Summary nodes are selected through a single fragment, so unlike timeline nodes they carry no __typename.
+	timelineNode := func(typename string, fields map[string]any) map[string]any {
+		node := map[string]any{"__typename": typename}
+		for k, v := range fields {
+			node[k] = v
+		}
+		return node
+	}
+	closedEvent := map[string]any{
+		"actor":       map[string]any{"login": "maintainer"},
+		"createdAt":   "2025-01-03T00:00:00Z",
+		"stateReason": "COMPLETED",
+		"closer":      closingPR,
+	}
+	crossReference := map[string]any{
+		"actor":           map[string]any{"login": "contributor"},
+		"createdAt":       "2025-01-02T00:00:00Z",
+		"willCloseTarget": false,
+		"source": map[string]any{
+			"__typename": "Issue",
+			"number":     12,
+			"title":      "Same crash on Windows",
+			"url":        "https://github.com/other/repo/issues/12",
+			"issueState": "OPEN",
+			"repository": map[string]any{"nameWithOwner": "other/repo"},
+		},
+	}
+	issueResponse := func(nodes []any, hasNextPage bool, endCursor string) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"issue": map[string]any{
+					"number":      42,
+					"title":       "App crashes on start",
+					"state":       "CLOSED",
+					"stateReason": "COMPLETED",
+					"url":         "https://github.com/owner/repo/issues/42",
+					"closedByPullRequestsReferences": map[string]any{
+						"nodes": []any{
+							map[string]any{
+								"number": 7,
+								"title":  "Fix the crash",
+								"state":  "MERGED",
+								"url":    "https://github.com/owner/repo/pull/7",
+							},
+						},
+					},
+					"closedEvents":    map[string]any{"nodes": []any{closedEvent}},
+					"crossReferences": map[string]any{"nodes": []any{crossReference}},
+					"timelineItems": map[string]any{
+						"totalCount": 4,
+						"pageInfo": map[string]any{
+							"hasNextPage": hasNextPage,
+							"endCursor":   endCursor,
+						},
+						"nodes": nodes,
+					},
+				},
+			},
+		})
+	}
+
+	type timelineResult struct {
+		Issue struct {
+			Number int    `json:"number"`
+			State  string `json:"state"`
+		} `json:"issue"`
+		Summary struct {
+			ClosedBy *struct {
+				Type        string `json:"type"`
+				Number      int    `json:"number"`
+				Actor       string `json:"actor"`
+				StateReason string `json:"state_reason"`
+			} `json:"closed_by"`
+			LinkedPullRequests []struct {
+				Number int    `json:"number"`
+				State  string `json:"state"`
+			} `json:"linked_pull_requests"`
+			CrossReferences []struct {
+				Type       string `json:"type"`
+				Number     int    `json:"number"`
+				Repository string `json:"repository"`
+				State      string `json:"state"`
+			} `json:"cross_references"`
+		} `json:"summary"`
+		Events []struct {
+			Type      string `json:"type"`
+			Actor     string `json:"actor"`
+			CreatedAt string `json:"created_at"`
+			Label     string `json:"label"`
+			Body      string `json:"body"`
+			Reference *struct {
+				Type   string `json:"type"`
+				Number int    `json:"number"`
+			} `json:"reference"`
+		} `json:"events"`
+		TotalCount int `json:"total_count"`
+		PageInfo   struct {
+			HasNextPage bool   `json:"has_next_page"`
+			EndCursor   string `json:"end_cursor"`
+		} `json:"page_info"`
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedEventTypes []string
+		expectedNextPage   bool
+	}{
+		{
+			name: "full timeline",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					issueTimelineQuery{},
+					map[string]any{
+						"owner":     githubv4.String("owner"),
+						"name":      githubv4.String("repo"),
+						"number":    githubv4.Int(42),
+						"first":     githubv4.Int(30),
+						"after":     (*githubv4.String)(nil),
+						"itemTypes": allItemTypes,
+					},
+					issueResponse([]any{
+						map[string]any{
+							"__typename": "IssueComment",
+							"author":     map[string]any{"login": "reporter"},
+							"createdAt":  "2025-01-01T00:00:00Z",
+							"body":       "It crashes",
+							"url":        "https://github.com/owner/repo/issues/42#issuecomment-1",
+						},
+						map[string]any{
+							"__typename": "LabeledEvent",
+							"actor":      map[string]any{"login": "maintainer"},
+							"createdAt":  "2025-01-01T01:00:00Z",
+							"label":      map[string]any{"name": "bug"},
+						},
+						timelineNode("CrossReferencedEvent", crossReference),
+						timelineNode("ClosedEvent", closedEvent),
+					}, false, "cursor-1"),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectedEventTypes: []string{"comment", "labeled", "cross_referenced", "closed"},
+		},
+		{
+			name: "filtered page after a cursor",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					issueTimelineQuery{},
+					map[string]any{
+						"owner":  githubv4.String("owner"),
+						"name":   githubv4.String("repo"),
+						"number": githubv4.Int(42),
+						"first":  githubv4.Int(1),
+						"after":  githubv4.String("cursor-1"),
+						"itemTypes": []githubv4.IssueTimelineItemsItemType{
+							githubv4.IssueTimelineItemsItemTypeLabeledEvent,
+						},
+					},
+					issueResponse([]any{
+						map[string]any{
+							"__typename": "LabeledEvent",
+							"actor":      map[string]any{"login": "maintainer"},
+							"createdAt":  "2025-01-01T01:00:00Z",
+							"label":      map[string]any{"name": "bug"},
+						},
+					}, true, "cursor-2"),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"event_types":  []any{"labeled"},
+				"per_page":     float64(1),
+				"after":        "cursor-1",
+			},
+			expectedEventTypes: []string{"labeled"},
+			expectedNextPage:   true,
+		},
+		{
+			name:         "unknown event type",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"event_types":  []any{"exploded"},
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: `unknown event type "exploded"`,
+		},
+		{
+			name: "query fails",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					issueTimelineQuery{},
+					map[string]any{
+						"owner":     githubv4.String("owner"),
+						"name":      githubv4.String("repo"),
+						"number":    githubv4.Int(999),
+						"first":     githubv4.Int(30),
+						"after":     (*githubv4.String)(nil),
+						"itemTypes": allItemTypes,
+					},
+					githubv4mock.ErrorResponse("Could not resolve to an Issue with the number of 999."),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(999),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "failed to get issue timeline",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := GetIssueTimeline(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned timelineResult
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+
+			assert.Equal(t, 42, returned.Issue.Number)
+			assert.Equal(t, "closed", returned.Issue.State)
+
+			// The summary is the same whichever page or event types were requested
+			require.NotNil(t, returned.Summary.ClosedBy)
+			assert.Equal(t, "pull_request", returned.Summary.ClosedBy.Type)
+			assert.Equal(t, 7, returned.Summary.ClosedBy.Number)
+			assert.Equal(t, "maintainer", returned.Summary.ClosedBy.Actor)
+			assert.Equal(t, "completed", returned.Summary.ClosedBy.StateReason)
+			require.Len(t, returned.Summary.LinkedPullRequests, 1)
+			assert.Equal(t, "merged", returned.Summary.LinkedPullRequests[0].State)
+			require.Len(t, returned.Summary.CrossReferences, 1)
+			assert.Equal(t, "issue", returned.Summary.CrossReferences[0].Type)
+			assert.Equal(t, 12, returned.Summary.CrossReferences[0].Number)
+			assert.Equal(t, "other/repo", returned.Summary.CrossReferences[0].Repository)
+			assert.Equal(t, "open", returned.Summary.CrossReferences[0].State)
+
+			eventTypes := make([]string, 0, len(returned.Events))
+			for _, event := range returned.Events {
+				eventTypes = append(eventTypes, event.Type)
+				assert.NotEmpty(t, event.Actor)
+				assert.NotEmpty(t, event.CreatedAt)
+				switch event.Type {
+				case "comment":
+					assert.Equal(t, "It crashes", event.Body)
+				case "labeled":
+					assert.Equal(t, "bug", event.Label)
+				case "closed":
+					require.NotNil(t, event.Reference)
+					assert.Equal(t, 7, event.Reference.Number)
+				case "cross_referenced":
+					require.NotNil(t, event.Reference)
+					assert.Equal(t, "issue", event.Reference.Type)
+				}
+			}
+			assert.Equal(t, tc.expectedEventTypes, eventTypes)
+			assert.Equal(t, 4, returned.TotalCount)
+			assert.Equal(t, tc.expectedNextPage, returned.PageInfo.HasNextPage)
+		})
+	}
+}
+
+func issueIDMatcher(repo string, number int, id string) githubv4mock.Matcher {
+	return githubv4mock.NewQueryMatcher(
+		issueIDQuery{},
+		map[string]any{
+			"owner":  githubv4.String("owner"),
+			"name":   githubv4.String(repo),
+			"number": githubv4.Int(number),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"issue": map[string]any{"id": id},
+			},
+		}),
+	)
+}
+
+func Test_TransferIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := TransferIssue(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "transfer_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "target_owner")
+	assert.Contains(t, tool.InputSchema.Properties, "target_repo")
+	assert.Contains(t, tool.InputSchema.Properties, "create_labels_if_missing")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "target_repo"})
+
+	repoIDQuery := struct {
+		Repository struct {
+			ID githubv4.ID
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}{}
+	transferMutation := struct {
+		TransferIssue struct {
+			Issue struct {
+				Number     githubv4.Int
+				URL        githubv4.String
+				Repository struct {
+					NameWithOwner githubv4.String
+				}
+			}
+		} `graphql:"transferIssue(input: $input)"`
+	}{}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+	}{
+		{
+			name: "transfer to another repository of the same owner",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				issueIDMatcher("repo", 42, "I_issue"),
+				githubv4mock.NewQueryMatcher(
+					repoIDQuery,
+					map[string]any{
+						"owner": githubv4.String("owner"),
+						"name":  githubv4.String("other-repo"),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"repository": map[string]any{"id": "R_other"},
+					}),
+				),
+				githubv4mock.NewMutationMatcher(
+					transferMutation,
+					githubv4.TransferIssueInput{
+						IssueID:               githubv4.ID("I_issue"),
+						RepositoryID:          githubv4.ID("R_other"),
+						CreateLabelsIfMissing: githubv4.NewBoolean(true),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"transferIssue": map[string]any{
+							"issue": map[string]any{
+								"number":     7,
+								"url":        "https://github.com/owner/other-repo/issues/7",
+								"repository": map[string]any{"nameWithOwner": "owner/other-repo"},
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":                    "owner",
+				"repo":                     "repo",
+				"issue_number":             float64(42),
+				"target_repo":              "other-repo",
+				"create_labels_if_missing": true,
+			},
+		},
+		{
+			name: "target repository not found",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				issueIDMatcher("repo", 42, "I_issue"),
+				githubv4mock.NewQueryMatcher(
+					repoIDQuery,
+					map[string]any{
+						"owner": githubv4.String("owner"),
+						"name":  githubv4.String("missing"),
+					},
+					githubv4mock.ErrorResponse("Could not resolve to a Repository with the name 'owner/missing'."),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"target_repo":  "missing",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "failed to get repository owner/missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := TransferIssue(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			var returned map[string]any
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, float64(7), returned["number"])
+			assert.Equal(t, "owner/other-repo", returned["repository"])
+		})
+	}
+}
+
+func Test_LockIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := LockIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "lock_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "lock_reason")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "lock with a reason",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposIssuesLockByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"lock_reason": "too heated",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"lock_reason":  "too heated",
+			},
+		},
+		{
+			name: "lock fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposIssuesLockByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to lock issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := LockIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "locked conversation on issue #42", getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_UnlockIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UnlockIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "unlock_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposIssuesLockByOwnerByRepoByIssueNumber,
+			expectPath(t, "/repos/owner/repo/issues/42/lock").andThen(
+				mockResponse(t, http.StatusNoContent, nil),
+			),
+		),
+	))
+	_, handler := UnlockIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(42),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "unlocked conversation on issue #42", getTextResult(t, result).Text)
+}
+
+func Test_PinIssue(t *testing.T) {
+	// Verify tool definitions once
+	mockClient := githubv4.NewClient(nil)
+	pinTool, _ := PinIssue(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	unpinTool, _ := UnpinIssue(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "pin_issue", pinTool.Name)
+	assert.Equal(t, "unpin_issue", unpinTool.Name)
+	assert.ElementsMatch(t, pinTool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+	assert.ElementsMatch(t, unpinTool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	pinned := func(mutation string, isPinned bool) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			mutation: map[string]any{
+				"issue": map[string]any{"isPinned": isPinned},
+			},
+		})
+	}
+
+	tests := []struct {
+		name               string
+		tool               func(GetGQLClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+		mockedClient       *http.Client
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedText       string
+	}{
+		{
+			name: "pin",
+			tool: PinIssue,
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				issueIDMatcher("repo", 42, "I_issue"),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						PinIssue struct {
+							Issue struct {
+								IsPinned githubv4.Boolean
+							}
+						} `graphql:"pinIssue(input: $input)"`
+					}{},
+					githubv4.PinIssueInput{IssueID: githubv4.ID("I_issue")},
+					nil,
+					pinned("pinIssue", true),
+				),
+			),
+			expectedText: "pinned issue #42",
+		},
+		{
+			name: "unpin",
+			tool: UnpinIssue,
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				issueIDMatcher("repo", 42, "I_issue"),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						UnpinIssue struct {
+							Issue struct {
+								IsPinned githubv4.Boolean
+							}
+						} `graphql:"unpinIssue(input: $input)"`
+					}{},
+					githubv4.UnpinIssueInput{IssueID: githubv4.ID("I_issue")},
+					nil,
+					pinned("unpinIssue", false),
+				),
+			),
+			expectedText: "unpinned issue #42",
+		},
+		{
+			name: "pin limit reached",
+			tool: PinIssue,
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				issueIDMatcher("repo", 42, "I_issue"),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						PinIssue struct {
+							Issue struct {
+								IsPinned githubv4.Boolean
+							}
+						} `graphql:"pinIssue(input: $input)"`
+					}{},
+					githubv4.PinIssueInput{IssueID: githubv4.ID("I_issue")},
+					nil,
+					githubv4mock.ErrorResponse("Repository already has 3 pinned issues"),
+				),
+			),
+			expectToolError:    true,
+			expectedToolErrMsg: "failed to pin issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := tc.tool(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			}))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_CloseIssueAsDuplicate(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := CloseIssueAsDuplicate(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "close_issue_as_duplicate", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "duplicate_of")
+	assert.Contains(t, tool.InputSchema.Properties, "duplicate_of_repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "duplicate_of"})
+
+	closeMutation := struct {
+		CloseIssue struct {
+			Issue struct {
+				State       githubv4.String
+				StateReason githubv4.String
+				URL         githubv4.String
+			}
+		} `graphql:"closeIssue(input: $input)"`
+	}{}
+	duplicate := issueClosedStateReasonDuplicate
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+	}{
+		{
+			name: "close as duplicate of an issue in another repository",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				issueIDMatcher("repo", 42, "I_duplicate"),
+				issueIDMatcher("other-repo", 7, "I_canonical"),
+				githubv4mock.NewMutationMatcher(
+					closeMutation,
+					CloseIssueInput{
+						IssueID:          githubv4.ID("I_duplicate"),
+						StateReason:      &duplicate,
+						DuplicateIssueID: githubv4mock.Ptr(githubv4.ID("I_canonical")),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"closeIssue": map[string]any{
+							"issue": map[string]any{
+								"state":       "CLOSED",
+								"stateReason": "DUPLICATE",
+								"url":         "https://github.com/owner/repo/issues/42",
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":             "owner",
+				"repo":              "repo",
+				"issue_number":      float64(42),
+				"duplicate_of":      float64(7),
+				"duplicate_of_repo": "other-repo",
+			},
+		},
+		{
+			name:         "duplicate of itself",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"duplicate_of": float64(42),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "an issue cannot be a duplicate of itself",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := CloseIssueAsDuplicate(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			var returned map[string]any
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, "closed", returned["state"])
+			assert.Equal(t, "duplicate", returned["state_reason"])
+			assert.Equal(t, "owner/other-repo#7", returned["duplicate_of"])
+		})
+	}
+}
@@ -0,0 +1,594 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	OutputFormatJSON     = "json"
+	OutputFormatMarkdown = "markdown"
+)
+
+// WithOutputFormat adds a "format" parameter to a tool, so that callers can ask for its result as
+// markdown rather than JSON. Only tools that have a markdown renderer should offer it.
+func WithOutputFormat() mcp.ToolOption {
+	return mcp.WithString("format",
+		mcp.Description("Optional: format of the result. Defaults to the server's output format, which is json unless configured otherwise"),
+		mcp.Enum(OutputFormatJSON, OutputFormatMarkdown),
+	)
+}
+
+// ValidateOutputFormat checks that format is one of the output formats the server supports.
+func ValidateOutputFormat(format string) error {
+	switch format {
+	case OutputFormatJSON, OutputFormatMarkdown:
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q, must be %q or %q", format, OutputFormatJSON, OutputFormatMarkdown)
+	}
+}
+
+// markdownRenderFunc renders the JSON result of a tool as markdown.
+type markdownRenderFunc func(data []byte) (string, error)
+
+// markdownRenderers are the renderers for the tools that offer a markdown result, by tool name.
+var markdownRenderers = map[string]markdownRenderFunc{
+	"get_issue":                 renderAs(renderIssue),
+	"list_issues":               renderListAs(renderIssueList),
+	"get_issue_comments":        renderListAs(renderComments),
+	"get_pull_request":          renderAs(renderPullRequest),
+	"list_pull_requests":        renderListAs(renderPullRequestList),
+	"get_pull_request_reviews":  renderListAs(renderReviews),
+	"get_pull_request_comments": renderListAs(renderReviewThreads),
+	"get_commit":                renderAs(renderCommit),
+	"list_commits":              renderListAs(renderCommitList),
+	"search_issues":             renderAs(renderSearchResult(renderIssueList)),
+	"search_repositories":       renderAs(renderSearchResult(renderRepositoryList)),
+	"search_code":               renderAs(renderSearchResult(renderCodeResults)),
+	"search_users":              renderAs(renderSearchResult(renderUserList)),
+	"list_notifications":        renderListAs(renderNotifications),
+}
+
+// MarkdownRenderer renders tool results as markdown for callers that ask for it, or for every
+// call when markdown is the server's output format. Results that are not JSON, such as errors,
+// are passed through unchanged.
+type MarkdownRenderer struct {
+	defaultFormat string
+}
+
+// NewMarkdownRenderer creates a MarkdownRenderer that uses defaultFormat for calls that do not
+// choose a format themselves.
+func NewMarkdownRenderer(defaultFormat string) *MarkdownRenderer {
+	return &MarkdownRenderer{defaultFormat: defaultFormat}
+}
+
+// Middleware renders the results of the tool handler it wraps.
+func (m *MarkdownRenderer) Middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		render, ok := markdownRenderers[request.Params.Name]
+		if !ok {
+			return next(ctx, request)
+		}
+
+		format, err := OptionalParam[string](request, "format")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if format == "" {
+			format = m.defaultFormat
+		}
+		if err := ValidateOutputFormat(format); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		result, err := next(ctx, request)
+		if err != nil || result == nil || result.IsError || format != OutputFormatMarkdown {
+			return result, err
+		}
+
+		// The first text is the result; any after it are notes, e.g. about truncation
+		rendered := *result
+		rendered.Content = make([]mcp.Content, len(result.Content))
+		copy(rendered.Content, result.Content)
+		for i, c := range rendered.Content {
+			text, ok := c.(mcp.TextContent)
+			if !ok {
+				continue
+			}
+			if markdown, err := render([]byte(text.Text)); err == nil {
+				text.Text = markdown
+				rendered.Content[i] = text
+			}
+			break
+		}
+		return &rendered, nil
+	}
+}
+
+// renderAs makes a renderer for a result that is a single T.
+func renderAs[T any](render func(b *strings.Builder, v T)) markdownRenderFunc {
+	return func(data []byte) (string, error) {
+		var v T
+		if err := json.Unmarshal(data, &v); err != nil {
+			return "", err
+		}
+		var b strings.Builder
+		render(&b, v)
+		return strings.TrimRight(b.String(), "\n"), nil
+	}
+}
+
+// renderListAs makes a renderer for a result that is a list of T, either as a JSON array or as a
+// MinimalList.
+func renderListAs[T any](render func(b *strings.Builder, items []T)) markdownRenderFunc {
+	return func(data []byte) (string, error) {
+		var items []T
+		if err := json.Unmarshal(data, &items); err != nil {
+			var list MinimalList[T]
+			if err := json.Unmarshal(data, &list); err != nil {
+				return "", err
+			}
+			items = list.Items
+		}
+		var b strings.Builder
+		render(&b, items)
+		return strings.TrimRight(b.String(), "\n"), nil
+	}
+}
+
+func renderSearchResult[T any](render func(b *strings.Builder, items []T)) func(b *strings.Builder, result MinimalSearchResult[T]) {
+	return func(b *strings.Builder, result MinimalSearchResult[T]) {
+		fmt.Fprintf(b, "Found %d results", result.TotalCount)
+		if result.IncompleteResults {
+			b.WriteString(" (incomplete, the search timed out)")
+		}
+		if len(result.Items) < result.TotalCount {
+			fmt.Fprintf(b, ", showing %d", len(result.Items))
+		}
+		b.WriteString(".\n\n")
+		render(b, result.Items)
+	}
+}
+
+func renderIssue(b *strings.Builder, issue MinimalIssue) {
+	fmt.Fprintf(b, "# #%d %s\n\n", issue.Number, issue.Title)
+	writeFacts(b,
+		fact("State", issue.State),
+		fact("Author", userLogin(issue.User)),
+		fact("Created", issue.CreatedAt),
+		fact("Updated", issue.UpdatedAt),
+		fact("Closed", issue.ClosedAt),
+		fact("Labels", labelNames(issue.Labels)),
+		fact("Assignees", userLogins(issue.Assignees)),
+		fact("Milestone", milestoneTitle(issue.Milestone)),
+		fact("Comments", fmt.Sprint(issue.Comments)),
+		fact("URL", issue.HTMLURL),
+	)
+	writeBody(b, issue.Body)
+}
+
+func renderIssueList(b *strings.Builder, issues []MinimalIssue) {
+	if len(issues) == 0 {
+		b.WriteString("No issues found.\n")
+		return
+	}
+	for _, issue := range issues {
+		fmt.Fprintf(b, "- #%d **%s**", issue.Number, issue.Title)
+		writeDetails(b,
+			repositoryName(issue.Repository),
+			issue.State,
+			prefixed("by ", userLogin(issue.User)),
+			labelNames(issue.Labels),
+			prefixed("updated ", issue.UpdatedAt),
+			issue.HTMLURL,
+		)
+	}
+}
+
+// markdownComment is a comment as the comment tools return it. Review comments also have the
+// place in the diff they were made on.
+type markdownComment struct {
+	Body      string       `json:"body"`
+	User      *MinimalUser `json:"user"`
+	CreatedAt string       `json:"created_at"`
+	HTMLURL   string       `json:"html_url"`
+	Path      string       `json:"path"`
+	Position  int          `json:"position"`
+	DiffHunk  string       `json:"diff_hunk"`
+}
+
+func renderComments(b *strings.Builder, comments []markdownComment) {
+	if len(comments) == 0 {
+		b.WriteString("No comments.\n")
+		return
+	}
+	for _, comment := range comments {
+		writeComment(b, "###", comment)
+	}
+}
+
+func writeComment(b *strings.Builder, heading string, comment markdownComment) {
+	fmt.Fprintf(b, "%s %s", heading, userLogin(comment.User))
+	if comment.CreatedAt != "" {
+		fmt.Fprintf(b, " on %s", comment.CreatedAt)
+	}
+	b.WriteString("\n\n")
+	if comment.HTMLURL != "" {
+		fmt.Fprintf(b, "%s\n\n", comment.HTMLURL)
+	}
+	writeBody(b, comment.Body)
+}
+
+func renderPullRequest(b *strings.Builder, pr MinimalPullRequest) {
+	fmt.Fprintf(b, "# #%d %s\n\n", pr.Number, pr.Title)
+	var changes string
+	if pr.ChangedFiles != nil {
+		changes = fmt.Sprintf("%d files, +%d −%d", *pr.ChangedFiles, deref(pr.Additions), deref(pr.Deletions))
+	}
+	var commits string
+	if pr.Commits != nil {
+		commits = fmt.Sprint(*pr.Commits)
+	}
+	var mergeable string
+	if pr.Mergeable != nil {
+		mergeable = fmt.Sprint(*pr.Mergeable)
+		if pr.MergeableState != "" {
+			mergeable += " (" + pr.MergeableState + ")"
+		}
+	}
+	writeFacts(b,
+		fact("State", pullRequestState(pr)),
+		fact("Author", userLogin(pr.User)),
+		fact("Branches", branches(pr)),
+		fact("Created", pr.CreatedAt),
+		fact("Updated", pr.UpdatedAt),
+		fact("Merged", pr.MergedAt),
+		fact("Closed", pr.ClosedAt),
+		fact("Labels", labelNames(pr.Labels)),
+		fact("Assignees", userLogins(pr.Assignees)),
+		fact("Commits", commits),
+		fact("Changes", changes),
+		fact("Mergeable", mergeable),
+		fact("URL", pr.HTMLURL),
+	)
+	writeBody(b, pr.Body)
+}
+
+func renderPullRequestList(b *strings.Builder, prs []MinimalPullRequest) {
+	if len(prs) == 0 {
+		b.WriteString("No pull requests found.\n")
+		return
+	}
+	for _, pr := range prs {
+		fmt.Fprintf(b, "- #%d **%s**", pr.Number, pr.Title)
+		writeDetails(b,
+			pullRequestState(pr),
+			prefixed("by ", userLogin(pr.User)),
+			branches(pr),
+			labelNames(pr.Labels),
+			prefixed("updated ", pr.UpdatedAt),
+			pr.HTMLURL,
+		)
+	}
+}
+
+// markdownReview is a review as get_pull_request_reviews returns it.
+type markdownReview struct {
+	User        *MinimalUser `json:"user"`
+	Body        string       `json:"body"`
+	State       string       `json:"state"`
+	SubmittedAt string       `json:"submitted_at"`
+	HTMLURL     string       `json:"html_url"`
+}
+
+func renderReviews(b *strings.Builder, reviews []markdownReview) {
+	if len(reviews) == 0 {
+		b.WriteString("No reviews.\n")
+		return
+	}
+	for _, review := range reviews {
+		fmt.Fprintf(b, "### %s: %s", userLogin(review.User), review.State)
+		if review.SubmittedAt != "" {
+			fmt.Fprintf(b, " on %s", review.SubmittedAt)
+		}
+		b.WriteString("\n\n")
+		if review.HTMLURL != "" {
+			fmt.Fprintf(b, "%s\n\n", review.HTMLURL)
+		}
+		writeBody(b, review.Body)
+	}
+}
+
+// renderReviewThreads groups review comments into threads, by the file and place in the diff they
+// were made on, and shows the diff once for each thread.
+func renderReviewThreads(b *strings.Builder, comments []markdownComment) {
+	if len(comments) == 0 {
+		b.WriteString("No review comments.\n")
+		return
+	}
+
+	type threadKey struct {
+		path     string
+		position int
+	}
+	var order []threadKey
+	threads := make(map[threadKey][]markdownComment)
+	for _, comment := range comments {
+		key := threadKey{comment.Path, comment.Position}
+		if _, seen := threads[key]; !seen {
+			order = append(order, key)
+		}
+		threads[key] = append(threads[key], comment)
+	}
+
+	for _, key := range order {
+		thread := threads[key]
+		fmt.Fprintf(b, "## `%s`", key.path)
+		if key.position > 0 {
+			fmt.Fprintf(b, " (diff position %d)", key.position)
+		}
+		b.WriteString("\n\n")
+		if hunk := thread[0].DiffHunk; hunk != "" {
+			fmt.Fprintf(b, "```diff\n%s\n```\n\n", strings.TrimRight(hunk, "\n"))
+		}
+		for _, comment := range thread {
+			writeComment(b, "###", comment)
+		}
+	}
+}
+
+func renderCommit(b *strings.Builder, commit MinimalCommit) {
+	title, message := commitMessage(commit)
+	fmt.Fprintf(b, "# %s %s\n\n", shortSHA(commit.SHA), title)
+	var stats string
+	if commit.Stats != nil {
+		stats = fmt.Sprintf("+%d −%d", commit.Stats.Additions, commit.Stats.Deletions)
+	}
+	writeFacts(b,
+		fact("Author", commitAuthor(commit)),
+		fact("Date", commitDate(commit)),
+		fact("Changes", stats),
+		fact("URL", commit.HTMLURL),
+	)
+	writeBody(b, message)
+
+	if len(commit.Files) > 0 {
+		b.WriteString("| File | Status | Additions | Deletions |\n| --- | --- | --- | --- |\n")
+		for _, file := range commit.Files {
+			fmt.Fprintf(b, "| `%s` | %s | %d | %d |\n", file.Filename, file.Status, file.Additions, file.Deletions)
+		}
+	}
+}
+
+func renderCommitList(b *strings.Builder, commits []MinimalCommit) {
+	if len(commits) == 0 {
+		b.WriteString("No commits found.\n")
+		return
+	}
+	for _, commit := range commits {
+		title, _ := commitMessage(commit)
+		fmt.Fprintf(b, "- `%s` %s", shortSHA(commit.SHA), title)
+		writeDetails(b, commitAuthor(commit), commitDate(commit))
+	}
+}
+
+func renderRepositoryList(b *strings.Builder, repos []MinimalRepository) {
+	for _, repo := range repos {
+		fmt.Fprintf(b, "- **%s**", repo.FullName)
+		if repo.Description != "" {
+			fmt.Fprintf(b, ": %s", repo.Description)
+		}
+		var visibility string
+		switch {
+		case repo.Archived:
+			visibility = "archived"
+		case repo.Private:
+			visibility = "private"
+		}
+		writeDetails(b, repo.Language, visibility, prefixed("updated ", repo.UpdatedAt), repo.HTMLURL)
+	}
+}
+
+func renderCodeResults(b *strings.Builder, results []MinimalCodeResult) {
+	for _, result := range results {
+		fmt.Fprintf(b, "- **%s** `%s`", result.Repository.FullName, result.Path)
+		writeDetails(b, result.HTMLURL)
+		for _, match := range result.TextMatches {
+			if fragment := match.GetFragment(); fragment != "" {
+				fmt.Fprintf(b, "\n  ```\n  %s\n  ```\n", strings.ReplaceAll(strings.TrimRight(fragment, "\n"), "\n", "\n  "))
+			}
+		}
+	}
+}
+
+func renderUserList(b *strings.Builder, users []MinimalUserDetails) {
+	for _, user := range users {
+		fmt.Fprintf(b, "- **@%s**", user.Login)
+		if user.Name != "" {
+			fmt.Fprintf(b, " (%s)", user.Name)
+		}
+		if user.Bio != "" {
+			fmt.Fprintf(b, ": %s", user.Bio)
+		}
+		writeDetails(b, user.Type, user.Company, user.Location, user.HTMLURL)
+	}
+}
+
+// markdownNotification is a notification as list_notifications returns it.
+type markdownNotification struct {
+	ID        string `json:"id"`
+	Unread    bool   `json:"unread"`
+	Reason    string `json:"reason"`
+	UpdatedAt string `json:"updated_at"`
+	Subject   struct {
+		Title string `json:"title"`
+		Type  string `json:"type"`
+	} `json:"subject"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+func renderNotifications(b *strings.Builder, notifications []markdownNotification) {
+	if len(notifications) == 0 {
+		b.WriteString("No notifications.\n")
+		return
+	}
+	for _, notification := range notifications {
+		b.WriteString("- ")
+		if notification.Unread {
+			b.WriteString("**Unread** ")
+		}
+		fmt.Fprintf(b, "%s: %s", notification.Subject.Type, notification.Subject.Title)
+		writeDetails(b,
+			notification.Repository.FullName,
+			notification.Reason,
+			prefixed("updated ", notification.UpdatedAt),
+			prefixed("id ", notification.ID),
+		)
+	}
+}
+
+type markdownFact struct{ name, value string }
+
+func fact(name, value string) markdownFact { return markdownFact{name, value} }
+
+// writeFacts writes the facts that have a value as a list.
+func writeFacts(b *strings.Builder, facts ...markdownFact) {
+	for _, f := range facts {
+		if f.value != "" {
+			fmt.Fprintf(b, "- **%s:** %s\n", f.name, f.value)
+		}
+	}
+	b.WriteString("\n")
+}
+
+// writeDetails ends a list item with the details that have a value.
+func writeDetails(b *strings.Builder, details ...string) {
+	var present []string
+	for _, detail := range details {
+		if detail != "" {
+			present = append(present, detail)
+		}
+	}
+	if len(present) > 0 {
+		fmt.Fprintf(b, " · %s", strings.Join(present, " · "))
+	}
+	b.WriteString("\n")
+}
+
+func writeBody(b *strings.Builder, body string) {
+	if body = strings.TrimSpace(body); body != "" {
+		fmt.Fprintf(b, "%s\n\n", body)
+	}
+}
+
+func prefixed(prefix, s string) string {
+	if s == "" {
+		return ""
+	}
+	return prefix + s
+}
+
+func userLogin(user *MinimalUser) string {
+	if user == nil || user.Login == "" {
+		return ""
+	}
+	return "@" + user.Login
+}
+
+func userLogins(users []*MinimalUser) string {
+	logins := make([]string, 0, len(users))
+	for _, user := range users {
+		if login := userLogin(user); login != "" {
+			logins = append(logins, login)
+		}
+	}
+	return strings.Join(logins, ", ")
+}
+
+func labelNames(labels []MinimalLabel) string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, "`"+label.Name+"`")
+	}
+	return strings.Join(names, " ")
+}
+
+func milestoneTitle(milestone *MinimalMilestone) string {
+	if milestone == nil {
+		return ""
+	}
+	return milestone.Title
+}
+
+func repositoryName(repo *MinimalRepositoryRef) string {
+	if repo == nil {
+		return ""
+	}
+	return repo.FullName
+}
+
+func pullRequestState(pr MinimalPullRequest) string {
+	switch {
+	case pr.MergedAt != "" || (pr.Merged != nil && *pr.Merged):
+		return "merged"
+	case pr.Draft && pr.State == "open":
+		return "draft"
+	default:
+		return pr.State
+	}
+}
+
+func branches(pr MinimalPullRequest) string {
+	if pr.Head.Ref == "" && pr.Base.Ref == "" {
+		return ""
+	}
+	return fmt.Sprintf("`%s` → `%s`", pr.Head.Ref, pr.Base.Ref)
+}
+
+// commitMessage splits a commit message into its first line and the rest.
+func commitMessage(commit MinimalCommit) (title, rest string) {
+	if commit.Commit == nil {
+		return "", ""
+	}
+	title, rest, _ = strings.Cut(commit.Commit.Message, "\n")
+	return title, rest
+}
+
+func commitAuthor(commit MinimalCommit) string {
+	if login := userLogin(commit.Author); login != "" {
+		return login
+	}
+	if commit.Commit != nil && commit.Commit.Author != nil {
+		return commit.Commit.Author.Name
+	}
+	return ""
+}
+
+func commitDate(commit MinimalCommit) string {
+	if commit.Commit == nil || commit.Commit.Author == nil {
+		return ""
+	}
+	return commit.Commit.Author.Date
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+func deref(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
@@ -0,0 +1,139 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MarkdownRenderer(t *testing.T) {
+	issue := MinimalIssue{
+		Number:    42,
+		Title:     "Crash on startup",
+		State:     "open",
+		HTMLURL:   "https://github.com/owner/repo/issues/42",
+		Body:      "It crashes.",
+		User:      &MinimalUser{Login: "octocat"},
+		Labels:    []MinimalLabel{{Name: "bug"}},
+		Comments:  2,
+		CreatedAt: "2025-01-01T00:00:00Z",
+	}
+	issueJSON, err := json.Marshal(issue)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		defaultFormat string
+		args          map[string]any
+		expectedError string
+		expectedText  string
+	}{
+		{
+			name:          "returns json by default",
+			defaultFormat: OutputFormatJSON,
+			args:          map[string]any{},
+			expectedText:  string(issueJSON),
+		},
+		{
+			name:          "renders markdown when the call asks for it",
+			defaultFormat: OutputFormatJSON,
+			args:          map[string]any{"format": "markdown"},
+			expectedText: "# #42 Crash on startup\n\n" +
+				"- **State:** open\n" +
+				"- **Author:** @octocat\n" +
+				"- **Created:** 2025-01-01T00:00:00Z\n" +
+				"- **Labels:** `bug`\n" +
+				"- **Comments:** 2\n" +
+				"- **URL:** https://github.com/owner/repo/issues/42\n\n" +
+				"It crashes.",
+		},
+		{
+			name:          "the call can ask for json when the server renders markdown",
+			defaultFormat: OutputFormatMarkdown,
+			args:          map[string]any{"format": "json"},
+			expectedText:  string(issueJSON),
+		},
+		{
+			name:          "rejects unknown formats",
+			defaultFormat: OutputFormatJSON,
+			args:          map[string]any{"format": "html"},
+			expectedError: `unknown output format "html", must be "json" or "markdown"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			renderer := NewMarkdownRenderer(tc.defaultFormat)
+			handler := renderer.Middleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return mcp.NewToolResultStructured(issue, string(issueJSON)), nil
+			})
+
+			request := createMCPRequest(tc.args)
+			request.Params.Name = "get_issue"
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectedError != "" {
+				assert.True(t, result.IsError)
+				assert.Equal(t, tc.expectedError, textContent.Text)
+				return
+			}
+			assert.Equal(t, tc.expectedText, textContent.Text)
+			// The structured result stays JSON either way
+			assert.Equal(t, issue, result.StructuredContent)
+		})
+	}
+}
+
+func Test_MarkdownRenderers(t *testing.T) {
+	tests := []struct {
+		name     string
+		tool     string
+		result   string
+		expected string
+	}{
+		{
+			name:     "commit list",
+			tool:     "list_commits",
+			result:   `[{"sha":"abc123def456","author":{"login":"octocat"},"commit":{"message":"Fix the bug\n\nDetails","author":{"name":"Octo Cat","date":"2025-01-02T00:00:00Z"}}}]`,
+			expected: "- `abc123d` Fix the bug · @octocat · 2025-01-02T00:00:00Z",
+		},
+		{
+			name:   "review comments grouped into threads",
+			tool:   "get_pull_request_comments",
+			result: `[{"path":"main.go","position":3,"diff_hunk":"@@ -1 +1 @@\n-a\n+b","body":"Why?","user":{"login":"alice"}},{"path":"main.go","position":3,"diff_hunk":"@@ -1 +1 @@\n-a\n+b","body":"Because.","user":{"login":"bob"}}]`,
+			expected: "## `main.go` (diff position 3)\n\n" +
+				"```diff\n@@ -1 +1 @@\n-a\n+b\n```\n\n" +
+				"### @alice\n\nWhy?\n\n" +
+				"### @bob\n\nBecause.",
+		},
+		{
+			name:   "search results",
+			tool:   "search_repositories",
+			result: `{"total_count":5,"incomplete_results":false,"items":[{"full_name":"owner/repo","description":"A repo","language":"Go","html_url":"https://github.com/owner/repo"}]}`,
+			expected: "Found 5 results, showing 1.\n\n" +
+				"- **owner/repo**: A repo · Go · https://github.com/owner/repo",
+		},
+		{
+			name:     "empty lists",
+			tool:     "get_pull_request_reviews",
+			result:   `[]`,
+			expected: "No reviews.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			render, ok := markdownRenderers[tc.tool]
+			require.True(t, ok)
+			markdown, err := render([]byte(tc.result))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, markdown)
+		})
+	}
+}
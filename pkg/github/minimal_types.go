@@ -0,0 +1,426 @@
+package github
+
+import (
+	"time"
+
+	"github.com/google/go-github/v72/github"
+)
+
+// The Minimal types are the results tools return for GitHub objects: the fields an agent needs,
+// without the many API URLs the full objects carry. They are also the source of the tools'
+// output schemas, so changing them changes the tools' declared output.
+
+// MinimalUser is a user as it appears in other objects, e.g. as an author or assignee.
+type MinimalUser struct {
+	Login     string `json:"login,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+	HTMLURL   string `json:"html_url,omitempty"`
+}
+
+// MinimalUserDetails is a user as found by search_users.
+type MinimalUserDetails struct {
+	Login       string `json:"login,omitempty"`
+	ID          int64  `json:"id,omitempty"`
+	NodeID      string `json:"node_id,omitempty"`
+	AvatarURL   string `json:"avatar_url,omitempty"`
+	HTMLURL     string `json:"html_url,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Company     string `json:"company,omitempty"`
+	Blog        string `json:"blog,omitempty"`
+	Location    string `json:"location,omitempty"`
+	Email       string `json:"email,omitempty"`
+	Bio         string `json:"bio,omitempty"`
+	Twitter     string `json:"twitter_username,omitempty"`
+	PublicRepos int    `json:"public_repos,omitempty"`
+	Followers   int    `json:"followers,omitempty"`
+	Following   int    `json:"following,omitempty"`
+}
+
+type MinimalLabel struct {
+	Name        string `json:"name,omitempty"`
+	Color       string `json:"color,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// MinimalRepositoryRef identifies the repository an issue or search result belongs to.
+type MinimalRepositoryRef struct {
+	Name     string `json:"name,omitempty"`
+	FullName string `json:"full_name,omitempty"`
+	HTMLURL  string `json:"html_url,omitempty"`
+}
+
+type MinimalRepository struct {
+	Name            string       `json:"name,omitempty"`
+	FullName        string       `json:"full_name,omitempty"`
+	Description     string       `json:"description,omitempty"`
+	HTMLURL         string       `json:"html_url,omitempty"`
+	Language        string       `json:"language,omitempty"`
+	Private         bool         `json:"private"`
+	Archived        bool         `json:"archived"`
+	CreatedAt       string       `json:"created_at,omitempty"`
+	UpdatedAt       string       `json:"updated_at,omitempty"`
+	OpenIssuesCount int          `json:"open_issues_count"`
+	Topics          []string     `json:"topics,omitempty"`
+	Owner           *MinimalUser `json:"owner,omitempty"`
+}
+
+type MinimalMilestone struct {
+	Number int    `json:"number"`
+	Title  string `json:"title,omitempty"`
+	State  string `json:"state,omitempty"`
+	DueOn  string `json:"due_on,omitempty"`
+}
+
+// MinimalPullRequestLink marks an issue that is a pull request.
+type MinimalPullRequestLink struct {
+	URL string `json:"url,omitempty"`
+}
+
+type MinimalIssue struct {
+	ID               int64                   `json:"id,omitempty"`
+	NodeID           string                  `json:"node_id,omitempty"`
+	Number           int                     `json:"number"`
+	Title            string                  `json:"title,omitempty"`
+	State            string                  `json:"state,omitempty"`
+	HTMLURL          string                  `json:"html_url,omitempty"`
+	Body             string                  `json:"body,omitempty"`
+	User             *MinimalUser            `json:"user,omitempty"`
+	Labels           []MinimalLabel          `json:"labels,omitempty"`
+	Assignee         *MinimalUser            `json:"assignee,omitempty"`
+	Assignees        []*MinimalUser          `json:"assignees,omitempty"`
+	Milestone        *MinimalMilestone       `json:"milestone,omitempty"`
+	Comments         int                     `json:"comments"`
+	CreatedAt        string                  `json:"created_at,omitempty"`
+	UpdatedAt        string                  `json:"updated_at,omitempty"`
+	ClosedAt         string                  `json:"closed_at,omitempty"`
+	Repository       *MinimalRepositoryRef   `json:"repository,omitempty"`
+	PullRequestLinks *MinimalPullRequestLink `json:"pull_request,omitempty"`
+}
+
+// MinimalPullRequestBranch is the head or base of a pull request.
+type MinimalPullRequestBranch struct {
+	Label string `json:"label,omitempty"`
+	Ref   string `json:"ref,omitempty"`
+	SHA   string `json:"sha,omitempty"`
+}
+
+// MinimalPullRequest is a pull request. The merge state and change counts are only known when a
+// single pull request is fetched, so they are left out of lists.
+type MinimalPullRequest struct {
+	ID        int64  `json:"id"`
+	Number    int    `json:"number"`
+	State     string `json:"state,omitempty"`
+	Locked    bool   `json:"locked,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Body      string `json:"body,omitempty"`
+	HTMLURL   string `json:"html_url,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+	ClosedAt  string `json:"closed_at,omitempty"`
+	MergedAt  string `json:"merged_at,omitempty"`
+
+	User      *MinimalUser   `json:"user,omitempty"`
+	Assignees []*MinimalUser `json:"assignees,omitempty"`
+	Labels    []MinimalLabel `json:"labels,omitempty"`
+
+	Draft               bool   `json:"draft"`
+	Merged              *bool  `json:"merged,omitempty"`
+	Mergeable           *bool  `json:"mergeable,omitempty"`
+	MergeableState      string `json:"mergeable_state,omitempty"`
+	MaintainerCanModify *bool  `json:"maintainer_can_modify,omitempty"`
+
+	Head MinimalPullRequestBranch `json:"head"`
+	Base MinimalPullRequestBranch `json:"base"`
+
+	Comments     *int `json:"comments,omitempty"`
+	Commits      *int `json:"commits,omitempty"`
+	Additions    *int `json:"additions,omitempty"`
+	Deletions    *int `json:"deletions,omitempty"`
+	ChangedFiles *int `json:"changed_files,omitempty"`
+}
+
+// MinimalCommitAuthor is the git author or committer of a commit, which need not be a GitHub user.
+type MinimalCommitAuthor struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+	Date  string `json:"date,omitempty"`
+}
+
+type MinimalCommitDetails struct {
+	Author    *MinimalCommitAuthor `json:"author,omitempty"`
+	Committer *MinimalCommitAuthor `json:"committer,omitempty"`
+	Message   string               `json:"message,omitempty"`
+}
+
+type MinimalCommitFile struct {
+	SHA       string `json:"sha,omitempty"`
+	Filename  string `json:"filename,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Changes   int    `json:"changes"`
+}
+
+type MinimalCommitStats struct {
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+	Total     int `json:"total"`
+}
+
+// MinimalCommit is a commit. Files and stats are only known when a single commit is fetched.
+type MinimalCommit struct {
+	SHA       string                `json:"sha,omitempty"`
+	NodeID    string                `json:"node_id,omitempty"`
+	HTMLURL   string                `json:"html_url,omitempty"`
+	Author    *MinimalUser          `json:"author,omitempty"`
+	Committer *MinimalUser          `json:"committer,omitempty"`
+	Commit    *MinimalCommitDetails `json:"commit,omitempty"`
+	Files     []MinimalCommitFile   `json:"files,omitempty"`
+	Stats     *MinimalCommitStats   `json:"stats,omitempty"`
+}
+
+type MinimalCodeResult struct {
+	Name        string               `json:"name,omitempty"`
+	Path        string               `json:"path,omitempty"`
+	HTMLURL     string               `json:"html_url,omitempty"`
+	Repository  MinimalRepositoryRef `json:"repository"`
+	TextMatches []*github.TextMatch  `json:"text_matches,omitempty"`
+}
+
+// MinimalList is the structured result of tools that list objects. Structured results must be
+// JSON objects, so the list is wrapped rather than returned as an array. HasMore and NextCursor
+// are filled in by PaginationMiddleware.
+type MinimalList[T any] struct {
+	Items      []T    `json:"items"`
+	HasMore    *bool  `json:"hasMore,omitempty"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+type MinimalSearchResult[T any] struct {
+	TotalCount        int    `json:"total_count"`
+	IncompleteResults bool   `json:"incomplete_results"`
+	Items             []T    `json:"items"`
+	HasMore           *bool  `json:"hasMore,omitempty"`
+	NextCursor        string `json:"nextCursor,omitempty"`
+}
+
+func newMinimalUser(user *github.User) *MinimalUser {
+	if user == nil {
+		return nil
+	}
+	return &MinimalUser{
+		Login:     user.GetLogin(),
+		AvatarURL: user.GetAvatarURL(),
+		HTMLURL:   user.GetHTMLURL(),
+	}
+}
+
+func newMinimalUsers(users []*github.User) []*MinimalUser {
+	minimal := make([]*MinimalUser, 0, len(users))
+	for _, user := range users {
+		if user != nil {
+			minimal = append(minimal, newMinimalUser(user))
+		}
+	}
+	return minimal
+}
+
+func newMinimalUserDetails(user *github.User) MinimalUserDetails {
+	return MinimalUserDetails{
+		Login:       user.GetLogin(),
+		ID:          user.GetID(),
+		NodeID:      user.GetNodeID(),
+		AvatarURL:   user.GetAvatarURL(),
+		HTMLURL:     user.GetHTMLURL(),
+		Type:        user.GetType(),
+		Name:        user.GetName(),
+		Company:     user.GetCompany(),
+		Blog:        user.GetBlog(),
+		Location:    user.GetLocation(),
+		Email:       user.GetEmail(),
+		Bio:         user.GetBio(),
+		Twitter:     user.GetTwitterUsername(),
+		PublicRepos: user.GetPublicRepos(),
+		Followers:   user.GetFollowers(),
+		Following:   user.GetFollowing(),
+	}
+}
+
+func newMinimalLabels(labels []*github.Label) []MinimalLabel {
+	minimal := make([]MinimalLabel, 0, len(labels))
+	for _, label := range labels {
+		minimal = append(minimal, MinimalLabel{
+			Name:        label.GetName(),
+			Color:       label.GetColor(),
+			Description: label.GetDescription(),
+		})
+	}
+	return minimal
+}
+
+func newMinimalRepository(repo *github.Repository) MinimalRepository {
+	return MinimalRepository{
+		Name:            repo.GetName(),
+		FullName:        repo.GetFullName(),
+		Description:     repo.GetDescription(),
+		HTMLURL:         repo.GetHTMLURL(),
+		Language:        repo.GetLanguage(),
+		Private:         repo.GetPrivate(),
+		Archived:        repo.GetArchived(),
+		CreatedAt:       formatMinimalTime(repo.CreatedAt),
+		UpdatedAt:       formatMinimalTime(repo.UpdatedAt),
+		OpenIssuesCount: repo.GetOpenIssuesCount(),
+		Topics:          repo.Topics,
+		Owner:           newMinimalUser(repo.Owner),
+	}
+}
+
+func newMinimalIssue(issue *github.Issue) MinimalIssue {
+	minimal := MinimalIssue{
+		ID:        issue.GetID(),
+		NodeID:    issue.GetNodeID(),
+		Number:    issue.GetNumber(),
+		Title:     issue.GetTitle(),
+		State:     issue.GetState(),
+		HTMLURL:   issue.GetHTMLURL(),
+		Body:      issue.GetBody(),
+		User:      newMinimalUser(issue.User),
+		Labels:    newMinimalLabels(issue.Labels),
+		Assignee:  newMinimalUser(issue.Assignee),
+		Assignees: newMinimalUsers(issue.Assignees),
+		Comments:  issue.GetComments(),
+		CreatedAt: formatMinimalTime(issue.CreatedAt),
+		UpdatedAt: formatMinimalTime(issue.UpdatedAt),
+		ClosedAt:  formatMinimalTime(issue.ClosedAt),
+	}
+	if issue.Repository != nil {
+		minimal.Repository = &MinimalRepositoryRef{
+			Name:     issue.Repository.GetName(),
+			FullName: issue.Repository.GetFullName(),
+			HTMLURL:  issue.Repository.GetHTMLURL(),
+		}
+	}
+	if issue.Milestone != nil {
+		minimal.Milestone = &MinimalMilestone{
+			Number: issue.Milestone.GetNumber(),
+			Title:  issue.Milestone.GetTitle(),
+			State:  issue.Milestone.GetState(),
+			DueOn:  formatMinimalTime(issue.Milestone.DueOn),
+		}
+	}
+	if issue.PullRequestLinks != nil {
+		minimal.PullRequestLinks = &MinimalPullRequestLink{URL: issue.PullRequestLinks.GetURL()}
+	}
+	return minimal
+}
+
+func newMinimalPullRequest(pr *github.PullRequest) MinimalPullRequest {
+	minimal := MinimalPullRequest{
+		ID:        pr.GetID(),
+		Number:    pr.GetNumber(),
+		State:     pr.GetState(),
+		Locked:    pr.GetLocked(),
+		Title:     pr.GetTitle(),
+		Body:      pr.GetBody(),
+		HTMLURL:   pr.GetHTMLURL(),
+		CreatedAt: formatMinimalTime(pr.CreatedAt),
+		UpdatedAt: formatMinimalTime(pr.UpdatedAt),
+		ClosedAt:  formatMinimalTime(pr.ClosedAt),
+		MergedAt:  formatMinimalTime(pr.MergedAt),
+
+		User:      newMinimalUser(pr.User),
+		Assignees: newMinimalUsers(pr.Assignees),
+		Labels:    newMinimalLabels(pr.Labels),
+
+		Draft:               pr.GetDraft(),
+		Merged:              pr.Merged,
+		Mergeable:           pr.Mergeable,
+		MergeableState:      pr.GetMergeableState(),
+		MaintainerCanModify: pr.MaintainerCanModify,
+
+		Comments:     pr.Comments,
+		Commits:      pr.Commits,
+		Additions:    pr.Additions,
+		Deletions:    pr.Deletions,
+		ChangedFiles: pr.ChangedFiles,
+	}
+	if pr.Head != nil {
+		minimal.Head = MinimalPullRequestBranch{Label: pr.Head.GetLabel(), Ref: pr.Head.GetRef(), SHA: pr.Head.GetSHA()}
+	}
+	if pr.Base != nil {
+		minimal.Base = MinimalPullRequestBranch{Label: pr.Base.GetLabel(), Ref: pr.Base.GetRef(), SHA: pr.Base.GetSHA()}
+	}
+	return minimal
+}
+
+func newMinimalCommitAuthor(author *github.CommitAuthor) *MinimalCommitAuthor {
+	if author == nil {
+		return nil
+	}
+	return &MinimalCommitAuthor{
+		Name:  author.GetName(),
+		Email: author.GetEmail(),
+		Date:  formatMinimalTime(author.Date),
+	}
+}
+
+func newMinimalCommit(commit *github.RepositoryCommit) MinimalCommit {
+	minimal := MinimalCommit{
+		SHA:       commit.GetSHA(),
+		NodeID:    commit.GetNodeID(),
+		HTMLURL:   commit.GetHTMLURL(),
+		Author:    newMinimalUser(commit.Author),
+		Committer: newMinimalUser(commit.Committer),
+	}
+	if commit.Commit != nil {
+		minimal.Commit = &MinimalCommitDetails{
+			Author:    newMinimalCommitAuthor(commit.Commit.Author),
+			Committer: newMinimalCommitAuthor(commit.Commit.Committer),
+			Message:   commit.Commit.GetMessage(),
+		}
+	}
+	for _, file := range commit.Files {
+		minimal.Files = append(minimal.Files, MinimalCommitFile{
+			SHA:       file.GetSHA(),
+			Filename:  file.GetFilename(),
+			Status:    file.GetStatus(),
+			Additions: file.GetAdditions(),
+			Deletions: file.GetDeletions(),
+			Changes:   file.GetChanges(),
+		})
+	}
+	if commit.Stats != nil {
+		minimal.Stats = &MinimalCommitStats{
+			Additions: commit.Stats.GetAdditions(),
+			Deletions: commit.Stats.GetDeletions(),
+			Total:     commit.Stats.GetTotal(),
+		}
+	}
+	return minimal
+}
+
+func newMinimalCodeResult(result *github.CodeResult) MinimalCodeResult {
+	minimal := MinimalCodeResult{
+		Name:        result.GetName(),
+		Path:        result.GetPath(),
+		HTMLURL:     result.GetHTMLURL(),
+		TextMatches: result.TextMatches,
+	}
+	if result.Repository != nil {
+		minimal.Repository = MinimalRepositoryRef{
+			Name:     result.Repository.GetName(),
+			FullName: result.Repository.GetFullName(),
+			HTMLURL:  result.Repository.GetHTMLURL(),
+		}
+	}
+	return minimal
+}
+
+// formatMinimalTime formats a timestamp as RFC 3339, or as an empty string if it is not set.
+func formatMinimalTime(ts *github.Timestamp) string {
+	if ts == nil || ts.IsZero() {
+		return ""
+	}
+	return ts.Format(time.RFC3339)
+}
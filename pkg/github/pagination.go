@@ -0,0 +1,431 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MaxAutoPaginationItems is the most results a call can ask to have gathered from several pages.
+const MaxAutoPaginationItems = 1000
+
+// defaultPageSize is the number of results per page of the tools that take a page, unless they
+// have their own in defaultPageSizes.
+const defaultPageSize = 30
+
+// defaultPageSizes are the page sizes of the tools that return more results per page than
+// defaultPageSize when the caller gives none.
+var defaultPageSizes = map[string]int{
+	"get_pull_request_comments": 100,
+}
+
+// defaultPageSizeOf returns the page size of the given tool when the caller gives none.
+func defaultPageSizeOf(tool string) int {
+	if size, ok := defaultPageSizes[tool]; ok {
+		return size
+	}
+	return defaultPageSize
+}
+
+// graphQLCursorTools are the tools that take the end cursor of a GraphQL page as "after", which
+// is passed through to them when it isn't a cursor made by PaginationMiddleware.
+var graphQLCursorTools = map[string]bool{
+	"get_issue_timeline": true,
+	"list_reactions":     true,
+}
+
+// pageSizeParam returns the argument the page size of a request is given in: "perPage", or
+// "per_page" for the tools that named it that way before the shared pagination parameters existed.
+func pageSizeParam(request mcp.CallToolRequest) string {
+	args := request.GetArguments()
+	if _, ok := args["perPage"]; !ok {
+		if _, ok := args["per_page"]; ok {
+			return "per_page"
+		}
+	}
+	return "perPage"
+}
+
+// WithCursorPagination adds the "after" and "maxItems" parameters to a tool. Tools that take a page
+// get them from WithPagination; tools paged by GraphQL cursors add them directly.
+func WithCursorPagination() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("after",
+			mcp.Description("Cursor to continue from, as returned in nextCursor by the previous call. Takes the place of page and perPage"),
+		)(tool)
+
+		mcp.WithNumber("maxItems",
+			mcp.Description(fmt.Sprintf("Optional: follow the pages of results until this many are gathered or there are no more (max %d)", MaxAutoPaginationItems)),
+			mcp.Min(1),
+			mcp.Max(MaxAutoPaginationItems),
+		)(tool)
+	}
+}
+
+// pageCursor is where a page of results starts: a page number for REST results, with how many of
+// the page's results were already seen, or the end cursor of the previous page for GraphQL
+// results. Callers only see it encoded, as an opaque string, so that both kinds are continued the
+// same way.
+type pageCursor struct {
+	Page    int    `json:"page,omitempty"`
+	PerPage int    `json:"perPage,omitempty"`
+	Skip    int    `json:"skip,omitempty"`
+	After   string `json:"after,omitempty"`
+}
+
+func (c pageCursor) String() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// parsePageCursor decodes a cursor made by pageCursor.String. Anything else, such as a GraphQL end
+// cursor taken straight from a page_info, is not a pageCursor.
+func parsePageCursor(s string) (pageCursor, bool) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return pageCursor{}, false
+	}
+	var c pageCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return pageCursor{}, false
+	}
+	return c, c.Page > 0 || c.After != ""
+}
+
+// cursorAt returns the cursor of the REST results starting at offset, in pages of perPage. When
+// offset is in the middle of a page, the results of the page before it are skipped.
+func cursorAt(offset, perPage int) pageCursor {
+	return pageCursor{Page: offset/perPage + 1, PerPage: perPage, Skip: offset % perPage}
+}
+
+// withPageCursor replaces the "after" argument of a request with the arguments the tool handler
+// understands: "page" and "perPage" for REST cursors, or the raw end cursor for GraphQL cursors.
+// It also returns how many results at the start of the page the caller has already seen. Any
+// other "after" is only passed through to the tools that take GraphQL end cursors; tools paged by
+// number would ignore it and start over from the first page.
+func withPageCursor(request mcp.CallToolRequest) (mcp.CallToolRequest, int, error) {
+	after, err := OptionalParam[string](request, "after")
+	if err != nil || after == "" {
+		return request, 0, err
+	}
+	cursor, ok := parsePageCursor(after)
+	if !ok {
+		if graphQLCursorTools[request.Params.Name] {
+			return request, 0, nil
+		}
+		return request, 0, errors.New("invalid cursor: after must be a nextCursor returned by this tool")
+	}
+	return withCursorArguments(request, cursor), cursor.Skip, nil
+}
+
+func withCursorArguments(request mcp.CallToolRequest, cursor pageCursor) mcp.CallToolRequest {
+	args := maps.Clone(request.GetArguments())
+	if args == nil {
+		args = map[string]any{}
+	}
+	if cursor.After != "" {
+		args["after"] = cursor.After
+	} else {
+		delete(args, "after")
+		delete(args, "per_page")
+		args["page"] = float64(cursor.Page)
+		args["perPage"] = float64(cursor.PerPage)
+	}
+	request.Params.Arguments = args
+	return request
+}
+
+type pageTrackerKey struct{}
+
+// pageTracker records whether the page of results a tool call returned is followed by another.
+type pageTracker struct {
+	mu       sync.Mutex
+	reported bool
+	paged    bool // whether the results are paged by number rather than GraphQL cursor
+	next     *pageCursor
+}
+
+func withPageTracker(ctx context.Context) (context.Context, *pageTracker) {
+	tracker := &pageTracker{}
+	return context.WithValue(ctx, pageTrackerKey{}, tracker), tracker
+}
+
+func (p *pageTracker) report(paged bool, next *pageCursor) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.reported = true
+	p.paged = paged
+	p.next = next
+}
+
+// reportNextPage records the page following the one a REST list call returned, as given by the
+// Link header of the response. Handlers of paginated tools call it so that PaginationMiddleware can
+// tell callers whether there are more results.
+func reportNextPage(ctx context.Context, resp *github.Response) {
+	tracker, ok := ctx.Value(pageTrackerKey{}).(*pageTracker)
+	if !ok {
+		return
+	}
+	var next *pageCursor
+	if resp.NextPage != 0 {
+		next = &pageCursor{Page: resp.NextPage}
+	}
+	tracker.report(true, next)
+}
+
+// reportPageInfo is reportNextPage for results paged by a GraphQL connection.
+func reportPageInfo(ctx context.Context, hasNextPage bool, endCursor string) {
+	tracker, ok := ctx.Value(pageTrackerKey{}).(*pageTracker)
+	if !ok {
+		return
+	}
+	var next *pageCursor
+	if hasNextPage {
+		next = &pageCursor{After: endCursor}
+	}
+	tracker.report(false, next)
+}
+
+// PaginationMiddleware lets callers page through the results of any paginated tool with an opaque
+// cursor, and tells them whether there are more results and how to get them. When a call gives
+// "maxItems", the middleware follows the pages itself and returns the results of all of them
+// together.
+func PaginationMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		request, skip, err := withPageCursor(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		maxItems, err := OptionalIntParam(request, "maxItems")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if maxItems < 0 || maxItems > MaxAutoPaginationItems {
+			return mcp.NewToolResultError(fmt.Sprintf("maxItems must be between 1 and %d", MaxAutoPaginationItems)), nil
+		}
+		if _, ok := request.GetArguments()[pageSizeParam(request)]; maxItems > 0 && !ok {
+			// Fetch no more than asked for if it fits in a single page
+			request = withArgument(request, "perPage", float64(min(maxItems, 100)))
+		}
+		pagination, err := OptionalPaginationParams(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		pageCtx, tracker := withPageTracker(ctx)
+		result, err := next(pageCtx, request)
+		if err != nil || result == nil || result.IsError || !tracker.reported {
+			return result, err
+		}
+
+		pages := newPageMerger(result, listFieldOf(request.Params.Name))
+		pages.skip(skip)
+		progress := newProgressReporter(ctx, request, 0)
+		for maxItems > 0 && tracker.next != nil && pages.mergeable() && pages.count() < maxItems {
+			progress.Step(fmt.Sprintf("Gathered %d results", pages.count()))
+			cursor := *tracker.next
+			cursor.PerPage = pagination.perPage
+			request = withCursorArguments(request, cursor)
+
+			pageCtx, tracker = withPageTracker(ctx)
+			page, err := next(pageCtx, request)
+			if err != nil || page == nil || page.IsError {
+				return page, err
+			}
+			pages.add(page)
+		}
+
+		var nextCursor *pageCursor
+		if tracker.next != nil {
+			nextCursor = tracker.next
+			if nextCursor.After == "" {
+				nextCursor.PerPage = pagination.perPage
+			}
+		}
+		// Only pages numbered by REST can be cut short and continued from where they were cut
+		if maxItems > 0 && tracker.paged && pages.count() > maxItems {
+			pages.cut(maxItems)
+			cursor := cursorAt((pagination.page-1)*pagination.perPage+skip+maxItems, pagination.perPage)
+			nextCursor = &cursor
+		}
+
+		result = pages.result()
+		if nextCursor != nil {
+			setNextCursor(result, nextCursor.String())
+		} else {
+			setNextCursor(result, "")
+		}
+		return result, nil
+	}
+}
+
+func withArgument(request mcp.CallToolRequest, name string, value any) mcp.CallToolRequest {
+	args := maps.Clone(request.GetArguments())
+	if args == nil {
+		args = map[string]any{}
+	}
+	args[name] = value
+	request.Params.Arguments = args
+	return request
+}
+
+// pageMerger gathers the items of several pages of a list result. Fields other than the items,
+// such as a page_info, are taken from the last page.
+type pageMerger struct {
+	first      *mcp.CallToolResult
+	listField  string
+	text       any // the decoded JSON of the first text content
+	items      []any
+	structured any
+	structItem []any
+}
+
+func newPageMerger(first *mcp.CallToolResult, listField string) *pageMerger {
+	m := &pageMerger{first: first, listField: listField}
+	text, structured := pageValues(first)
+	if items, ok := listItems(text, m.listField); ok {
+		m.text, m.items = text, items
+	}
+	if items, ok := listItems(structured, m.listField); ok {
+		m.structured, m.structItem = structured, items
+	}
+	return m
+}
+
+// pageValues decodes the JSON text and the structured content of a page of results.
+func pageValues(page *mcp.CallToolResult) (text, structured any) {
+	for _, c := range page.Content {
+		if t, ok := c.(mcp.TextContent); ok {
+			text, _ = decodeJSON([]byte(t.Text))
+			break
+		}
+	}
+	if page.StructuredContent != nil {
+		if data, err := json.Marshal(page.StructuredContent); err == nil {
+			structured, _ = decodeJSON(data)
+		}
+	}
+	return text, structured
+}
+
+func (m *pageMerger) mergeable() bool {
+	return m.text != nil
+}
+
+func (m *pageMerger) count() int {
+	return len(m.items)
+}
+
+func (m *pageMerger) add(page *mcp.CallToolResult) {
+	text, structured := pageValues(page)
+	if items, ok := listItems(text, m.listField); ok {
+		m.text = text
+		m.items = append(m.items, items...)
+	}
+	if items, ok := listItems(structured, m.listField); ok && m.structured != nil {
+		m.structured = structured
+		m.structItem = append(m.structItem, items...)
+	}
+}
+
+// skip drops the first n items, which the caller has already seen.
+func (m *pageMerger) skip(n int) {
+	if n == 0 {
+		return
+	}
+	m.items = m.items[min(n, len(m.items)):]
+	if m.structured != nil {
+		m.structItem = m.structItem[min(n, len(m.structItem)):]
+	}
+}
+
+func (m *pageMerger) cut(n int) {
+	m.items = m.items[:min(n, len(m.items))]
+	if m.structured != nil {
+		m.structItem = m.structItem[:min(n, len(m.structItem))]
+	}
+}
+
+// result is the first page with the items of every page.
+func (m *pageMerger) result() *mcp.CallToolResult {
+	result := *m.first
+	if m.text == nil {
+		return &result
+	}
+	result.Content = make([]mcp.Content, len(m.first.Content))
+	copy(result.Content, m.first.Content)
+	for i, c := range result.Content {
+		if t, ok := c.(mcp.TextContent); ok {
+			if data, err := json.Marshal(withListItems(m.text, m.listField, m.items)); err == nil {
+				t.Text = string(data)
+				result.Content[i] = t
+			}
+			break
+		}
+	}
+	if m.structured != nil {
+		result.StructuredContent = withListItems(m.structured, m.listField, m.structItem)
+	}
+	return &result
+}
+
+// setNextCursor records on a result whether there are more results and, if there are, the cursor
+// to get them with: in a note for the caller, in the structured content and in the result's _meta.
+// It replaces what was recorded before, since shaping a result can move where the rest start.
+func setNextCursor(result *mcp.CallToolResult, next string) {
+	if previous, ok := result.Meta["hasMore"]; ok {
+		previousNote := pageNote("")
+		if previous == true {
+			previousNote = pageNote(fmt.Sprint(result.Meta["nextCursor"]))
+		}
+		for i, c := range result.Content {
+			if t, ok := c.(mcp.TextContent); ok && t.Text == previousNote {
+				result.Content = append(result.Content[:i:i], result.Content[i+1:]...)
+				break
+			}
+		}
+	}
+
+	meta := maps.Clone(result.Meta)
+	if meta == nil {
+		meta = map[string]any{}
+	}
+	meta["hasMore"] = next != ""
+	delete(meta, "nextCursor")
+	if next != "" {
+		meta["nextCursor"] = next
+	}
+	result.Meta = meta
+
+	if result.StructuredContent != nil {
+		if data, err := json.Marshal(result.StructuredContent); err == nil {
+			if object, err := decodeJSON(data); err == nil {
+				if object, ok := object.(map[string]any); ok {
+					object["hasMore"] = next != ""
+					delete(object, "nextCursor")
+					if next != "" {
+						object["nextCursor"] = next
+					}
+					result.StructuredContent = object
+				}
+			}
+		}
+	}
+
+	result.Content = append(result.Content, mcp.NewTextContent(pageNote(next)))
+}
+
+func pageNote(next string) string {
+	if next == "" {
+		return "There are no more results."
+	}
+	return fmt.Sprintf("More results are available. To get them, call this tool again with after: %q.", next)
+}
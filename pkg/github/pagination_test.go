@@ -0,0 +1,293 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PageCursor(t *testing.T) {
+	cursor := pageCursor{Page: 3, PerPage: 20}
+	parsed, ok := parsePageCursor(cursor.String())
+	require.True(t, ok)
+	assert.Equal(t, cursor, parsed)
+
+	// GraphQL end cursors are passed through to the tools that take them
+	_, ok = parsePageCursor("Y3Vyc29yOnYyOpHOAAAA")
+	assert.False(t, ok)
+
+	assert.Equal(t, pageCursor{Page: 3, PerPage: 10}, cursorAt(20, 10))
+	assert.Equal(t, pageCursor{Page: 1, PerPage: 10}, cursorAt(0, 10))
+
+	// Offsets in the middle of a page keep the page size and skip what was already seen
+	assert.Equal(t, pageCursor{Page: 3, PerPage: 10, Skip: 5}, cursorAt(25, 10))
+	assert.Equal(t, pageCursor{Page: 2, PerPage: 30, Skip: 13}, cursorAt(43, 30))
+	assert.Equal(t, pageCursor{Page: 2, PerPage: 30, Skip: 7}, cursorAt(37, 30))
+}
+
+func Test_PaginationMiddleware(t *testing.T) {
+	// A REST tool listing the numbers 1 to 25
+	var calls int
+	restHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		pagination, err := OptionalPaginationParams(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		items := []int{}
+		for i := (pagination.page-1)*pagination.perPage + 1; i <= min(pagination.page*pagination.perPage, 25); i++ {
+			items = append(items, i)
+		}
+		resp := &github.Response{}
+		if pagination.page*pagination.perPage < 25 {
+			resp.NextPage = pagination.page + 1
+		}
+		reportNextPage(ctx, resp)
+		return MarshalledTextResult(items), nil
+	}
+
+	tests := []struct {
+		name          string
+		args          map[string]any
+		expectedItems []int
+		expectedNext  *pageCursor
+		expectedCalls int
+	}{
+		{
+			name:          "reports the cursor of the next page",
+			args:          map[string]any{"perPage": float64(10)},
+			expectedItems: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			expectedNext:  &pageCursor{Page: 2, PerPage: 10},
+			expectedCalls: 1,
+		},
+		{
+			name:          "takes the page size as per_page",
+			args:          map[string]any{"per_page": float64(10)},
+			expectedItems: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			expectedNext:  &pageCursor{Page: 2, PerPage: 10},
+			expectedCalls: 1,
+		},
+		{
+			name:          "continues from a cursor",
+			args:          map[string]any{"after": pageCursor{Page: 3, PerPage: 10}.String()},
+			expectedItems: []int{21, 22, 23, 24, 25},
+			expectedCalls: 1,
+		},
+		{
+			name:          "gathers pages up to maxItems",
+			args:          map[string]any{"perPage": float64(10), "maxItems": float64(22)},
+			expectedItems: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22},
+			expectedNext:  &pageCursor{Page: 3, PerPage: 10, Skip: 2},
+			expectedCalls: 3,
+		},
+		{
+			name:          "continues from the middle of a page",
+			args:          map[string]any{"after": pageCursor{Page: 2, PerPage: 10, Skip: 3}.String()},
+			expectedItems: []int{14, 15, 16, 17, 18, 19, 20},
+			expectedNext:  &pageCursor{Page: 3, PerPage: 10},
+			expectedCalls: 1,
+		},
+		{
+			name:          "gathers pages from the middle of a page",
+			args:          map[string]any{"after": pageCursor{Page: 1, PerPage: 10, Skip: 3}.String(), "maxItems": float64(9)},
+			expectedItems: []int{4, 5, 6, 7, 8, 9, 10, 11, 12},
+			expectedNext:  &pageCursor{Page: 2, PerPage: 10, Skip: 2},
+			expectedCalls: 2,
+		},
+		{
+			name:          "stops gathering when there are no more pages",
+			args:          map[string]any{"maxItems": float64(100)},
+			expectedItems: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25},
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls = 0
+			result, err := PaginationMiddleware(restHandler)(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			require.Len(t, result.Content, 2)
+
+			var items []int
+			require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &items))
+			assert.Equal(t, tc.expectedItems, items)
+			assert.Equal(t, tc.expectedCalls, calls)
+
+			note := result.Content[1].(mcp.TextContent).Text
+			if tc.expectedNext == nil {
+				assert.Equal(t, false, result.Meta["hasMore"])
+				assert.Equal(t, "There are no more results.", note)
+				return
+			}
+			assert.Equal(t, true, result.Meta["hasMore"])
+			assert.Equal(t, tc.expectedNext.String(), result.Meta["nextCursor"])
+			assert.Equal(t, pageNote(tc.expectedNext.String()), note)
+		})
+	}
+}
+
+func Test_PaginationMiddleware_InvalidCursor(t *testing.T) {
+	var afters []string
+	handler := func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		after, _ := OptionalParam[string](request, "after")
+		afters = append(afters, after)
+		return MarshalledTextResult([]int{}), nil
+	}
+	requestTo := func(tool string) mcp.CallToolRequest {
+		request := createMCPRequest(map[string]any{"after": "Y3Vyc29yOnYyOpHOAAAA"})
+		request.Params.Name = tool
+		return request
+	}
+
+	// A tool paged by number would start over from the first page, so it isn't called
+	result, err := PaginationMiddleware(handler)(context.Background(), requestTo("get_issue_comments"))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "invalid cursor")
+	assert.Empty(t, afters)
+
+	// A tool that takes GraphQL end cursors gets it as is
+	result, err = PaginationMiddleware(handler)(context.Background(), requestTo("get_issue_timeline"))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, []string{"Y3Vyc29yOnYyOpHOAAAA"}, afters)
+}
+
+func Test_PaginationMiddleware_GraphQL(t *testing.T) {
+	// A GraphQL tool with two pages of events, the second after "end1"
+	var afters []string
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		after, _ := OptionalParam[string](request, "after")
+		afters = append(afters, after)
+		if after == "" {
+			reportPageInfo(ctx, true, "end1")
+			return MarshalledTextResult(map[string]any{"events": []string{"a", "b"}, "total_count": 3}), nil
+		}
+		reportPageInfo(ctx, false, "end2")
+		return MarshalledTextResult(map[string]any{"events": []string{"c"}, "total_count": 3}), nil
+	}
+	timelineRequest := func(args map[string]any) mcp.CallToolRequest {
+		request := createMCPRequest(args)
+		request.Params.Name = "get_issue_timeline"
+		return request
+	}
+
+	result, err := PaginationMiddleware(handler)(context.Background(), timelineRequest(map[string]any{}))
+	require.NoError(t, err)
+	next, ok := result.Meta["nextCursor"].(string)
+	require.True(t, ok)
+
+	// The cursor is given to the tool as the GraphQL end cursor
+	result, err = PaginationMiddleware(handler)(context.Background(), timelineRequest(map[string]any{"after": next}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "end1"}, afters)
+	assert.Equal(t, false, result.Meta["hasMore"])
+
+	// maxItems gathers the events of both pages
+	afters = nil
+	result, err = PaginationMiddleware(handler)(context.Background(), timelineRequest(map[string]any{"maxItems": float64(10)}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "end1"}, afters)
+	assert.JSONEq(t, `{"events":["a","b","c"],"total_count":3}`, result.Content[0].(mcp.TextContent).Text)
+}
+
+func Test_PaginationMiddleware_Unpaginated(t *testing.T) {
+	handler := func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("done"), nil
+	}
+
+	result, err := PaginationMiddleware(handler)(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	assert.Equal(t, "done", getTextResult(t, result).Text)
+	assert.Nil(t, result.Meta)
+}
+
+func Test_ListToolsReportNextPage(t *testing.T) {
+	pullArgs := map[string]any{"owner": "owner", "repo": "repo", "pullNumber": float64(42)}
+	repoArgs := map[string]any{"owner": "owner", "repo": "repo"}
+	tests := []struct {
+		name     string
+		tool     func(GetClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+		endpoint mock.EndpointPattern
+		args     map[string]any
+	}{
+		{
+			name:     "get_issue_comments",
+			tool:     GetIssueComments,
+			endpoint: mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+			args:     map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(42)},
+		},
+		{
+			name:     "get_pull_request_files",
+			tool:     GetPullRequestFiles,
+			endpoint: mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+			args:     pullArgs,
+		},
+		{
+			name:     "get_pull_request_comments",
+			tool:     GetPullRequestComments,
+			endpoint: mock.GetReposPullsCommentsByOwnerByRepoByPullNumber,
+			args:     pullArgs,
+		},
+		{
+			name:     "get_pull_request_reviews",
+			tool:     GetPullRequestReviews,
+			endpoint: mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
+			args:     pullArgs,
+		},
+		{
+			name:     "list_code_scanning_alerts",
+			tool:     ListCodeScanningAlerts,
+			endpoint: mock.GetReposCodeScanningAlertsByOwnerByRepo,
+			args:     repoArgs,
+		},
+		{
+			name:     "list_secret_scanning_alerts",
+			tool:     ListSecretScanningAlerts,
+			endpoint: mock.GetReposSecretScanningAlertsByOwnerByRepo,
+			args:     repoArgs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					tc.endpoint,
+					expectQueryParams(t, map[string]string{"page": "2", "per_page": "5"}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/next?page=3&per_page=5>; rel="next"`)
+							w.WriteHeader(http.StatusOK)
+							_, _ = w.Write([]byte(`[]`))
+						}),
+					),
+				),
+			)
+			tool, handler := tc.tool(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+			assert.Equal(t, tc.name, tool.Name)
+			assert.Contains(t, tool.InputSchema.Properties, "after")
+
+			args := map[string]any{"after": pageCursor{Page: 2, PerPage: 5}.String()}
+			for k, v := range tc.args {
+				args[k] = v
+			}
+			result, err := PaginationMiddleware(handler)(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			assert.Equal(t, true, result.Meta["hasMore"])
+			assert.Equal(t, pageCursor{Page: 3, PerPage: 5}.String(), result.Meta["nextCursor"])
+		})
+	}
+}
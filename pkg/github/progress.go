@@ -0,0 +1,85 @@
+package github
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MethodProgress is the notification that reports the progress of a request the client gave a progress
+// token with.
+const MethodProgress = "notifications/progress"
+
+type progressTokenKey struct{}
+
+// ContextWithProgressToken returns a context carrying the progress token a client gave with a request.
+// Transports use it for requests whose _meta mcp-go drops while parsing them, such as resources/read.
+func ContextWithProgressToken(ctx context.Context, token mcp.ProgressToken) context.Context {
+	return context.WithValue(ctx, progressTokenKey{}, token)
+}
+
+// progressReporter sends notifications/progress for a tool call, so that clients can show that a long
+// running call is still making headway. Progress is only sent if the client asked for it by giving a
+// progress token with the call; otherwise reporting does nothing, so handlers can report unconditionally.
+type progressReporter struct {
+	ctx    context.Context
+	server *server.MCPServer
+	token  mcp.ProgressToken
+
+	mu       sync.Mutex
+	progress int
+	total    int
+}
+
+// newProgressReporter creates a reporter for a tool call made up of total steps, or an unknown number of
+// steps if total is 0.
+func newProgressReporter(ctx context.Context, request mcp.CallToolRequest, total int) *progressReporter {
+	p := newRequestProgressReporter(ctx, total)
+	if request.Params.Meta != nil && request.Params.Meta.ProgressToken != nil {
+		p.token = request.Params.Meta.ProgressToken
+	}
+	return p
+}
+
+// newRequestProgressReporter creates a reporter for a request whose progress token, if any, the
+// transport put in ctx, such as reading a resource.
+func newRequestProgressReporter(ctx context.Context, total int) *progressReporter {
+	token, _ := ctx.Value(progressTokenKey{}).(mcp.ProgressToken)
+	return &progressReporter{
+		ctx:    ctx,
+		server: server.ServerFromContext(ctx),
+		token:  token,
+		total:  total,
+	}
+}
+
+// SetTotal sets the number of steps, once it is known.
+func (p *progressReporter) SetTotal(total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total = total
+}
+
+// Step reports that one more step has been completed, described by message.
+func (p *progressReporter) Step(message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.progress++
+	if p.token == nil || p.server == nil {
+		return
+	}
+
+	params := map[string]any{
+		"progressToken": p.token,
+		"progress":      p.progress,
+		"message":       message,
+	}
+	if p.total > 0 {
+		params["total"] = p.total
+	}
+	// Progress is informational, so a client that can't be reached doesn't fail the call
+	_ = p.server.SendNotificationToClient(p.ctx, MethodProgress, params)
+}
@@ -0,0 +1,99 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// progressTestSession is a client session that keeps the notifications sent to it.
+type progressTestSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *progressTestSession) SessionID() string { return "progress-test" }
+func (s *progressTestSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+func (s *progressTestSession) Initialize()       {}
+func (s *progressTestSession) Initialized() bool { return true }
+
+func Test_ProgressReporter(t *testing.T) {
+	s := server.NewMCPServer("test", "1.0.0")
+	s.AddTool(mcp.NewTool("steps"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		progress := newProgressReporter(ctx, request, 2)
+		progress.Step("first")
+		progress.Step("second")
+		return mcp.NewToolResultText("done"), nil
+	})
+
+	callTool := func(meta string) []mcp.JSONRPCNotification {
+		session := &progressTestSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+		ctx := s.WithContext(context.Background(), session)
+
+		message := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"steps"` + meta + `}}`
+		response := s.HandleMessage(ctx, json.RawMessage(message))
+		require.IsType(t, mcp.JSONRPCResponse{}, response)
+
+		close(session.notifications)
+		var notifications []mcp.JSONRPCNotification
+		for notification := range session.notifications {
+			notifications = append(notifications, notification)
+		}
+		return notifications
+	}
+
+	t.Run("reports each step when the client gives a progress token", func(t *testing.T) {
+		notifications := callTool(`,"_meta":{"progressToken":"token-1"}`)
+		require.Len(t, notifications, 2)
+		for i, message := range []string{"first", "second"} {
+			assert.Equal(t, MethodProgress, notifications[i].Method)
+			assert.Equal(t, map[string]any{
+				"progressToken": "token-1",
+				"progress":      i + 1,
+				"total":         2,
+				"message":       message,
+			}, notifications[i].Params.AdditionalFields)
+		}
+	})
+
+	t.Run("reports nothing without a progress token", func(t *testing.T) {
+		assert.Empty(t, callTool(""))
+	})
+}
+
+func Test_ProgressReporterWithoutServer(t *testing.T) {
+	// Handlers are also called directly, without a server in the context, which must not fail
+	progress := newProgressReporter(context.Background(), mcp.CallToolRequest{}, 0)
+	progress.SetTotal(1)
+	progress.Step("step")
+}
+
+func Test_RequestProgressReporter(t *testing.T) {
+	// mcp-go drops the _meta of resources/read, so the transport passes the progress token in the context
+	s := server.NewMCPServer("test", "1.0.0", server.WithResourceCapabilities(false, false))
+	s.AddResourceTemplate(mcp.NewResourceTemplate("test://{id}", "test"), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		progress := newRequestProgressReporter(ctx, 0)
+		progress.Step("first page")
+		return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, Text: "done"}}, nil
+	})
+
+	session := &progressTestSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	ctx := ContextWithProgressToken(s.WithContext(context.Background(), session), "token-1")
+	response := s.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"test://1"}}`))
+	require.IsType(t, mcp.JSONRPCResponse{}, response)
+
+	require.Len(t, session.notifications, 1)
+	notification := <-session.notifications
+	assert.Equal(t, MethodProgress, notification.Method)
+	assert.Equal(t, map[string]any{
+		"progressToken": "token-1",
+		"progress":      1,
+		"message":       "first page",
+	}, notification.Params.AdditionalFields)
+}
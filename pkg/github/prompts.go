@@ -0,0 +1,231 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// InitPrompts returns the prompts the server offers. Each prompt lists the toolsets whose
+// tools it relies on, so it is only advertised when those toolsets are enabled. Prompts that
+// tell the model to use write tools are not advertised when the server is read-only.
+func InitPrompts(t translations.TranslationHelperFunc) []toolsets.ServerPrompt {
+	return []toolsets.ServerPrompt{
+		toolsets.NewServerPrompt(ReviewPullRequestPrompt(t)).Requires("pull_requests").RequiresWriteTools(),
+		toolsets.NewServerPrompt(TriageIssuesPrompt(t)).Requires("issues").RequiresWriteTools(),
+		toolsets.NewServerPrompt(SummarizeNotificationsPrompt(t)).Requires("notifications"),
+		toolsets.NewServerPrompt(FixFailingCIPrompt(t)).Requires("pull_requests", "repos").RequiresWriteTools(),
+	}
+}
+
+// requiredPromptArgument returns the value of a prompt argument, or an error if it is missing or empty.
+func requiredPromptArgument(request mcp.GetPromptRequest, name string) (string, error) {
+	value, ok := request.Params.Arguments[name]
+	if !ok || value == "" {
+		return "", fmt.Errorf("missing required argument: %s", name)
+	}
+	return value, nil
+}
+
+// requiredPromptNumberArgument returns the value of a prompt argument that must be a positive number.
+func requiredPromptNumberArgument(request mcp.GetPromptRequest, name string) (int, error) {
+	value, err := requiredPromptArgument(request, name)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("argument %s must be a positive number, got %q", name, value)
+	}
+	return n, nil
+}
+
+func userPromptResult(description, text string) *mcp.GetPromptResult {
+	return mcp.NewGetPromptResult(description, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+	})
+}
+
+// ReviewPullRequestPrompt creates a prompt that walks the model through reviewing a pull request.
+func ReviewPullRequestPrompt(t translations.TranslationHelperFunc) (prompt mcp.Prompt, handler server.PromptHandlerFunc) {
+	description := t("PROMPT_REVIEW_PULL_REQUEST_DESCRIPTION", "Review a pull request and leave a review with inline comments")
+	return mcp.NewPrompt("review_pull_request",
+			mcp.WithPromptDescription(description),
+			mcp.WithArgument("owner",
+				mcp.ArgumentDescription("Repository owner"),
+				mcp.RequiredArgument(),
+			),
+			mcp.WithArgument("repo",
+				mcp.ArgumentDescription("Repository name"),
+				mcp.RequiredArgument(),
+			),
+			mcp.WithArgument("pullNumber",
+				mcp.ArgumentDescription("Pull request number"),
+				mcp.RequiredArgument(),
+			),
+			mcp.WithArgument("focus",
+				mcp.ArgumentDescription("Optional area to pay particular attention to, e.g. security or performance"),
+			),
+		),
+		func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner, err := requiredPromptArgument(request, "owner")
+			if err != nil {
+				return nil, err
+			}
+			repo, err := requiredPromptArgument(request, "repo")
+			if err != nil {
+				return nil, err
+			}
+			pullNumber, err := requiredPromptNumberArgument(request, "pullNumber")
+			if err != nil {
+				return nil, err
+			}
+
+			text := fmt.Sprintf(t("PROMPT_REVIEW_PULL_REQUEST_TEXT",
+				"Review pull request #%d in %s/%s.\n\n"+
+					"1. Use get_pull_request to understand what the change is for, and get_pull_request_files and get_pull_request_diff to read the change itself.\n"+
+					"2. Use get_pull_request_comments and get_pull_request_reviews to see what has already been discussed, and don't repeat it.\n"+
+					"3. Start a review with create_pending_pull_request_review, add a comment on each specific problem with add_pull_request_review_comment_to_pending_review, "+
+					"then submit it with submit_pending_pull_request_review. Request changes only for problems that must be fixed before merging; otherwise comment or approve.\n"+
+					"Keep comments concrete and suggest a fix where you can."),
+				pullNumber, owner, repo)
+			if focus := request.Params.Arguments["focus"]; focus != "" {
+				text += fmt.Sprintf(t("PROMPT_REVIEW_PULL_REQUEST_FOCUS", "\n\nPay particular attention to: %s."), focus)
+			}
+
+			return userPromptResult(description, text), nil
+		}
+}
+
+// TriageIssuesPrompt creates a prompt that walks the model through triaging new issues in a repository.
+func TriageIssuesPrompt(t translations.TranslationHelperFunc) (prompt mcp.Prompt, handler server.PromptHandlerFunc) {
+	description := t("PROMPT_TRIAGE_ISSUES_DESCRIPTION", "Triage new issues in a repository by labelling them and closing duplicates")
+	return mcp.NewPrompt("triage_issues",
+			mcp.WithPromptDescription(description),
+			mcp.WithArgument("owner",
+				mcp.ArgumentDescription("Repository owner"),
+				mcp.RequiredArgument(),
+			),
+			mcp.WithArgument("repo",
+				mcp.ArgumentDescription("Repository name"),
+				mcp.RequiredArgument(),
+			),
+			mcp.WithArgument("since",
+				mcp.ArgumentDescription("Only triage issues updated after this time (ISO 8601 timestamp)"),
+			),
+			mcp.WithArgument("label",
+				mcp.ArgumentDescription("Only triage issues with this label, e.g. needs-triage"),
+			),
+		),
+		func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner, err := requiredPromptArgument(request, "owner")
+			if err != nil {
+				return nil, err
+			}
+			repo, err := requiredPromptArgument(request, "repo")
+			if err != nil {
+				return nil, err
+			}
+
+			text := fmt.Sprintf(t("PROMPT_TRIAGE_ISSUES_TEXT",
+				"Triage the open issues in %s/%s.\n\n"+
+					"1. Use list_issues to find the open issues to triage.\n"+
+					"2. For each issue, read it with get_issue and get_issue_comments, and use find_similar_issues to check whether it duplicates an existing issue. "+
+					"Close true duplicates with close_issue_as_duplicate.\n"+
+					"3. Apply labels that describe the kind and area of each remaining issue with update_issue, using labels that already exist in the repository.\n"+
+					"4. If an issue is missing what is needed to act on it, ask for it with add_issue_comment.\n"+
+					"Finish with a short summary of what you did to each issue."),
+				owner, repo)
+			if since := request.Params.Arguments["since"]; since != "" {
+				text += fmt.Sprintf(t("PROMPT_TRIAGE_ISSUES_SINCE", "\n\nOnly consider issues updated since %s."), since)
+			}
+			if label := request.Params.Arguments["label"]; label != "" {
+				text += fmt.Sprintf(t("PROMPT_TRIAGE_ISSUES_LABEL", "\n\nOnly consider issues labelled %q."), label)
+			}
+
+			return userPromptResult(description, text), nil
+		}
+}
+
+// SummarizeNotificationsPrompt creates a prompt that asks the model to summarize the user's notifications.
+func SummarizeNotificationsPrompt(t translations.TranslationHelperFunc) (prompt mcp.Prompt, handler server.PromptHandlerFunc) {
+	description := t("PROMPT_SUMMARIZE_NOTIFICATIONS_DESCRIPTION", "Summarize your GitHub notifications and highlight what needs your attention")
+	return mcp.NewPrompt("summarize_notifications",
+			mcp.WithPromptDescription(description),
+			mcp.WithArgument("filter",
+				mcp.ArgumentDescription("Which notifications to summarize: 'default', 'include_read_notifications' or 'only_participating'"),
+			),
+		),
+		func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			filter := request.Params.Arguments["filter"]
+			if filter == "" {
+				filter = FilterDefault
+			}
+			switch filter {
+			case FilterDefault, FilterIncludeRead, FilterOnlyParticipating:
+			default:
+				return nil, fmt.Errorf("invalid filter %q", filter)
+			}
+
+			text := fmt.Sprintf(t("PROMPT_SUMMARIZE_NOTIFICATIONS_TEXT",
+				"Summarize my GitHub notifications.\n\n"+
+					"1. Use list_notifications with filter %q to fetch them, and get_notification_details where the title alone isn't enough.\n"+
+					"2. Group them by repository and put first anything that needs action from me: review requests, mentions and assignments.\n"+
+					"3. For each, say in one line what happened and what, if anything, I should do.\n"+
+					"Don't dismiss or mark anything as read unless I ask you to."),
+				filter)
+
+			return userPromptResult(description, text), nil
+		}
+}
+
+// FixFailingCIPrompt creates a prompt that walks the model through fixing the failing checks on a pull request.
+func FixFailingCIPrompt(t translations.TranslationHelperFunc) (prompt mcp.Prompt, handler server.PromptHandlerFunc) {
+	description := t("PROMPT_FIX_FAILING_CI_DESCRIPTION", "Find out why the checks on a pull request are failing and push a fix")
+	return mcp.NewPrompt("fix_failing_ci",
+			mcp.WithPromptDescription(description),
+			mcp.WithArgument("owner",
+				mcp.ArgumentDescription("Repository owner"),
+				mcp.RequiredArgument(),
+			),
+			mcp.WithArgument("repo",
+				mcp.ArgumentDescription("Repository name"),
+				mcp.RequiredArgument(),
+			),
+			mcp.WithArgument("pullNumber",
+				mcp.ArgumentDescription("Pull request number"),
+				mcp.RequiredArgument(),
+			),
+		),
+		func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner, err := requiredPromptArgument(request, "owner")
+			if err != nil {
+				return nil, err
+			}
+			repo, err := requiredPromptArgument(request, "repo")
+			if err != nil {
+				return nil, err
+			}
+			pullNumber, err := requiredPromptNumberArgument(request, "pullNumber")
+			if err != nil {
+				return nil, err
+			}
+
+			text := fmt.Sprintf(t("PROMPT_FIX_FAILING_CI_TEXT",
+				"The checks on pull request #%d in %s/%s are failing. Fix them.\n\n"+
+					"1. Use get_pull_request_status to find which checks are failing, and get_required_status_checks to see which of them block merging.\n"+
+					"2. Use get_pull_request, get_pull_request_files and get_file_contents to understand the change and the code the failing checks exercise.\n"+
+					"3. Work out the cause. If it is in the pull request, commit a fix to its head branch with create_or_update_file or push_files. "+
+					"If it is unrelated to the change, e.g. a flaky test or an outdated base branch, say so instead of changing code; "+
+					"update_pull_request_branch can bring the branch up to date.\n"+
+					"Explain the cause and what you changed."),
+				pullNumber, owner, repo)
+
+			return userPromptResult(description, text), nil
+		}
+}
@@ -0,0 +1,148 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createPromptRequest(args map[string]string) mcp.GetPromptRequest {
+	var request mcp.GetPromptRequest
+	request.Params.Arguments = args
+	return request
+}
+
+func Test_ReviewPullRequestPrompt(t *testing.T) {
+	// Verify prompt definition once
+	prompt, handler := ReviewPullRequestPrompt(translations.NullTranslationHelper)
+
+	assert.Equal(t, "review_pull_request", prompt.Name)
+	assert.NotEmpty(t, prompt.Description)
+	require.Len(t, prompt.Arguments, 4)
+	assert.Equal(t, "owner", prompt.Arguments[0].Name)
+	assert.True(t, prompt.Arguments[0].Required)
+	assert.Equal(t, "focus", prompt.Arguments[3].Name)
+	assert.False(t, prompt.Arguments[3].Required)
+
+	tests := []struct {
+		name           string
+		args           map[string]string
+		expectError    bool
+		expectedErrMsg string
+		expectedText   []string
+	}{
+		{
+			name: "builds review instructions",
+			args: map[string]string{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": "42",
+			},
+			expectedText: []string{"Review pull request #42 in owner/repo.", "create_pending_pull_request_review"},
+		},
+		{
+			name: "includes focus",
+			args: map[string]string{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": "42",
+				"focus":      "security",
+			},
+			expectedText: []string{"Pay particular attention to: security."},
+		},
+		{
+			name: "missing pull number",
+			args: map[string]string{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required argument: pullNumber",
+		},
+		{
+			name: "invalid pull number",
+			args: map[string]string{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": "abc",
+			},
+			expectError:    true,
+			expectedErrMsg: `argument pullNumber must be a positive number, got "abc"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handler(context.Background(), createPromptRequest(tc.args))
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, result.Messages, 1)
+			assert.Equal(t, mcp.RoleUser, result.Messages[0].Role)
+			text, ok := result.Messages[0].Content.(mcp.TextContent)
+			require.True(t, ok)
+			for _, expected := range tc.expectedText {
+				assert.Contains(t, text.Text, expected)
+			}
+		})
+	}
+}
+
+func Test_SummarizeNotificationsPrompt(t *testing.T) {
+	// Verify prompt definition once
+	prompt, handler := SummarizeNotificationsPrompt(translations.NullTranslationHelper)
+
+	assert.Equal(t, "summarize_notifications", prompt.Name)
+	require.Len(t, prompt.Arguments, 1)
+	assert.False(t, prompt.Arguments[0].Required)
+
+	result, err := handler(context.Background(), createPromptRequest(nil))
+	require.NoError(t, err)
+	text, ok := result.Messages[0].Content.(mcp.TextContent)
+	require.True(t, ok)
+	assert.Contains(t, text.Text, `filter "default"`)
+
+	_, err = handler(context.Background(), createPromptRequest(map[string]string{"filter": "everything"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid filter "everything"`)
+}
+
+func Test_InitPrompts(t *testing.T) {
+	tsg, err := InitToolsets([]string{"issues"}, false, stubGetClientFn(nil), nil, translations.NullTranslationHelper)
+	require.NoError(t, err)
+
+	var names []string
+	for _, prompt := range tsg.GetAvailablePrompts() {
+		names = append(names, prompt.Prompt.Name)
+	}
+	assert.Equal(t, []string{"triage_issues"}, names)
+
+	require.NoError(t, tsg.EnableToolsets([]string{"pull_requests", "repos"}))
+
+	names = nil
+	for _, prompt := range tsg.GetAvailablePrompts() {
+		names = append(names, prompt.Prompt.Name)
+	}
+	assert.ElementsMatch(t, []string{"review_pull_request", "triage_issues", "fix_failing_ci"}, names)
+}
+
+func Test_InitPrompts_ReadOnly(t *testing.T) {
+	tsg, err := InitToolsets([]string{"all"}, true, stubGetClientFn(nil), nil, translations.NullTranslationHelper)
+	require.NoError(t, err)
+
+	var names []string
+	for _, prompt := range tsg.GetAvailablePrompts() {
+		names = append(names, prompt.Prompt.Name)
+	}
+	// The other prompts tell the model to use write tools, which aren't registered in read-only mode
+	assert.Equal(t, []string{"summarize_notifications"}, names)
+}
@@ -0,0 +1,227 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetPullRequestResource defines the resource template and handler for reading a pull request,
+// its comments, reviews and diff as markdown.
+func GetPullRequestResource(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"repo://{owner}/{repo}/pulls/{number}", // Resource template
+			t("RESOURCE_PULL_REQUEST_DESCRIPTION", "Pull request with its comments, review threads and diff"),
+			mcp.WithTemplateMIMEType("text/markdown"),
+		),
+		PullRequestResourceHandler(getClient)
+}
+
+// GetPullRequestResourceFileContent defines the resource template and handler for getting a file as it is in a pull request.
+func GetPullRequestResourceFileContent(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"repo://{owner}/{repo}/pulls/{number}/files{/path*}", // Resource template
+			t("RESOURCE_PULL_REQUEST_FILE_DESCRIPTION", "File content as of the head of a pull request"),
+		),
+		PullRequestResourceFileContentHandler(getClient)
+}
+
+// PullRequestResourceHandler returns a handler function for pull request resource requests.
+func PullRequestResourceHandler(getClient GetClientFn) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		owner, repo, number, err := resourceIssueArguments(request)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		// The number of pages of comments and reviews isn't known up front, so there is no total
+		progress := newRequestProgressReporter(ctx, 0)
+
+		pr, _, err := client.PullRequests.Get(ctx, owner, repo, number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request: %w", err)
+		}
+		progress.Step(fmt.Sprintf("Got pull request #%d", number))
+
+		comments, err := listAllIssueComments(ctx, client, owner, repo, number, progress)
+		if err != nil {
+			return nil, err
+		}
+
+		reviews, err := listAllPullRequestReviews(ctx, client, owner, repo, number, progress)
+		if err != nil {
+			return nil, err
+		}
+
+		reviewComments, err := listAllPullRequestReviewComments(ctx, client, owner, repo, number, progress)
+		if err != nil {
+			return nil, err
+		}
+
+		diff, _, err := client.PullRequests.GetRaw(ctx, owner, repo, number, github.RawOptions{Type: github.Diff})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request diff: %w", err)
+		}
+		progress.Step("Got the diff")
+
+		var b strings.Builder
+		writePullRequestMarkdown(&b, pr)
+		writeIssueCommentsMarkdown(&b, comments)
+		writePullRequestReviewsMarkdown(&b, reviews)
+		writeReviewThreadsMarkdown(&b, reviewComments)
+		b.WriteString("## Diff\n\n```diff\n")
+		b.WriteString(strings.TrimSuffix(diff, "\n"))
+		b.WriteString("\n```\n")
+
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "text/markdown",
+				Text:     b.String(),
+			},
+		}, nil
+	}
+}
+
+// PullRequestResourceFileContentHandler returns a handler function for pull request file content requests.
+// It reads the file at the pull request's head ref, so it also works for pull requests from forks.
+func PullRequestResourceFileContentHandler(getClient GetClientFn) server.ResourceTemplateHandlerFunc {
+	contentsHandler := RepositoryResourceContentsHandler(getClient)
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		_, _, number, err := resourceIssueArguments(request)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := resourceArgument(request, "path"); err != nil {
+			return nil, err
+		}
+
+		request.Params.Arguments["prNumber"] = []string{fmt.Sprintf("%d", number)}
+		return contentsHandler(ctx, request)
+	}
+}
+
+func listAllPullRequestReviews(ctx context.Context, client *github.Client, owner, repo string, number int, progress *progressReporter) ([]*github.PullRequestReview, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	var all []*github.PullRequestReview
+	for {
+		reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request reviews: %w", err)
+		}
+		all = append(all, reviews...)
+		progress.Step(fmt.Sprintf("Fetched %d reviews", len(all)))
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func listAllPullRequestReviewComments(ctx context.Context, client *github.Client, owner, repo string, number int, progress *progressReporter) ([]*github.PullRequestComment, error) {
+	opts := &github.PullRequestListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	var all []*github.PullRequestComment
+	for {
+		comments, resp, err := client.PullRequests.ListComments(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request review comments: %w", err)
+		}
+		all = append(all, comments...)
+		progress.Step(fmt.Sprintf("Fetched %d review comments", len(all)))
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func writePullRequestMarkdown(b *strings.Builder, pr *github.PullRequest) {
+	fmt.Fprintf(b, "# %s (#%d)\n\n", pr.GetTitle(), pr.GetNumber())
+	state := pr.GetState()
+	switch {
+	case pr.GetMerged():
+		state = "merged"
+	case pr.GetDraft():
+		state += " (draft)"
+	}
+	fmt.Fprintf(b, "- **State:** %s\n", state)
+	fmt.Fprintf(b, "- **Author:** @%s\n", pr.GetUser().GetLogin())
+	fmt.Fprintf(b, "- **Branches:** %s → %s\n", pr.GetHead().GetLabel(), pr.GetBase().GetRef())
+	fmt.Fprintf(b, "- **Created:** %s\n", formatResourceTime(pr.GetCreatedAt()))
+	fmt.Fprintf(b, "- **Changes:** %d files, +%d −%d\n", pr.GetChangedFiles(), pr.GetAdditions(), pr.GetDeletions())
+	if len(pr.Labels) > 0 {
+		labels := make([]string, 0, len(pr.Labels))
+		for _, label := range pr.Labels {
+			labels = append(labels, label.GetName())
+		}
+		fmt.Fprintf(b, "- **Labels:** %s\n", strings.Join(labels, ", "))
+	}
+	if len(pr.RequestedReviewers) > 0 {
+		reviewers := make([]string, 0, len(pr.RequestedReviewers))
+		for _, reviewer := range pr.RequestedReviewers {
+			reviewers = append(reviewers, "@"+reviewer.GetLogin())
+		}
+		fmt.Fprintf(b, "- **Requested reviewers:** %s\n", strings.Join(reviewers, ", "))
+	}
+	fmt.Fprintf(b, "- **URL:** %s\n\n", pr.GetHTMLURL())
+	writeMarkdownBody(b, pr.GetBody())
+}
+
+func writePullRequestReviewsMarkdown(b *strings.Builder, reviews []*github.PullRequestReview) {
+	fmt.Fprintf(b, "## Reviews (%d)\n\n", len(reviews))
+	for _, review := range reviews {
+		fmt.Fprintf(b, "### @%s: %s on %s\n\n", review.GetUser().GetLogin(), review.GetState(), formatResourceTime(review.GetSubmittedAt()))
+		if body := strings.TrimSpace(review.GetBody()); body != "" {
+			b.WriteString(body)
+			b.WriteString("\n\n")
+		}
+	}
+}
+
+// writeReviewThreadsMarkdown groups review comments into threads by the comment they reply to,
+// keeping threads in the order they were started.
+func writeReviewThreadsMarkdown(b *strings.Builder, comments []*github.PullRequestComment) {
+	var roots []int64
+	threads := make(map[int64][]*github.PullRequestComment)
+	for _, comment := range comments {
+		root := comment.GetInReplyTo()
+		if root == 0 {
+			root = comment.GetID()
+		}
+		if _, ok := threads[root]; !ok {
+			roots = append(roots, root)
+		}
+		threads[root] = append(threads[root], comment)
+	}
+
+	fmt.Fprintf(b, "## Review threads (%d)\n\n", len(roots))
+	for _, root := range roots {
+		thread := threads[root]
+		first := thread[0]
+		location := first.GetPath()
+		switch {
+		case first.GetSubjectType() == "file":
+		case first.Line != nil:
+			location = fmt.Sprintf("%s:%d", location, first.GetLine())
+		default:
+			location = fmt.Sprintf("%s:%d (outdated)", location, first.GetOriginalLine())
+		}
+		fmt.Fprintf(b, "### %s\n\n", location)
+		for _, comment := range thread {
+			fmt.Fprintf(b, "**@%s** on %s:\n\n", comment.GetUser().GetLogin(), formatResourceTime(comment.GetCreatedAt()))
+			writeMarkdownBody(b, comment.GetBody())
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetPullRequestResource(t *testing.T) {
+	tmpl, _ := GetPullRequestResource(nil, translations.NullTranslationHelper)
+	require.Equal(t, "repo://{owner}/{repo}/pulls/{number}", tmpl.URITemplate.Raw())
+	require.Equal(t, "text/markdown", tmpl.MIMEType)
+	require.True(t, tmpl.URITemplate.Regexp().MatchString("repo://owner/repo/pulls/42"))
+	require.False(t, tmpl.URITemplate.Regexp().MatchString("repo://owner/repo/pulls/42/files/README.md"))
+}
+
+func Test_GetPullRequestResourceFileContent(t *testing.T) {
+	tmpl, _ := GetPullRequestResourceFileContent(nil, translations.NullTranslationHelper)
+	require.Equal(t, "repo://{owner}/{repo}/pulls/{number}/files{/path*}", tmpl.URITemplate.Raw())
+}
+
+func Test_PullRequestResourceHandler(t *testing.T) {
+	created := github.Timestamp{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	mockPR := &github.PullRequest{
+		Number:       github.Ptr(42),
+		Title:        github.Ptr("Fix startup crash"),
+		Body:         github.Ptr("Fixes #41."),
+		State:        github.Ptr("open"),
+		HTMLURL:      github.Ptr("https://github.com/owner/repo/pull/42"),
+		User:         &github.User{Login: github.Ptr("contributor")},
+		CreatedAt:    &created,
+		Head:         &github.PullRequestBranch{Label: github.Ptr("contributor:fix-crash")},
+		Base:         &github.PullRequestBranch{Ref: github.Ptr("main")},
+		ChangedFiles: github.Ptr(1),
+		Additions:    github.Ptr(2),
+		Deletions:    github.Ptr(1),
+	}
+	mockComments := []*github.IssueComment{
+		{
+			Body:      github.Ptr("Thanks!"),
+			User:      &github.User{Login: github.Ptr("maintainer")},
+			CreatedAt: &created,
+		},
+	}
+	mockReviews := []*github.PullRequestReview{
+		{
+			State:       github.Ptr("CHANGES_REQUESTED"),
+			Body:        github.Ptr("One thing to fix."),
+			User:        &github.User{Login: github.Ptr("maintainer")},
+			SubmittedAt: &created,
+		},
+	}
+	mockReviewComments := []*github.PullRequestComment{
+		{
+			ID:        github.Ptr(int64(1)),
+			Path:      github.Ptr("main.go"),
+			Line:      github.Ptr(10),
+			Body:      github.Ptr("This can be nil."),
+			User:      &github.User{Login: github.Ptr("maintainer")},
+			CreatedAt: &created,
+		},
+		{
+			ID:           github.Ptr(int64(2)),
+			Path:         github.Ptr("util.go"),
+			OriginalLine: github.Ptr(3),
+			Body:         github.Ptr("Unused?"),
+			User:         &github.User{Login: github.Ptr("maintainer")},
+			CreatedAt:    &created,
+		},
+		{
+			ID:        github.Ptr(int64(3)),
+			InReplyTo: github.Ptr(int64(1)),
+			Path:      github.Ptr("main.go"),
+			Line:      github.Ptr(10),
+			Body:      github.Ptr("Good catch, fixed."),
+			User:      &github.User{Login: github.Ptr("contributor")},
+			CreatedAt: &created,
+		},
+	}
+	stubbedDiff := "diff --git a/main.go b/main.go\n+fixed\n"
+
+	tests := []struct {
+		name         string
+		mockedClient *http.Client
+		requestArgs  map[string]any
+		expectError  string
+		expectedText []string
+	}{
+		{
+			name: "renders pull request with comments, reviews, threads and diff",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if strings.Contains(r.Header.Get("Accept"), "diff") {
+							mockResponse(t, http.StatusOK, stubbedDiff)(w, r)
+							return
+						}
+						mockResponse(t, http.StatusOK, mockPR)(w, r)
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					mockComments,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
+					mockReviews,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsCommentsByOwnerByRepoByPullNumber,
+					mockReviewComments,
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"number": []string{"42"},
+			},
+			expectedText: []string{
+				"# Fix startup crash (#42)",
+				"- **Branches:** contributor:fix-crash → main",
+				"- **Changes:** 1 files, +2 −1",
+				"## Comments (1)",
+				"Thanks!",
+				"## Reviews (1)",
+				"### @maintainer: CHANGES_REQUESTED on 2024-05-01T12:00:00Z\n\nOne thing to fix.",
+				"## Review threads (2)",
+				"### main.go:10\n\n**@maintainer** on 2024-05-01T12:00:00Z:\n\nThis can be nil.\n\n**@contributor** on 2024-05-01T12:00:00Z:\n\nGood catch, fixed.",
+				"### util.go:3 (outdated)",
+				"## Diff\n\n```diff\ndiff --git a/main.go b/main.go\n+fixed\n```\n",
+			},
+		},
+		{
+			name: "pull request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"number": []string{"999"},
+			},
+			expectError: "failed to get pull request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			handler := PullRequestResourceHandler(stubGetClientFn(client))
+
+			request := mcp.ReadResourceRequest{
+				Params: struct {
+					URI       string         `json:"uri"`
+					Arguments map[string]any `json:"arguments,omitempty"`
+				}{
+					URI:       "repo://owner/repo/pulls/42",
+					Arguments: tc.requestArgs,
+				},
+			}
+
+			resp, err := handler(context.TODO(), request)
+
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, resp, 1)
+			content, ok := resp[0].(mcp.TextResourceContents)
+			require.True(t, ok)
+			assert.Equal(t, "text/markdown", content.MIMEType)
+			for _, expected := range tc.expectedText {
+				assert.Contains(t, content.Text, expected)
+			}
+		})
+	}
+}
+
+func Test_PullRequestResourceFileContentHandler(t *testing.T) {
+	mockFileContent := &github.RepositoryContent{
+		Type:        github.Ptr("file"),
+		Name:        github.Ptr("README.md"),
+		Path:        github.Ptr("README.md"),
+		Content:     github.Ptr("# Test Repository"),
+		DownloadURL: github.Ptr("https://raw.githubusercontent.com/owner/repo/main/README.md"),
+	}
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposContentsByOwnerByRepoByPath,
+			expectQueryParams(t, map[string]string{"ref": "refs/pull/42/head"}).andThen(
+				mockResponse(t, http.StatusOK, mockFileContent),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			GetRawReposContentsByOwnerByRepoByPath,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "text/markdown")
+				_, _ = w.Write([]byte("# Test Repository"))
+			}),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	handler := PullRequestResourceFileContentHandler(stubGetClientFn(client))
+
+	request := mcp.ReadResourceRequest{
+		Params: struct {
+			URI       string         `json:"uri"`
+			Arguments map[string]any `json:"arguments,omitempty"`
+		}{
+			URI: "repo://owner/repo/pulls/42/files/README.md",
+			Arguments: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"number": []string{"42"},
+				"path":   []string{"README.md"},
+			},
+		},
+	}
+
+	resp, err := handler(context.TODO(), request)
+	require.NoError(t, err)
+	require.Equal(t, []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      "repo://owner/repo/pulls/42/files/README.md",
+			MIMEType: "text/markdown",
+			Text:     "# Test Repository",
+		},
+	}, resp)
+
+	request.Params.Arguments = map[string]any{
+		"owner":  []string{"owner"},
+		"repo":   []string{"repo"},
+		"number": []string{"42"},
+	}
+	_, err = handler(context.TODO(), request)
+	require.ErrorContains(t, err, "path is required")
+}
@@ -0,0 +1,460 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+// maxStackDepth bounds how far we walk a stack in either direction, so that a misconfigured
+// set of pull requests (or a cycle of bases) can't make us page through the whole repository.
+const maxStackDepth = 25
+
+// Updating a pull request branch happens in the background, so before the pull requests stacked on
+// it are updated, we poll until its head has moved, giving up after branchUpdateTimeout.
+var (
+	branchUpdatePollInterval = 2 * time.Second
+	branchUpdateTimeout      = 2 * time.Minute
+)
+
+// StackLayer describes a single pull request in a stack of pull requests.
+type StackLayer struct {
+	Number         int    `json:"number"`
+	Title          string `json:"title,omitempty"`
+	State          string `json:"state,omitempty"`
+	Draft          bool   `json:"draft"`
+	Merged         bool   `json:"merged"`
+	MergeableState string `json:"mergeable_state,omitempty"`
+	HeadRef        string `json:"head_ref,omitempty"`
+	HeadSHA        string `json:"head_sha,omitempty"`
+	BaseRef        string `json:"base_ref,omitempty"`
+	HTMLURL        string `json:"html_url,omitempty"`
+	// Depth is the position of the pull request in the stack, where 1 targets the trunk branch.
+	Depth    int   `json:"depth"`
+	Parent   int   `json:"parent,omitempty"`
+	Children []int `json:"children,omitempty"`
+}
+
+// PullRequestStack is an ordered view of a stack of pull requests, bottom layer first.
+type PullRequestStack struct {
+	Trunk  string       `json:"trunk,omitempty"`
+	Target int          `json:"target"`
+	Layers []StackLayer `json:"layers"`
+}
+
+// GetPullRequestStack creates a tool to discover the stack of pull requests that a pull request belongs to.
+func GetPullRequestStack(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_stack",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_STACK_DESCRIPTION", "Discover the stack a pull request belongs to, i.e. the chain of pull requests whose base branch is another pull request's head branch. Returns the layers in merge order, bottom first, with the state of each layer.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_STACK_USER_TITLE", "Get pull request stack"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Number of any pull request in the stack"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
+			}
+
+			stack, err := discoverPullRequestStack(ctx, client, owner, repo, pr)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := json.Marshal(stack)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal pull request stack: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdatePullRequestStack creates a tool to restack the pull requests that were stacked on top of a merged pull request.
+func UpdatePullRequestStack(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("update_pull_request_stack",
+			mcp.WithDescription(t("TOOL_UPDATE_PULL_REQUEST_STACK_DESCRIPTION", "After a pull request in a stack has been merged, retarget the pull requests stacked directly on it to its base branch, then update the branch of every pull request above it in stack order, waiting for each update to land before updating the pull requests stacked on it. Stops at the first failure and reports which steps were completed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_PULL_REQUEST_STACK_USER_TITLE", "Update pull request stack"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Number of the merged pull request whose children should be restacked"),
+			),
+			mcp.WithBoolean("updateBranches",
+				mcp.Description("Update each pull request branch with its new base after retargeting (default true)"),
+				mcp.DefaultBool(true),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			updateBranches, ok, err := OptionalParamOK[bool](request, "updateBranches")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				updateBranches = true
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			parent, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
+			}
+
+			if !parent.GetMerged() {
+				return mcp.NewToolResultError(fmt.Sprintf("pull request #%d has not been merged; only the children of a merged pull request can be restacked", pullNumber)), nil
+			}
+
+			type RestackStep struct {
+				Number int    `json:"number"`
+				Action string `json:"action"`
+				Status string `json:"status"`
+				Detail string `json:"detail,omitempty"`
+			}
+
+			type RestackResult struct {
+				Parent    int           `json:"parent"`
+				NewBase   string        `json:"new_base"`
+				Steps     []RestackStep `json:"steps"`
+				Completed bool          `json:"completed"`
+			}
+
+			result := RestackResult{
+				Parent:  pullNumber,
+				NewBase: parent.GetBase().GetRef(),
+				Steps:   []RestackStep{},
+			}
+
+			marshalResult := func() (*mcp.CallToolResult, error) {
+				r, err := json.Marshal(result)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal restack result: %w", err)
+				}
+				return mcp.NewToolResultText(string(r)), nil
+			}
+
+			children, err := listStackedPullRequests(ctx, client, owner, repo, parent.GetHead().GetRef())
+			if err != nil {
+				return nil, err
+			}
+
+			// The number of pull requests above the direct children, and how long their updates take to
+			// land, isn't known up front, so there is no total
+			progress := newProgressReporter(ctx, request, 0)
+
+			// Retarget the direct children first, so that nothing is left pointing at a branch that may be deleted.
+			for _, child := range children {
+				_, resp, err := client.PullRequests.Edit(ctx, owner, repo, child.GetNumber(), &github.PullRequest{
+					Base: &github.PullRequestBranch{Ref: github.Ptr(result.NewBase)},
+				})
+				if err != nil {
+					result.Steps = append(result.Steps, RestackStep{Number: child.GetNumber(), Action: "retarget", Status: "failed", Detail: err.Error()})
+					return marshalResult()
+				}
+				_ = resp.Body.Close()
+				result.Steps = append(result.Steps, RestackStep{Number: child.GetNumber(), Action: "retarget", Status: "done", Detail: fmt.Sprintf("base changed to %s", result.NewBase)})
+				progress.Step(fmt.Sprintf("Retargeted #%d to %s", child.GetNumber(), result.NewBase))
+			}
+
+			if updateBranches {
+				// Update branches breadth first so that each pull request is updated after the one it is stacked on.
+				queue := children
+				visited := map[int]bool{pullNumber: true}
+				for depth := 0; len(queue) > 0 && depth < maxStackDepth; depth++ {
+					var next []*github.PullRequest
+					for _, pr := range queue {
+						if visited[pr.GetNumber()] {
+							continue
+						}
+						visited[pr.GetNumber()] = true
+
+						_, resp, err := client.PullRequests.UpdateBranch(ctx, owner, repo, pr.GetNumber(), &github.PullRequestBranchUpdateOptions{})
+						if err != nil && !(resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err)) {
+							result.Steps = append(result.Steps, RestackStep{Number: pr.GetNumber(), Action: "update_branch", Status: "failed", Detail: err.Error()})
+							return marshalResult()
+						}
+						if resp != nil {
+							_ = resp.Body.Close()
+						}
+						progress.Step(fmt.Sprintf("Requested a branch update for #%d", pr.GetNumber()))
+
+						grandchildren, err := listStackedPullRequests(ctx, client, owner, repo, pr.GetHead().GetRef())
+						if err != nil {
+							return nil, err
+						}
+						if len(grandchildren) == 0 {
+							// Nothing is stacked on it, so there is no need to wait for the update to land
+							result.Steps = append(result.Steps, RestackStep{Number: pr.GetNumber(), Action: "update_branch", Status: "in_progress"})
+							continue
+						}
+
+						headSHA, err := waitForHeadChange(ctx, client, owner, repo, pr.GetNumber(), pr.GetHead().GetSHA(), progress)
+						if err != nil {
+							result.Steps = append(result.Steps, RestackStep{Number: pr.GetNumber(), Action: "update_branch", Status: "timed_out", Detail: err.Error()})
+							return marshalResult()
+						}
+						result.Steps = append(result.Steps, RestackStep{Number: pr.GetNumber(), Action: "update_branch", Status: "done", Detail: fmt.Sprintf("head moved to %s", headSHA)})
+						next = append(next, grandchildren...)
+					}
+					queue = next
+				}
+			}
+
+			result.Completed = true
+			return marshalResult()
+		}
+}
+
+// waitForHeadChange polls a pull request until its head is no longer oldSHA, and returns the new head.
+// Every poll is reported to progress, so that the client can tell the call is still waiting.
+func waitForHeadChange(ctx context.Context, client *github.Client, owner, repo string, number int, oldSHA string, progress *progressReporter) (string, error) {
+	pollCtx, cancel := context.WithTimeout(ctx, branchUpdateTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(branchUpdatePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-pollCtx.Done():
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+			return "", fmt.Errorf("the branch update had not landed after %s; run this tool again once it has to update the pull requests stacked on #%d", branchUpdateTimeout, number)
+		case <-ticker.C:
+		}
+
+		pr, resp, err := client.PullRequests.Get(pollCtx, owner, repo, number)
+		if err != nil {
+			if pollCtx.Err() != nil {
+				continue
+			}
+			return "", fmt.Errorf("failed to get pull request #%d: %w", number, err)
+		}
+		_ = resp.Body.Close()
+		if sha := pr.GetHead().GetSHA(); sha != oldSHA {
+			return sha, nil
+		}
+		progress.Step(fmt.Sprintf("Waiting for the branch update of #%d to land", number))
+	}
+}
+
+// discoverPullRequestStack walks down from pr to the trunk branch and up through every pull request stacked on it.
+func discoverPullRequestStack(ctx context.Context, client *github.Client, owner, repo string, pr *github.PullRequest) (*PullRequestStack, error) {
+	layers := map[int]*StackLayer{}
+	order := []int{}
+
+	addLayer := func(p *github.PullRequest) *StackLayer {
+		layer := &StackLayer{
+			Number:         p.GetNumber(),
+			Title:          p.GetTitle(),
+			State:          p.GetState(),
+			Draft:          p.GetDraft(),
+			Merged:         p.GetMerged() || p.MergedAt != nil,
+			MergeableState: p.GetMergeableState(),
+			HeadRef:        p.GetHead().GetRef(),
+			HeadSHA:        p.GetHead().GetSHA(),
+			BaseRef:        p.GetBase().GetRef(),
+			HTMLURL:        p.GetHTMLURL(),
+		}
+		layers[layer.Number] = layer
+		order = append(order, layer.Number)
+		return layer
+	}
+
+	// Walk down the stack: the parent of a pull request is the one whose head branch is our base branch.
+	// We stop at the default branch, which is the trunk of any stack even if some pull request uses it as a head.
+	ancestors := []*github.PullRequest{pr}
+	current := pr
+	defaultBranch := pr.GetBase().GetRepo().GetDefaultBranch()
+	for len(ancestors) < maxStackDepth && current.GetBase().GetRef() != defaultBranch {
+		parents, resp, err := client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+			State: "all",
+			Head:  fmt.Sprintf("%s:%s", owner, current.GetBase().GetRef()),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull requests: %w", err)
+		}
+		_ = resp.Body.Close()
+
+		parent := pickStackParent(parents, ancestors)
+		if parent == nil {
+			break
+		}
+		ancestors = append(ancestors, parent)
+		current = parent
+	}
+
+	stack := &PullRequestStack{
+		Trunk:  current.GetBase().GetRef(),
+		Target: pr.GetNumber(),
+	}
+
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		layer := addLayer(ancestors[i])
+		layer.Depth = len(ancestors) - i
+		if i < len(ancestors)-1 {
+			layer.Parent = ancestors[i+1].GetNumber()
+		}
+	}
+
+	// Walk up the stack breadth first: children are open pull requests based on our head branch.
+	queue := []*github.PullRequest{pr}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		parentLayer := layers[p.GetNumber()]
+		if parentLayer.Depth >= maxStackDepth {
+			continue
+		}
+
+		children, err := listStackedPullRequests(ctx, client, owner, repo, p.GetHead().GetRef())
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range children {
+			if _, seen := layers[child.GetNumber()]; seen {
+				continue
+			}
+			layer := addLayer(child)
+			layer.Depth = parentLayer.Depth + 1
+			layer.Parent = parentLayer.Number
+			queue = append(queue, child)
+		}
+	}
+
+	// Link children now that every layer is known, so that the ancestors also point up the stack.
+	for _, number := range order {
+		layer := layers[number]
+		if parentLayer, ok := layers[layer.Parent]; ok && layer.Parent != 0 {
+			parentLayer.Children = append(parentLayer.Children, layer.Number)
+		}
+	}
+
+	stack.Layers = make([]StackLayer, 0, len(order))
+	for _, number := range order {
+		stack.Layers = append(stack.Layers, *layers[number])
+	}
+	return stack, nil
+}
+
+// pickStackParent chooses the pull request a layer is stacked on from the candidates whose head is its base.
+// Open pull requests win over closed ones, and pull requests already in the stack are ignored to avoid cycles.
+func pickStackParent(candidates []*github.PullRequest, seen []*github.PullRequest) *github.PullRequest {
+	var parent *github.PullRequest
+	for _, candidate := range candidates {
+		alreadySeen := false
+		for _, s := range seen {
+			if s.GetNumber() == candidate.GetNumber() {
+				alreadySeen = true
+				break
+			}
+		}
+		if alreadySeen {
+			continue
+		}
+		if parent == nil || (candidate.GetState() == "open" && parent.GetState() != "open") {
+			parent = candidate
+		}
+	}
+	return parent
+}
+
+// listStackedPullRequests lists the open pull requests whose base branch is the given branch.
+func listStackedPullRequests(ctx context.Context, client *github.Client, owner, repo, branch string) ([]*github.PullRequest, error) {
+	opts := &github.PullRequestListOptions{
+		State: "open",
+		Base:  branch,
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	prs, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests based on %s: %w", branch, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return prs, nil
+}
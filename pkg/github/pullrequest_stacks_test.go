@@ -0,0 +1,490 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stackedPR(number int, head, base, state string) *github.PullRequest {
+	return &github.PullRequest{
+		Number: github.Ptr(number),
+		Title:  github.Ptr("PR " + head),
+		State:  github.Ptr(state),
+		Head:   &github.PullRequestBranch{Ref: github.Ptr(head), SHA: github.Ptr(head + "-sha")},
+		Base: &github.PullRequestBranch{
+			Ref:  github.Ptr(base),
+			Repo: &github.Repository{DefaultBranch: github.Ptr("main")},
+		},
+	}
+}
+
+// mockStackListHandler serves the list pull requests endpoint, answering "head" queries from byHead
+// and "base" queries from byBase, as the stack discovery does.
+func mockStackListHandler(t *testing.T, byHead, byBase map[string][]*github.PullRequest) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if head := r.URL.Query().Get("head"); head != "" {
+			mockResponse(t, http.StatusOK, byHead[head])(w, r)
+			return
+		}
+		mockResponse(t, http.StatusOK, byBase[r.URL.Query().Get("base")])(w, r)
+	}
+}
+
+func Test_GetPullRequestStack(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPullRequestStack(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_pull_request_stack", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	// A stack of main <- feature-1 (#1) <- feature-2 (#2) <- {feature-3a (#3), feature-3b (#4)}
+	pr1 := stackedPR(1, "feature-1", "main", "open")
+	pr2 := stackedPR(2, "feature-2", "feature-1", "open")
+	pr3 := stackedPR(3, "feature-3a", "feature-2", "open")
+	pr4 := stackedPR(4, "feature-3b", "feature-2", "open")
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedTrunk  string
+		expectedLayers []StackLayer
+	}{
+		{
+			name: "discovers parents and children",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					pr2,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepo,
+					mockStackListHandler(t,
+						map[string][]*github.PullRequest{
+							"owner:feature-1": {pr1},
+						},
+						map[string][]*github.PullRequest{
+							"feature-2": {pr3, pr4},
+						},
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(2),
+			},
+			expectedTrunk: "main",
+			expectedLayers: []StackLayer{
+				{Number: 1, Depth: 1, HeadRef: "feature-1", BaseRef: "main", Children: []int{2}},
+				{Number: 2, Depth: 2, HeadRef: "feature-2", BaseRef: "feature-1", Parent: 1, Children: []int{3, 4}},
+				{Number: 3, Depth: 3, HeadRef: "feature-3a", BaseRef: "feature-2", Parent: 2},
+				{Number: 4, Depth: 3, HeadRef: "feature-3b", BaseRef: "feature-2", Parent: 2},
+			},
+		},
+		{
+			name: "pull request not in a stack",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					pr1,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepo,
+					mockStackListHandler(t, nil, nil),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(1),
+			},
+			expectedTrunk: "main",
+			expectedLayers: []StackLayer{
+				{Number: 1, Depth: 1, HeadRef: "feature-1", BaseRef: "main"},
+			},
+		},
+		{
+			name: "pull request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPullRequestStack(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var stack PullRequestStack
+			err = json.Unmarshal([]byte(textContent.Text), &stack)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedTrunk, stack.Trunk)
+			assert.Equal(t, int(tc.requestArgs["pullNumber"].(float64)), stack.Target)
+			require.Len(t, stack.Layers, len(tc.expectedLayers))
+			for i, expected := range tc.expectedLayers {
+				assert.Equal(t, expected.Number, stack.Layers[i].Number)
+				assert.Equal(t, expected.Depth, stack.Layers[i].Depth)
+				assert.Equal(t, expected.HeadRef, stack.Layers[i].HeadRef)
+				assert.Equal(t, expected.BaseRef, stack.Layers[i].BaseRef)
+				assert.Equal(t, expected.Parent, stack.Layers[i].Parent)
+				assert.Equal(t, expected.Children, stack.Layers[i].Children)
+			}
+		})
+	}
+}
+
+// mockBranchUpdate serves the get pull request and update branch endpoints for a stack whose branch
+// updates land in the background: the head of a pull request only moves on the polls after its
+// branch update was accepted, once landAfter polls have seen the old head.
+type mockBranchUpdate struct {
+	prs       map[string]*github.PullRequest // by number
+	landAfter int
+	updated   map[string]int // polls since the update, by number
+	events    []string
+}
+
+func (m *mockBranchUpdate) getHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		number := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		pr := *m.prs[number]
+		if polls, ok := m.updated[number]; ok {
+			m.updated[number] = polls + 1
+			if polls >= m.landAfter {
+				pr.Head = &github.PullRequestBranch{Ref: pr.Head.Ref, SHA: github.Ptr("updated-sha")}
+				m.events = append(m.events, "landed "+number)
+			}
+		}
+		mockResponse(t, http.StatusOK, &pr)(w, r)
+	}
+}
+
+func (m *mockBranchUpdate) updateHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		number := strings.Split(r.URL.Path, "/")[5]
+		m.updated[number] = 0
+		m.events = append(m.events, "update "+number)
+		mockResponse(t, http.StatusAccepted, &github.PullRequestBranchUpdateResponse{Message: github.Ptr("Updating pull request branch.")})(w, r)
+	}
+}
+
+func Test_UpdatePullRequestStack(t *testing.T) {
+	pollInterval, timeout := branchUpdatePollInterval, branchUpdateTimeout
+	branchUpdatePollInterval, branchUpdateTimeout = time.Millisecond, 100*time.Millisecond
+	t.Cleanup(func() { branchUpdatePollInterval, branchUpdateTimeout = pollInterval, timeout })
+
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdatePullRequestStack(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_pull_request_stack", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "updateBranches")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	mergedParent := stackedPR(1, "feature-1", "main", "closed")
+	mergedParent.Merged = github.Ptr(true)
+	child := stackedPR(2, "feature-2", "feature-1", "open")
+	grandchild := stackedPR(3, "feature-3", "feature-2", "open")
+	newBranchUpdate := func(landAfter int) *mockBranchUpdate {
+		return &mockBranchUpdate{
+			prs:       map[string]*github.PullRequest{"1": mergedParent, "2": child, "3": grandchild},
+			landAfter: landAfter,
+			updated:   map[string]int{},
+		}
+	}
+	inOrder := newBranchUpdate(2)
+	neverLands := newBranchUpdate(1 << 30)
+
+	type restackStep struct {
+		Number int    `json:"number"`
+		Action string `json:"action"`
+		Status string `json:"status"`
+	}
+	type restackResult struct {
+		Parent    int           `json:"parent"`
+		NewBase   string        `json:"new_base"`
+		Steps     []restackStep `json:"steps"`
+		Completed bool          `json:"completed"`
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult restackResult
+		branchUpdate   *mockBranchUpdate
+		expectedEvents []string
+	}{
+		{
+			name: "retargets children and updates branches in order",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					inOrder.getHandler(t),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepo,
+					mockStackListHandler(t, nil, map[string][]*github.PullRequest{
+						"feature-1": {child},
+						"feature-2": {grandchild},
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposPullsByOwnerByRepoByPullNumber,
+					expect(t, expectations{
+						path: "/repos/owner/repo/pulls/2",
+						requestBody: map[string]any{
+							"base": "main",
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, child),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposPullsUpdateBranchByOwnerByRepoByPullNumber,
+					inOrder.updateHandler(t),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(1),
+			},
+			expectedResult: restackResult{
+				Parent:  1,
+				NewBase: "main",
+				Steps: []restackStep{
+					{Number: 2, Action: "retarget", Status: "done"},
+					{Number: 2, Action: "update_branch", Status: "done"},
+					{Number: 3, Action: "update_branch", Status: "in_progress"},
+				},
+				Completed: true,
+			},
+			// The grandchild is only updated once the child's update has landed
+			branchUpdate:   inOrder,
+			expectedEvents: []string{"update 2", "landed 2", "update 3"},
+		},
+		{
+			name: "stops when a branch update does not land",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					neverLands.getHandler(t),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepo,
+					mockStackListHandler(t, nil, map[string][]*github.PullRequest{
+						"feature-1": {child},
+						"feature-2": {grandchild},
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.PatchReposPullsByOwnerByRepoByPullNumber,
+					child,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposPullsUpdateBranchByOwnerByRepoByPullNumber,
+					neverLands.updateHandler(t),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(1),
+			},
+			expectedResult: restackResult{
+				Parent:  1,
+				NewBase: "main",
+				Steps: []restackStep{
+					{Number: 2, Action: "retarget", Status: "done"},
+					{Number: 2, Action: "update_branch", Status: "timed_out"},
+				},
+				Completed: false,
+			},
+			branchUpdate:   neverLands,
+			expectedEvents: []string{"update 2"},
+		},
+		{
+			name: "retarget only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mergedParent,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepo,
+					mockStackListHandler(t, nil, map[string][]*github.PullRequest{
+						"feature-1": {child},
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.PatchReposPullsByOwnerByRepoByPullNumber,
+					child,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"pullNumber":     float64(1),
+				"updateBranches": false,
+			},
+			expectedResult: restackResult{
+				Parent:  1,
+				NewBase: "main",
+				Steps: []restackStep{
+					{Number: 2, Action: "retarget", Status: "done"},
+				},
+				Completed: true,
+			},
+		},
+		{
+			name: "stops at the first failure",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mergedParent,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepo,
+					mockStackListHandler(t, nil, map[string][]*github.PullRequest{
+						"feature-1": {child},
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.PatchReposPullsByOwnerByRepoByPullNumber,
+					child,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposPullsUpdateBranchByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "merge conflict between base and head"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(1),
+			},
+			expectedResult: restackResult{
+				Parent:  1,
+				NewBase: "main",
+				Steps: []restackStep{
+					{Number: 2, Action: "retarget", Status: "done"},
+					{Number: 2, Action: "update_branch", Status: "failed"},
+				},
+				Completed: false,
+			},
+		},
+		{
+			name: "parent not merged",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					child,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(2),
+			},
+			expectError:    false,
+			expectedErrMsg: "has not been merged",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdatePullRequestStack(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned restackResult
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returned)
+			if tc.branchUpdate != nil {
+				assert.Equal(t, tc.expectedEvents, tc.branchUpdate.events)
+			}
+		})
+	}
+}
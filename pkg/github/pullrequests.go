@@ -21,6 +21,9 @@ import (
 func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("get_pull_request",
 			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_DESCRIPTION", "Get details of a specific pull request in a GitHub repository.")),
+			mcp.WithOutputSchema[MinimalPullRequest](),
+			WithFieldSelection(),
+			WithOutputFormat(),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_PULL_REQUEST_USER_TITLE", "Get pull request details"),
 				ReadOnlyHint: toBoolPtr(true),
@@ -70,159 +73,13 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
 			}
 
-			// Create simplified user structure
-			type SimplifiedUser struct {
-				Login     string `json:"login,omitempty"`
-				AvatarURL string `json:"avatar_url,omitempty"`
-				HTMLURL   string `json:"html_url,omitempty"`
-			}
-
-			// Create simplified label structure
-			type SimplifiedLabel struct {
-				Name        string `json:"name,omitempty"`
-				Color       string `json:"color,omitempty"`
-				Description string `json:"description,omitempty"`
-			}
-
-			// Create simplified pull request structure
-			type SimplifiedPullRequest struct {
-				ID        int64  `json:"id"`
-				Number    int    `json:"number"`
-				State     string `json:"state,omitempty"`
-				Locked    bool   `json:"locked"`
-				Title     string `json:"title,omitempty"`
-				Body      string `json:"body,omitempty"`
-				HTMLURL   string `json:"html_url,omitempty"`
-				CreatedAt string `json:"created_at,omitempty"`
-				UpdatedAt string `json:"updated_at,omitempty"`
-				ClosedAt  string `json:"closed_at,omitempty"`
-				MergedAt  string `json:"merged_at,omitempty"`
-
-				User      *SimplifiedUser  `json:"user,omitempty"`
-				Assignee  *SimplifiedUser  `json:"assignee,omitempty"`
-				Assignees []SimplifiedUser `json:"assignees,omitempty"`
-
-				Labels []SimplifiedLabel `json:"labels,omitempty"`
-
-				Draft          bool   `json:"draft"`
-				Merged         bool   `json:"merged"`
-				Mergeable      bool   `json:"mergeable"`
-				MergeableState string `json:"mergeable_state,omitempty"`
-
-				Head struct {
-					Label string `json:"label,omitempty"`
-					Ref   string `json:"ref,omitempty"`
-					SHA   string `json:"sha,omitempty"`
-				} `json:"head,omitempty"`
-
-				Base struct {
-					Label string `json:"label,omitempty"`
-					Ref   string `json:"ref,omitempty"`
-					SHA   string `json:"sha,omitempty"`
-				} `json:"base,omitempty"`
-
-				Comments     int `json:"comments"`
-				Commits      int `json:"commits"`
-				Additions    int `json:"additions"`
-				Deletions    int `json:"deletions"`
-				ChangedFiles int `json:"changed_files"`
-			}
-
-			// Create simplified pull request instance
-			simplifiedPR := SimplifiedPullRequest{
-				ID:             pr.GetID(),
-				Number:         pr.GetNumber(),
-				State:          pr.GetState(),
-				Locked:         pr.GetLocked(),
-				Title:          pr.GetTitle(),
-				Body:           pr.GetBody(),
-				HTMLURL:        pr.GetHTMLURL(),
-				Draft:          pr.GetDraft(),
-				Merged:         pr.GetMerged(),
-				Mergeable:      pr.GetMergeable(),
-				MergeableState: pr.GetMergeableState(),
-				Comments:       pr.GetComments(),
-				Commits:        pr.GetCommits(),
-				Additions:      pr.GetAdditions(),
-				Deletions:      pr.GetDeletions(),
-				ChangedFiles:   pr.GetChangedFiles(),
-			}
-
-			// Format dates
-			if pr.CreatedAt != nil {
-				simplifiedPR.CreatedAt = pr.CreatedAt.Format(time.RFC3339)
-			}
-			if pr.UpdatedAt != nil {
-				simplifiedPR.UpdatedAt = pr.UpdatedAt.Format(time.RFC3339)
-			}
-			if pr.ClosedAt != nil && !pr.ClosedAt.IsZero() {
-				simplifiedPR.ClosedAt = pr.ClosedAt.Format(time.RFC3339)
-			}
-			if pr.MergedAt != nil && !pr.MergedAt.IsZero() {
-				simplifiedPR.MergedAt = pr.MergedAt.Format(time.RFC3339)
-			}
-
-			// Add user
-			if pr.User != nil {
-				simplifiedPR.User = &SimplifiedUser{
-					Login:     pr.User.GetLogin(),
-					AvatarURL: pr.User.GetAvatarURL(),
-					HTMLURL:   pr.User.GetHTMLURL(),
-				}
-			}
-
-			// Add assignee
-			if pr.Assignee != nil {
-				simplifiedPR.Assignee = &SimplifiedUser{
-					Login:     pr.Assignee.GetLogin(),
-					AvatarURL: pr.Assignee.GetAvatarURL(),
-					HTMLURL:   pr.Assignee.GetHTMLURL(),
-				}
-			}
-
-			// Add assignees
-			if pr.Assignees != nil {
-				simplifiedPR.Assignees = make([]SimplifiedUser, 0, len(pr.Assignees))
-				for _, assignee := range pr.Assignees {
-					simplifiedPR.Assignees = append(simplifiedPR.Assignees, SimplifiedUser{
-						Login:     assignee.GetLogin(),
-						AvatarURL: assignee.GetAvatarURL(),
-						HTMLURL:   assignee.GetHTMLURL(),
-					})
-				}
-			}
-
-			// Add labels
-			if pr.Labels != nil {
-				simplifiedPR.Labels = make([]SimplifiedLabel, 0, len(pr.Labels))
-				for _, label := range pr.Labels {
-					simplifiedPR.Labels = append(simplifiedPR.Labels, SimplifiedLabel{
-						Name:        label.GetName(),
-						Color:       label.GetColor(),
-						Description: label.GetDescription(),
-					})
-				}
-			}
-
-			// Add head and base info
-			if pr.Head != nil {
-				simplifiedPR.Head.Label = pr.Head.GetLabel()
-				simplifiedPR.Head.Ref = pr.Head.GetRef()
-				simplifiedPR.Head.SHA = pr.Head.GetSHA()
-			}
-
-			if pr.Base != nil {
-				simplifiedPR.Base.Label = pr.Base.GetLabel()
-				simplifiedPR.Base.Ref = pr.Base.GetRef()
-				simplifiedPR.Base.SHA = pr.Base.GetSHA()
-			}
-
-			r, err := json.Marshal(simplifiedPR)
+			minimalPR := newMinimalPullRequest(pr)
+			r, err := json.Marshal(minimalPR)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal simplified pull request: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return mcp.NewToolResultStructured(minimalPR, string(r)), nil
 		}
 }
 
@@ -230,6 +87,7 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("create_pull_request",
 			mcp.WithDescription(t("TOOL_CREATE_PULL_REQUEST_DESCRIPTION", "Create a new pull request in a GitHub repository.")),
+			mcp.WithOutputSchema[MinimalPullRequest](),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_CREATE_PULL_REQUEST_USER_TITLE", "Open new pull request"),
 				ReadOnlyHint: toBoolPtr(false),
@@ -332,91 +190,13 @@ func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 				return mcp.NewToolResultError(fmt.Sprintf("failed to create pull request: %s", string(body))), nil
 			}
 
-			// Create simplified user structure
-			type SimplifiedUser struct {
-				Login     string `json:"login,omitempty"`
-				AvatarURL string `json:"avatar_url,omitempty"`
-				HTMLURL   string `json:"html_url,omitempty"`
-			}
-
-			// Create simplified label structure
-			type SimplifiedLabel struct {
-				Name        string `json:"name,omitempty"`
-				Color       string `json:"color,omitempty"`
-				Description string `json:"description,omitempty"`
-			}
-
-			// Create simplified pull request structure
-			type SimplifiedPullRequest struct {
-				ID        int64  `json:"id"`
-				Number    int    `json:"number"`
-				State     string `json:"state,omitempty"`
-				Title     string `json:"title,omitempty"`
-				Body      string `json:"body,omitempty"`
-				HTMLURL   string `json:"html_url,omitempty"`
-				CreatedAt string `json:"created_at,omitempty"`
-
-				User *SimplifiedUser `json:"user,omitempty"`
-
-				Head struct {
-					Label string `json:"label,omitempty"`
-					Ref   string `json:"ref,omitempty"`
-					SHA   string `json:"sha,omitempty"`
-				} `json:"head,omitempty"`
-
-				Base struct {
-					Label string `json:"label,omitempty"`
-					Ref   string `json:"ref,omitempty"`
-					SHA   string `json:"sha,omitempty"`
-				} `json:"base,omitempty"`
-
-				Draft bool `json:"draft"`
-			}
-
-			// Create simplified pull request instance
-			simplifiedPR := SimplifiedPullRequest{
-				ID:      pr.GetID(),
-				Number:  pr.GetNumber(),
-				State:   pr.GetState(),
-				Title:   pr.GetTitle(),
-				Body:    pr.GetBody(),
-				HTMLURL: pr.GetHTMLURL(),
-				Draft:   pr.GetDraft(),
-			}
-
-			// Format dates
-			if pr.CreatedAt != nil {
-				simplifiedPR.CreatedAt = pr.CreatedAt.Format(time.RFC3339)
-			}
-
-			// Add user
-			if pr.User != nil {
-				simplifiedPR.User = &SimplifiedUser{
-					Login:     pr.User.GetLogin(),
-					AvatarURL: pr.User.GetAvatarURL(),
-					HTMLURL:   pr.User.GetHTMLURL(),
-				}
-			}
-
-			// Add head and base info
-			if pr.Head != nil {
-				simplifiedPR.Head.Label = pr.Head.GetLabel()
-				simplifiedPR.Head.Ref = pr.Head.GetRef()
-				simplifiedPR.Head.SHA = pr.Head.GetSHA()
-			}
-
-			if pr.Base != nil {
-				simplifiedPR.Base.Label = pr.Base.GetLabel()
-				simplifiedPR.Base.Ref = pr.Base.GetRef()
-				simplifiedPR.Base.SHA = pr.Base.GetSHA()
-			}
-
-			r, err := json.Marshal(simplifiedPR)
+			minimalPR := newMinimalPullRequest(pr)
+			r, err := json.Marshal(minimalPR)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal simplified pull request: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return mcp.NewToolResultStructured(minimalPR, string(r)), nil
 		}
 }
 
@@ -424,6 +204,7 @@ func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 func UpdatePullRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("update_pull_request",
 			mcp.WithDescription(t("TOOL_UPDATE_PULL_REQUEST_DESCRIPTION", "Update an existing pull request in a GitHub repository.")),
+			mcp.WithOutputSchema[MinimalPullRequest](),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_UPDATE_PULL_REQUEST_USER_TITLE", "Edit pull request"),
 				ReadOnlyHint: toBoolPtr(false),
@@ -532,91 +313,13 @@ func UpdatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 				return mcp.NewToolResultError(fmt.Sprintf("failed to update pull request: %s", string(body))), nil
 			}
 
-			// Create simplified user structure
-			type SimplifiedUser struct {
-				Login     string `json:"login,omitempty"`
-				AvatarURL string `json:"avatar_url,omitempty"`
-				HTMLURL   string `json:"html_url,omitempty"`
-			}
-
-			// Create simplified label structure
-			type SimplifiedLabel struct {
-				Name        string `json:"name,omitempty"`
-				Color       string `json:"color,omitempty"`
-				Description string `json:"description,omitempty"`
-			}
-
-			// Create simplified pull request structure
-			type SimplifiedPullRequest struct {
-				ID        int64  `json:"id"`
-				Number    int    `json:"number"`
-				State     string `json:"state,omitempty"`
-				Title     string `json:"title,omitempty"`
-				Body      string `json:"body,omitempty"`
-				HTMLURL   string `json:"html_url,omitempty"`
-				UpdatedAt string `json:"updated_at,omitempty"`
-
-				User *SimplifiedUser `json:"user,omitempty"`
-
-				Head struct {
-					Label string `json:"label,omitempty"`
-					Ref   string `json:"ref,omitempty"`
-					SHA   string `json:"sha,omitempty"`
-				} `json:"head,omitempty"`
-
-				Base struct {
-					Label string `json:"label,omitempty"`
-					Ref   string `json:"ref,omitempty"`
-					SHA   string `json:"sha,omitempty"`
-				} `json:"base,omitempty"`
-
-				MaintainerCanModify bool `json:"maintainer_can_modify"`
-			}
-
-			// Create simplified pull request instance
-			simplifiedPR := SimplifiedPullRequest{
-				ID:                  pr.GetID(),
-				Number:              pr.GetNumber(),
-				State:               pr.GetState(),
-				Title:               pr.GetTitle(),
-				Body:                pr.GetBody(),
-				HTMLURL:             pr.GetHTMLURL(),
-				MaintainerCanModify: pr.GetMaintainerCanModify(),
-			}
-
-			// Format dates
-			if pr.UpdatedAt != nil {
-				simplifiedPR.UpdatedAt = pr.UpdatedAt.Format(time.RFC3339)
-			}
-
-			// Add user
-			if pr.User != nil {
-				simplifiedPR.User = &SimplifiedUser{
-					Login:     pr.User.GetLogin(),
-					AvatarURL: pr.User.GetAvatarURL(),
-					HTMLURL:   pr.User.GetHTMLURL(),
-				}
-			}
-
-			// Add head and base info
-			if pr.Head != nil {
-				simplifiedPR.Head.Label = pr.Head.GetLabel()
-				simplifiedPR.Head.Ref = pr.Head.GetRef()
-				simplifiedPR.Head.SHA = pr.Head.GetSHA()
-			}
-
-			if pr.Base != nil {
-				simplifiedPR.Base.Label = pr.Base.GetLabel()
-				simplifiedPR.Base.Ref = pr.Base.GetRef()
-				simplifiedPR.Base.SHA = pr.Base.GetSHA()
-			}
-
-			r, err := json.Marshal(simplifiedPR)
+			minimalPR := newMinimalPullRequest(pr)
+			r, err := json.Marshal(minimalPR)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal simplified pull request: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return mcp.NewToolResultStructured(minimalPR, string(r)), nil
 		}
 }
 
@@ -624,6 +327,9 @@ func UpdatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("list_pull_requests",
 			mcp.WithDescription(t("TOOL_LIST_PULL_REQUESTS_DESCRIPTION", "List pull requests in a GitHub repository.")),
+			mcp.WithOutputSchema[MinimalList[MinimalPullRequest]](),
+			WithFieldSelection(),
+			WithOutputFormat(),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_PULL_REQUESTS_USER_TITLE", "List pull requests"),
 				ReadOnlyHint: toBoolPtr(true),
@@ -711,6 +417,7 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				return nil, fmt.Errorf("failed to list pull requests: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
 
 			if resp.StatusCode != http.StatusOK {
 				body, err := io.ReadAll(resp.Body)
@@ -720,86 +427,17 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list pull requests: %s", string(body))), nil
 			}
 
-			// Create simplified user structure
-			type SimplifiedUser struct {
-				Login     string `json:"login,omitempty"`
-				AvatarURL string `json:"avatar_url,omitempty"`
-				HTMLURL   string `json:"html_url,omitempty"`
-			}
-
-			// Create simplified PR structure
-			type SimplifiedPR struct {
-				ID        int64  `json:"id"`
-				Number    int    `json:"number"`
-				State     string `json:"state,omitempty"`
-				Title     string `json:"title,omitempty"`
-				HTMLURL   string `json:"html_url,omitempty"`
-				CreatedAt string `json:"created_at,omitempty"`
-				UpdatedAt string `json:"updated_at,omitempty"`
-
-				User *SimplifiedUser `json:"user,omitempty"`
-
-				Head struct {
-					Ref string `json:"ref,omitempty"`
-					SHA string `json:"sha,omitempty"`
-				} `json:"head,omitempty"`
-
-				Base struct {
-					Ref string `json:"ref,omitempty"`
-				} `json:"base,omitempty"`
-
-				Draft bool `json:"draft"`
-			}
-
-			// Create simplified PRs list
-			simplifiedPRs := make([]SimplifiedPR, 0, len(prs))
-
+			minimalPRs := make([]MinimalPullRequest, 0, len(prs))
 			for _, pr := range prs {
-				simplifiedPR := SimplifiedPR{
-					ID:      pr.GetID(),
-					Number:  pr.GetNumber(),
-					State:   pr.GetState(),
-					Title:   pr.GetTitle(),
-					HTMLURL: pr.GetHTMLURL(),
-					Draft:   pr.GetDraft(),
-				}
-
-				// Format dates
-				if pr.CreatedAt != nil {
-					simplifiedPR.CreatedAt = pr.CreatedAt.Format(time.RFC3339)
-				}
-				if pr.UpdatedAt != nil {
-					simplifiedPR.UpdatedAt = pr.UpdatedAt.Format(time.RFC3339)
-				}
-
-				// Add user
-				if pr.User != nil {
-					simplifiedPR.User = &SimplifiedUser{
-						Login:     pr.User.GetLogin(),
-						AvatarURL: pr.User.GetAvatarURL(),
-						HTMLURL:   pr.User.GetHTMLURL(),
-					}
-				}
-
-				// Add head and base info
-				if pr.Head != nil {
-					simplifiedPR.Head.Ref = pr.Head.GetRef()
-					simplifiedPR.Head.SHA = pr.Head.GetSHA()
-				}
-
-				if pr.Base != nil {
-					simplifiedPR.Base.Ref = pr.Base.GetRef()
-				}
-
-				simplifiedPRs = append(simplifiedPRs, simplifiedPR)
+				minimalPRs = append(minimalPRs, newMinimalPullRequest(pr))
 			}
 
-			r, err := json.Marshal(simplifiedPRs)
+			r, err := json.Marshal(minimalPRs)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal simplified pull requests: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return mcp.NewToolResultStructured(MinimalList[MinimalPullRequest]{Items: minimalPRs}, string(r)), nil
 		}
 }
 
@@ -926,6 +564,7 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -940,17 +579,25 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			opts := &github.ListOptions{}
+			opts := &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			}
 			files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get pull request files: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
 
 			if resp.StatusCode != http.StatusOK {
 				body, err := io.ReadAll(resp.Body)
@@ -1215,6 +862,7 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("get_pull_request_comments",
 			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_COMMENTS_DESCRIPTION", "Get comments for a specific pull request.")),
+			WithOutputFormat(),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_PULL_REQUEST_COMMENTS_USER_TITLE", "Get pull request comments"),
 				ReadOnlyHint: toBoolPtr(true),
@@ -1231,6 +879,7 @@ func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHel
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -1245,10 +894,15 @@ func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHel
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			opts := &github.PullRequestListCommentsOptions{
 				ListOptions: github.ListOptions{
-					PerPage: 100,
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
 				},
 			}
 
@@ -1261,6 +915,7 @@ func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHel
 				return nil, fmt.Errorf("failed to get pull request comments: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
 
 			if resp.StatusCode != http.StatusOK {
 				body, err := io.ReadAll(resp.Body)
@@ -1342,6 +997,7 @@ func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHel
 func GetPullRequestReviews(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("get_pull_request_reviews",
 			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_REVIEWS_DESCRIPTION", "Get reviews for a specific pull request.")),
+			WithOutputFormat(),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_PULL_REQUEST_REVIEWS_USER_TITLE", "Get pull request reviews"),
 				ReadOnlyHint: toBoolPtr(true),
@@ -1358,6 +1014,7 @@ func GetPullRequestReviews(getClient GetClientFn, t translations.TranslationHelp
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -1372,16 +1029,25 @@ func GetPullRequestReviews(getClient GetClientFn, t translations.TranslationHelp
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, pullNumber, nil)
+			opts := &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			}
+			reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, pullNumber, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get pull request reviews: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
 
 			if resp.StatusCode != http.StatusOK {
 				body, err := io.ReadAll(resp.Body)
@@ -1744,7 +1410,7 @@ func AddPullRequestReviewCommentToPendingReview(getGQLClient GetGQLClientFn, t t
 				"prNum":  githubv4.Int(params.PullNumber),
 			}
 
-			if err := client.Query(context.Background(), &getLatestReviewForViewerQuery, vars); err != nil {
+			if err := client.Query(ctx, &getLatestReviewForViewerQuery, vars); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
@@ -1875,7 +1541,7 @@ func SubmitPendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 				"prNum":  githubv4.Int(params.PullNumber),
 			}
 
-			if err := client.Query(context.Background(), &getLatestReviewForViewerQuery, vars); err != nil {
+			if err := client.Query(ctx, &getLatestReviewForViewerQuery, vars); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
@@ -1990,7 +1656,7 @@ func DeletePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 				"prNum":  githubv4.Int(params.PullNumber),
 			}
 
-			if err := client.Query(context.Background(), &getLatestReviewForViewerQuery, vars); err != nil {
+			if err := client.Query(ctx, &getLatestReviewForViewerQuery, vars); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
@@ -0,0 +1,618 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+const (
+	ReactionSubjectIssue                    = "issue"
+	ReactionSubjectIssueComment             = "issue_comment"
+	ReactionSubjectPullRequestReviewComment = "pull_request_review_comment"
+	ReactionSubjectDiscussion               = "discussion"
+)
+
+// reactionContents are the reactions GitHub supports, as named by the REST API. The order matches the GitHub UI.
+var reactionContents = []string{"+1", "-1", "laugh", "hooray", "confused", "heart", "rocket", "eyes"}
+
+// graphQLReactionContents maps the REST names of reactions to the GraphQL ones.
+var graphQLReactionContents = map[string]githubv4.ReactionContent{
+	"+1":       githubv4.ReactionContentThumbsUp,
+	"-1":       githubv4.ReactionContentThumbsDown,
+	"laugh":    githubv4.ReactionContentLaugh,
+	"hooray":   githubv4.ReactionContentHooray,
+	"confused": githubv4.ReactionContentConfused,
+	"heart":    githubv4.ReactionContentHeart,
+	"rocket":   githubv4.ReactionContentRocket,
+	"eyes":     githubv4.ReactionContentEyes,
+}
+
+func restReactionContent(content githubv4.ReactionContent) string {
+	for name, c := range graphQLReactionContents {
+		if c == content {
+			return name
+		}
+	}
+	return string(content)
+}
+
+// Reaction is a simplified view of a reaction on an issue, comment or discussion.
+type Reaction struct {
+	ID        int64  `json:"id,omitempty"`
+	Content   string `json:"content"`
+	User      string `json:"user,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+func newReaction(reaction *github.Reaction) Reaction {
+	r := Reaction{
+		ID:      reaction.GetID(),
+		Content: reaction.GetContent(),
+		User:    reaction.GetUser().GetLogin(),
+	}
+	if reaction.CreatedAt != nil {
+		r.CreatedAt = reaction.CreatedAt.Format(time.RFC3339)
+	}
+	return r
+}
+
+// ReactionSummary holds the number of reactions of every kind on a subject.
+type ReactionSummary struct {
+	TotalCount int            `json:"total_count"`
+	Counts     map[string]int `json:"counts"`
+}
+
+func newReactionSummary(reactions *github.Reactions) ReactionSummary {
+	return ReactionSummary{
+		TotalCount: reactions.GetTotalCount(),
+		Counts: map[string]int{
+			"+1":       reactions.GetPlusOne(),
+			"-1":       reactions.GetMinusOne(),
+			"laugh":    reactions.GetLaugh(),
+			"hooray":   reactions.GetHooray(),
+			"confused": reactions.GetConfused(),
+			"heart":    reactions.GetHeart(),
+			"rocket":   reactions.GetRocket(),
+			"eyes":     reactions.GetEyes(),
+		},
+	}
+}
+
+// reactionSubject identifies what is being reacted to.
+type reactionSubject struct {
+	kind      string
+	owner     string
+	repo      string
+	number    int
+	commentID int64
+}
+
+func (s reactionSubject) String() string {
+	switch s.kind {
+	case ReactionSubjectIssueComment:
+		return fmt.Sprintf("issue comment %d", s.commentID)
+	case ReactionSubjectPullRequestReviewComment:
+		return fmt.Sprintf("pull request review comment %d", s.commentID)
+	case ReactionSubjectDiscussion:
+		return fmt.Sprintf("discussion #%d", s.number)
+	default:
+		return fmt.Sprintf("issue #%d", s.number)
+	}
+}
+
+// withReactionSubject adds the parameters identifying the subject of a reaction to a tool.
+func withReactionSubject() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		)(tool)
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		)(tool)
+		mcp.WithString("subject_type",
+			mcp.Required(),
+			mcp.Description("What the reactions are on. Use issue for both issues and pull requests"),
+			mcp.Enum(ReactionSubjectIssue, ReactionSubjectIssueComment, ReactionSubjectPullRequestReviewComment, ReactionSubjectDiscussion),
+		)(tool)
+		mcp.WithNumber("number",
+			mcp.Description("Issue, pull request or discussion number, required for the issue and discussion subject types"),
+		)(tool)
+		mcp.WithNumber("comment_id",
+			mcp.Description("Comment ID, required for the issue_comment and pull_request_review_comment subject types"),
+		)(tool)
+	}
+}
+
+// reactionSubjectParams reads the parameters added by withReactionSubject.
+func reactionSubjectParams(request mcp.CallToolRequest) (reactionSubject, error) {
+	var subject reactionSubject
+	var err error
+	if subject.owner, err = requiredParam[string](request, "owner"); err != nil {
+		return subject, err
+	}
+	if subject.repo, err = requiredParam[string](request, "repo"); err != nil {
+		return subject, err
+	}
+	if subject.kind, err = requiredParam[string](request, "subject_type"); err != nil {
+		return subject, err
+	}
+
+	switch subject.kind {
+	case ReactionSubjectIssue, ReactionSubjectDiscussion:
+		if subject.number, err = RequiredInt(request, "number"); err != nil {
+			return subject, err
+		}
+	case ReactionSubjectIssueComment, ReactionSubjectPullRequestReviewComment:
+		commentID, err := RequiredInt(request, "comment_id")
+		if err != nil {
+			return subject, err
+		}
+		subject.commentID = int64(commentID)
+	default:
+		return subject, fmt.Errorf("unknown subject_type %q", subject.kind)
+	}
+	return subject, nil
+}
+
+// restReactionSubject wraps the REST endpoints for the reactions on one type of subject.
+type restReactionSubject struct {
+	list   func(context.Context, *github.Client, reactionSubject, *github.ListReactionOptions) ([]*github.Reaction, *github.Response, error)
+	create func(context.Context, *github.Client, reactionSubject, string) (*github.Reaction, *github.Response, error)
+	delete func(context.Context, *github.Client, reactionSubject, int64) (*github.Response, error)
+	// rollup fetches the subject itself, for the reaction counts it carries.
+	rollup func(context.Context, *github.Client, reactionSubject) (*github.Reactions, *github.Response, error)
+}
+
+var restReactionSubjects = map[string]restReactionSubject{
+	ReactionSubjectIssue: {
+		list: func(ctx context.Context, client *github.Client, s reactionSubject, opts *github.ListReactionOptions) ([]*github.Reaction, *github.Response, error) {
+			return client.Reactions.ListIssueReactions(ctx, s.owner, s.repo, s.number, opts)
+		},
+		create: func(ctx context.Context, client *github.Client, s reactionSubject, content string) (*github.Reaction, *github.Response, error) {
+			return client.Reactions.CreateIssueReaction(ctx, s.owner, s.repo, s.number, content)
+		},
+		delete: func(ctx context.Context, client *github.Client, s reactionSubject, id int64) (*github.Response, error) {
+			return client.Reactions.DeleteIssueReaction(ctx, s.owner, s.repo, s.number, id)
+		},
+		rollup: func(ctx context.Context, client *github.Client, s reactionSubject) (*github.Reactions, *github.Response, error) {
+			issue, resp, err := client.Issues.Get(ctx, s.owner, s.repo, s.number)
+			return issue.GetReactions(), resp, err
+		},
+	},
+	ReactionSubjectIssueComment: {
+		list: func(ctx context.Context, client *github.Client, s reactionSubject, opts *github.ListReactionOptions) ([]*github.Reaction, *github.Response, error) {
+			return client.Reactions.ListIssueCommentReactions(ctx, s.owner, s.repo, s.commentID, opts)
+		},
+		create: func(ctx context.Context, client *github.Client, s reactionSubject, content string) (*github.Reaction, *github.Response, error) {
+			return client.Reactions.CreateIssueCommentReaction(ctx, s.owner, s.repo, s.commentID, content)
+		},
+		delete: func(ctx context.Context, client *github.Client, s reactionSubject, id int64) (*github.Response, error) {
+			return client.Reactions.DeleteIssueCommentReaction(ctx, s.owner, s.repo, s.commentID, id)
+		},
+		rollup: func(ctx context.Context, client *github.Client, s reactionSubject) (*github.Reactions, *github.Response, error) {
+			comment, resp, err := client.Issues.GetComment(ctx, s.owner, s.repo, s.commentID)
+			return comment.GetReactions(), resp, err
+		},
+	},
+	ReactionSubjectPullRequestReviewComment: {
+		list: func(ctx context.Context, client *github.Client, s reactionSubject, opts *github.ListReactionOptions) ([]*github.Reaction, *github.Response, error) {
+			return client.Reactions.ListPullRequestCommentReactions(ctx, s.owner, s.repo, s.commentID, opts)
+		},
+		create: func(ctx context.Context, client *github.Client, s reactionSubject, content string) (*github.Reaction, *github.Response, error) {
+			return client.Reactions.CreatePullRequestCommentReaction(ctx, s.owner, s.repo, s.commentID, content)
+		},
+		delete: func(ctx context.Context, client *github.Client, s reactionSubject, id int64) (*github.Response, error) {
+			return client.Reactions.DeletePullRequestCommentReaction(ctx, s.owner, s.repo, s.commentID, id)
+		},
+		rollup: func(ctx context.Context, client *github.Client, s reactionSubject) (*github.Reactions, *github.Response, error) {
+			comment, resp, err := client.PullRequests.GetComment(ctx, s.owner, s.repo, s.commentID)
+			return comment.GetReactions(), resp, err
+		},
+	},
+}
+
+// reactionResponseError turns an unexpected REST response into a tool error.
+func reactionResponseError(prefix string, resp *github.Response) (*mcp.CallToolResult, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("%s: %s", prefix, string(body))), nil
+}
+
+type discussionReactionNode struct {
+	DatabaseID int64 `graphql:"databaseId"`
+	Content    githubv4.ReactionContent
+	CreatedAt  githubv4.DateTime
+	User       struct {
+		Login githubv4.String
+	}
+}
+
+type discussionReactionsQuery struct {
+	Repository struct {
+		Discussion struct {
+			ReactionGroups []struct {
+				Content  githubv4.ReactionContent
+				Reactors struct {
+					TotalCount int
+				}
+			}
+			Reactions struct {
+				TotalCount int
+				Nodes      []discussionReactionNode
+				PageInfo   struct {
+					HasNextPage bool
+					EndCursor   githubv4.String
+				}
+			} `graphql:"reactions(first: $first, after: $after, content: $content)"`
+		} `graphql:"discussion(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+type discussionIDQuery struct {
+	Repository struct {
+		Discussion struct {
+			ID githubv4.ID
+		} `graphql:"discussion(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+func getDiscussionNodeID(ctx context.Context, client *githubv4.Client, s reactionSubject) (githubv4.ID, error) {
+	var query discussionIDQuery
+	vars := map[string]any{
+		"owner":  githubv4.String(s.owner),
+		"name":   githubv4.String(s.repo),
+		"number": githubv4.Int(int32(s.number)), // #nosec G115 - discussion numbers are always small positive integers
+	}
+	if err := client.Query(ctx, &query, vars); err != nil {
+		return nil, err
+	}
+	return query.Repository.Discussion.ID, nil
+}
+
+// ListReactions creates a tool to list the reactions on an issue, pull request, comment or discussion.
+func ListReactions(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_reactions",
+			mcp.WithDescription(t("TOOL_LIST_REACTIONS_DESCRIPTION", "List the reactions on an issue, pull request, issue comment, pull request review comment or discussion, along with the number of reactions of each kind.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REACTIONS_USER_TITLE", "List reactions"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			withReactionSubject(),
+			mcp.WithString("content",
+				mcp.Description("Only list reactions of this kind"),
+				mcp.Enum(reactionContents...),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			subject, err := reactionSubjectParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := OptionalParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			after, err := OptionalParam[string](request, "after")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if subject.kind == ReactionSubjectDiscussion {
+				if pagination.page > 1 {
+					return mcp.NewToolResultError("page is not supported for discussions, use after instead"), nil
+				}
+
+				client, err := getGQLClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+				}
+
+				var contentFilter *githubv4.ReactionContent
+				if content != "" {
+					c := graphQLReactionContents[content]
+					contentFilter = &c
+				}
+				var afterCursor *githubv4.String
+				if after != "" {
+					afterCursor = githubv4.NewString(githubv4.String(after))
+				}
+
+				var query discussionReactionsQuery
+				vars := map[string]any{
+					"owner":   githubv4.String(subject.owner),
+					"name":    githubv4.String(subject.repo),
+					"number":  githubv4.Int(int32(subject.number)),     // #nosec G115 - discussion numbers are always small positive integers
+					"first":   githubv4.Int(int32(pagination.perPage)), // #nosec G115 - perPage is bounded to 100
+					"after":   afterCursor,
+					"content": contentFilter,
+				}
+				if err := client.Query(ctx, &query, vars); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list reactions: %v", err)), nil
+				}
+
+				discussion := query.Repository.Discussion
+				reportPageInfo(ctx, discussion.Reactions.PageInfo.HasNextPage, string(discussion.Reactions.PageInfo.EndCursor))
+				summary := ReactionSummary{Counts: make(map[string]int, len(reactionContents))}
+				for _, name := range reactionContents {
+					summary.Counts[name] = 0
+				}
+				for _, group := range discussion.ReactionGroups {
+					summary.Counts[restReactionContent(group.Content)] = group.Reactors.TotalCount
+					summary.TotalCount += group.Reactors.TotalCount
+				}
+				reactions := make([]Reaction, 0, len(discussion.Reactions.Nodes))
+				for _, node := range discussion.Reactions.Nodes {
+					reactions = append(reactions, Reaction{
+						ID:        node.DatabaseID,
+						Content:   restReactionContent(node.Content),
+						User:      string(node.User.Login),
+						CreatedAt: node.CreatedAt.Format(time.RFC3339),
+					})
+				}
+
+				return MarshalledTextResult(map[string]any{
+					"summary":   summary,
+					"reactions": reactions,
+					"page_info": map[string]any{
+						"has_next_page": discussion.Reactions.PageInfo.HasNextPage,
+						"end_cursor":    string(discussion.Reactions.PageInfo.EndCursor),
+					},
+				}), nil
+			}
+
+			if after != "" {
+				return mcp.NewToolResultError("after is only supported for discussions, use page instead"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			endpoints := restReactionSubjects[subject.kind]
+
+			rollup, rollupResp, err := endpoints.rollup(ctx, client, subject)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get %s: %w", subject, err)
+			}
+			defer func() { _ = rollupResp.Body.Close() }()
+			if rollupResp.StatusCode != http.StatusOK {
+				return reactionResponseError(fmt.Sprintf("failed to get %s", subject), rollupResp)
+			}
+
+			opts := &github.ListReactionOptions{
+				Content: content,
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+			list, listResp, err := endpoints.list(ctx, client, subject, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list reactions: %w", err)
+			}
+			defer func() { _ = listResp.Body.Close() }()
+			reportNextPage(ctx, listResp)
+			if listResp.StatusCode != http.StatusOK {
+				return reactionResponseError("failed to list reactions", listResp)
+			}
+
+			reactions := make([]Reaction, 0, len(list))
+			for _, reaction := range list {
+				reactions = append(reactions, newReaction(reaction))
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"summary":   newReactionSummary(rollup),
+				"reactions": reactions,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal reactions: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// AddReaction creates a tool to react to an issue, pull request, comment or discussion as the authenticated user.
+func AddReaction(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_reaction",
+			mcp.WithDescription(t("TOOL_ADD_REACTION_DESCRIPTION", "Add a reaction as the authenticated user to an issue, pull request, issue comment, pull request review comment or discussion. Adding a reaction the user already added has no effect.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_ADD_REACTION_USER_TITLE", "Add reaction"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			withReactionSubject(),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("The reaction to add"),
+				mcp.Enum(reactionContents...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			subject, err := reactionSubjectParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := requiredParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			graphQLContent, ok := graphQLReactionContents[content]
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("unknown reaction %q", content)), nil
+			}
+
+			if subject.kind == ReactionSubjectDiscussion {
+				client, err := getGQLClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+				}
+				subjectID, err := getDiscussionNodeID(ctx, client, subject)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get %s: %v", subject, err)), nil
+				}
+
+				var mutation struct {
+					AddReaction struct {
+						Reaction struct {
+							DatabaseID int64 `graphql:"databaseId"`
+							Content    githubv4.ReactionContent
+						}
+					} `graphql:"addReaction(input: $input)"`
+				}
+				input := githubv4.AddReactionInput{SubjectID: subjectID, Content: graphQLContent}
+				if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to add reaction: %v", err)), nil
+				}
+
+				return MarshalledTextResult(Reaction{
+					ID:      mutation.AddReaction.Reaction.DatabaseID,
+					Content: restReactionContent(mutation.AddReaction.Reaction.Content),
+				}), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			reaction, resp, err := restReactionSubjects[subject.kind].create(ctx, client, subject, content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to add reaction: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// The API answers 200 rather than 201 when the user already reacted this way.
+			if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+				return reactionResponseError("failed to add reaction", resp)
+			}
+
+			return MarshalledTextResult(newReaction(reaction)), nil
+		}
+}
+
+// RemoveReaction creates a tool to remove a reaction of the authenticated user from an issue, pull request, comment or discussion.
+func RemoveReaction(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_reaction",
+			mcp.WithDescription(t("TOOL_REMOVE_REACTION_DESCRIPTION", "Remove a reaction the authenticated user added to an issue, pull request, issue comment, pull request review comment or discussion.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REMOVE_REACTION_USER_TITLE", "Remove reaction"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			withReactionSubject(),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("The reaction to remove"),
+				mcp.Enum(reactionContents...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			subject, err := reactionSubjectParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := requiredParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			graphQLContent, ok := graphQLReactionContents[content]
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("unknown reaction %q", content)), nil
+			}
+
+			if subject.kind == ReactionSubjectDiscussion {
+				client, err := getGQLClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+				}
+				subjectID, err := getDiscussionNodeID(ctx, client, subject)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get %s: %v", subject, err)), nil
+				}
+
+				var mutation struct {
+					RemoveReaction struct {
+						Reaction struct {
+							Content githubv4.ReactionContent
+						}
+					} `graphql:"removeReaction(input: $input)"`
+				}
+				input := githubv4.RemoveReactionInput{SubjectID: subjectID, Content: graphQLContent}
+				if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to remove reaction: %v", err)), nil
+				}
+
+				return mcp.NewToolResultText(fmt.Sprintf("removed %s reaction from %s", content, subject)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			endpoints := restReactionSubjects[subject.kind]
+
+			// The REST API deletes reactions by ID, so find the one the authenticated user added.
+			user, userResp, err := client.Users.Get(ctx, "")
+			if err != nil {
+				return nil, fmt.Errorf("failed to get authenticated user: %w", err)
+			}
+			_ = userResp.Body.Close()
+
+			progress := newProgressReporter(ctx, request, 0)
+
+			var reactionID int64
+			opts := &github.ListReactionOptions{Content: content, ListOptions: github.ListOptions{PerPage: 100}}
+			for reactionID == 0 {
+				reactions, resp, err := endpoints.list(ctx, client, subject, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list reactions: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, reaction := range reactions {
+					if reaction.GetUser().GetLogin() == user.GetLogin() {
+						reactionID = reaction.GetID()
+						break
+					}
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				progress.Step(fmt.Sprintf("Looked through %d %s reactions", len(reactions), content))
+				opts.Page = resp.NextPage
+			}
+			if reactionID == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("%s has no %s reaction by %s", subject, content, user.GetLogin())), nil
+			}
+
+			deleteResp, err := endpoints.delete(ctx, client, subject, reactionID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to remove reaction: %w", err)
+			}
+			defer func() { _ = deleteResp.Body.Close() }()
+			if deleteResp.StatusCode != http.StatusNoContent {
+				return reactionResponseError("failed to remove reaction", deleteResp)
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("removed %s reaction from %s", content, subject)), nil
+		}
+}
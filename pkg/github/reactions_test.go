@@ -0,0 +1,486 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func discussionIDMatcher(number int, id string) githubv4mock.Matcher {
+	return githubv4mock.NewQueryMatcher(
+		discussionIDQuery{},
+		map[string]any{
+			"owner":  githubv4.String("owner"),
+			"name":   githubv4.String("repo"),
+			"number": githubv4.Int(number),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussion": map[string]any{"id": id},
+			},
+		}),
+	)
+}
+
+func Test_ListReactions(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := ListReactions(stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_reactions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "subject_type")
+	assert.Contains(t, tool.InputSchema.Properties, "number")
+	assert.Contains(t, tool.InputSchema.Properties, "comment_id")
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type"})
+
+	mockReactions := []*github.Reaction{
+		{ID: github.Ptr(int64(1)), Content: github.Ptr("+1"), User: &github.User{Login: github.Ptr("octocat")}},
+		{ID: github.Ptr(int64(2)), Content: github.Ptr("+1"), User: &github.User{Login: github.Ptr("hubot")}},
+	}
+	expectedCounts := map[string]int{"+1": 2, "-1": 0, "laugh": 0, "hooray": 0, "confused": 0, "heart": 1, "rocket": 0, "eyes": 0}
+
+	tests := []struct {
+		name              string
+		restClient        *http.Client
+		gqlClient         *http.Client
+		requestArgs       map[string]any
+		expectToolError   bool
+		expectedErrMsg    string
+		expectedSummary   ReactionSummary
+		expectedReactions []Reaction
+		expectedPageInfo  map[string]any
+	}{
+		{
+			name: "issue reactions filtered by content",
+			restClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					&github.Issue{
+						Number:    github.Ptr(42),
+						Reactions: &github.Reactions{TotalCount: github.Ptr(3), PlusOne: github.Ptr(2), Heart: github.Ptr(1)},
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					expectQueryParams(t, map[string]string{
+						"content":  "+1",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockReactions),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"number":       float64(42),
+				"content":      "+1",
+			},
+			expectedSummary: ReactionSummary{TotalCount: 3, Counts: expectedCounts},
+			expectedReactions: []Reaction{
+				{ID: 1, Content: "+1", User: "octocat"},
+				{ID: 2, Content: "+1", User: "hubot"},
+			},
+		},
+		{
+			name: "pull request review comment reactions",
+			restClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsCommentsByOwnerByRepoByCommentId,
+					&github.PullRequestComment{
+						ID:        github.Ptr(int64(99)),
+						Reactions: &github.Reactions{TotalCount: github.Ptr(3), PlusOne: github.Ptr(2), Heart: github.Ptr(1)},
+					},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsCommentsReactionsByOwnerByRepoByCommentId,
+					mockReactions,
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "pull_request_review_comment",
+				"comment_id":   float64(99),
+			},
+			expectedSummary: ReactionSummary{TotalCount: 3, Counts: expectedCounts},
+			expectedReactions: []Reaction{
+				{ID: 1, Content: "+1", User: "octocat"},
+				{ID: 2, Content: "+1", User: "hubot"},
+			},
+		},
+		{
+			name: "discussion reactions",
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					discussionReactionsQuery{},
+					map[string]any{
+						"owner":   githubv4.String("owner"),
+						"name":    githubv4.String("repo"),
+						"number":  githubv4.Int(7),
+						"first":   githubv4.Int(30),
+						"after":   (*githubv4.String)(nil),
+						"content": (*githubv4.ReactionContent)(nil),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"repository": map[string]any{
+							"discussion": map[string]any{
+								"reactionGroups": []any{
+									map[string]any{"content": "THUMBS_UP", "reactors": map[string]any{"totalCount": 2}},
+									map[string]any{"content": "HEART", "reactors": map[string]any{"totalCount": 1}},
+								},
+								"reactions": map[string]any{
+									"totalCount": 3,
+									"nodes": []any{
+										map[string]any{"databaseId": 1, "content": "THUMBS_UP", "createdAt": "2025-01-01T00:00:00Z", "user": map[string]any{"login": "octocat"}},
+									},
+									"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "Y3Vyc29y"},
+								},
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "discussion",
+				"number":       float64(7),
+			},
+			expectedSummary: ReactionSummary{TotalCount: 3, Counts: expectedCounts},
+			expectedReactions: []Reaction{
+				{ID: 1, Content: "+1", User: "octocat", CreatedAt: "2025-01-01T00:00:00Z"},
+			},
+			expectedPageInfo: map[string]any{"has_next_page": true, "end_cursor": "Y3Vyc29y"},
+		},
+		{
+			name: "comment subject without comment_id",
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue_comment",
+				"number":       float64(42),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: comment_id",
+		},
+		{
+			name: "page is not supported for discussions",
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "discussion",
+				"number":       float64(7),
+				"page":         float64(2),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "page is not supported for discussions",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			restClient := github.NewClient(tc.restClient)
+			gqlClient := githubv4.NewClient(tc.gqlClient)
+			_, handler := ListReactions(stubGetClientFn(restClient), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var response struct {
+				Summary   ReactionSummary `json:"summary"`
+				Reactions []Reaction      `json:"reactions"`
+				PageInfo  map[string]any  `json:"page_info"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedSummary, response.Summary)
+			assert.Equal(t, tc.expectedReactions, response.Reactions)
+			assert.Equal(t, tc.expectedPageInfo, response.PageInfo)
+		})
+	}
+}
+
+func Test_AddReaction(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := AddReaction(stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_reaction", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type", "content"})
+
+	tests := []struct {
+		name             string
+		restClient       *http.Client
+		gqlClient        *http.Client
+		requestArgs      map[string]any
+		expectToolError  bool
+		expectedErrMsg   string
+		expectedReaction Reaction
+	}{
+		{
+			name: "react to an issue comment",
+			restClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsReactionsByOwnerByRepoByCommentId,
+					expectRequestBody(t, map[string]any{"content": "rocket"}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reaction{
+							ID:      github.Ptr(int64(5)),
+							Content: github.Ptr("rocket"),
+							User:    &github.User{Login: github.Ptr("octocat")},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue_comment",
+				"comment_id":   float64(123),
+				"content":      "rocket",
+			},
+			expectedReaction: Reaction{ID: 5, Content: "rocket", User: "octocat"},
+		},
+		{
+			name: "reaction already present",
+			restClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusOK, &github.Reaction{
+						ID:      github.Ptr(int64(6)),
+						Content: github.Ptr("+1"),
+						User:    &github.User{Login: github.Ptr("octocat")},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"number":       float64(42),
+				"content":      "+1",
+			},
+			expectedReaction: Reaction{ID: 6, Content: "+1", User: "octocat"},
+		},
+		{
+			name: "react to a discussion",
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				discussionIDMatcher(7, "D_discussion"),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						AddReaction struct {
+							Reaction struct {
+								DatabaseID int64 `graphql:"databaseId"`
+								Content    githubv4.ReactionContent
+							}
+						} `graphql:"addReaction(input: $input)"`
+					}{},
+					githubv4.AddReactionInput{SubjectID: githubv4.ID("D_discussion"), Content: githubv4.ReactionContentHeart},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"addReaction": map[string]any{
+							"reaction": map[string]any{"databaseId": 8, "content": "HEART"},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "discussion",
+				"number":       float64(7),
+				"content":      "heart",
+			},
+			expectedReaction: Reaction{ID: 8, Content: "heart"},
+		},
+		{
+			name: "reaction fails",
+			restClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusGone)
+						_, _ = w.Write([]byte(`{"message": "Issue is locked"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"number":       float64(42),
+				"content":      "+1",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "Issue is locked",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			restClient := github.NewClient(tc.restClient)
+			gqlClient := githubv4.NewClient(tc.gqlClient)
+			_, handler := AddReaction(stubGetClientFn(restClient), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectToolError {
+				if err != nil {
+					assert.Contains(t, err.Error(), tc.expectedErrMsg)
+					return
+				}
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			require.False(t, result.IsError, textContent.Text)
+
+			var reaction Reaction
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &reaction))
+			assert.Equal(t, tc.expectedReaction, reaction)
+		})
+	}
+}
+
+func Test_RemoveReaction(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := RemoveReaction(stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+	assert.Equal(t, "remove_reaction", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type", "content"})
+
+	me := &github.User{Login: github.Ptr("octocat")}
+
+	tests := []struct {
+		name            string
+		restClient      *http.Client
+		gqlClient       *http.Client
+		requestArgs     map[string]any
+		expectToolError bool
+		expectedText    string
+	}{
+		{
+			name: "remove own reaction from an issue",
+			restClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUser, me),
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					expectQueryParams(t, map[string]string{
+						"content":  "+1",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Reaction{
+							{ID: github.Ptr(int64(1)), Content: github.Ptr("+1"), User: &github.User{Login: github.Ptr("hubot")}},
+							{ID: github.Ptr(int64(2)), Content: github.Ptr("+1"), User: me},
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesReactionsByOwnerByRepoByIssueNumberByReactionId,
+					expectPath(t, "/repos/owner/repo/issues/42/reactions/2").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"number":       float64(42),
+				"content":      "+1",
+			},
+			expectedText: "removed +1 reaction from issue #42",
+		},
+		{
+			name: "no reaction of the user",
+			restClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUser, me),
+				mock.WithRequestMatch(
+					mock.GetReposPullsCommentsReactionsByOwnerByRepoByCommentId,
+					[]*github.Reaction{
+						{ID: github.Ptr(int64(1)), Content: github.Ptr("eyes"), User: &github.User{Login: github.Ptr("hubot")}},
+					},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "pull_request_review_comment",
+				"comment_id":   float64(99),
+				"content":      "eyes",
+			},
+			expectToolError: true,
+			expectedText:    "pull request review comment 99 has no eyes reaction by octocat",
+		},
+		{
+			name: "remove reaction from a discussion",
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				discussionIDMatcher(7, "D_discussion"),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						RemoveReaction struct {
+							Reaction struct {
+								Content githubv4.ReactionContent
+							}
+						} `graphql:"removeReaction(input: $input)"`
+					}{},
+					githubv4.RemoveReactionInput{SubjectID: githubv4.ID("D_discussion"), Content: githubv4.ReactionContentThumbsDown},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"removeReaction": map[string]any{
+							"reaction": map[string]any{"content": "THUMBS_DOWN"},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "discussion",
+				"number":       float64(7),
+				"content":      "-1",
+			},
+			expectedText: "removed -1 reaction from discussion #7",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			restClient := github.NewClient(tc.restClient)
+			gqlClient := githubv4.NewClient(tc.gqlClient)
+			_, handler := RemoveReaction(stubGetClientFn(restClient), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectToolError, result.IsError)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
@@ -6,17 +6,24 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/go-github/v72/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
 )
 
 func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_commit",
 			mcp.WithDescription(t("TOOL_GET_COMMITS_DESCRIPTION", "Get details for a commit from a GitHub repository")),
+			mcp.WithOutputSchema[MinimalCommit](),
+			WithFieldSelection(),
+			WithOutputFormat(),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_COMMITS_USER_TITLE", "Get commit details"),
 				ReadOnlyHint: toBoolPtr(true),
@@ -67,6 +74,7 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return nil, fmt.Errorf("failed to get commit: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
 
 			if resp.StatusCode != 200 {
 				body, err := io.ReadAll(resp.Body)
@@ -76,128 +84,13 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get commit: %s", string(body))), nil
 			}
 
-			// Create simplified author and committer structure
-			type SimplifiedUser struct {
-				Login     string `json:"login,omitempty"`
-				AvatarURL string `json:"avatar_url,omitempty"`
-				HTMLURL   string `json:"html_url,omitempty"`
-			}
-
-			type SimplifiedCommitDetails struct {
-				Author struct {
-					Name  string `json:"name,omitempty"`
-					Email string `json:"email,omitempty"`
-					Date  string `json:"date,omitempty"`
-				} `json:"author,omitempty"`
-				Committer struct {
-					Name  string `json:"name,omitempty"`
-					Email string `json:"email,omitempty"`
-					Date  string `json:"date,omitempty"`
-				} `json:"committer,omitempty"`
-				Message string `json:"message,omitempty"`
-			}
-
-			type SimplifiedFile struct {
-				SHA       string `json:"sha,omitempty"`
-				Filename  string `json:"filename,omitempty"`
-				Status    string `json:"status,omitempty"`
-				Additions int    `json:"additions"`
-				Deletions int    `json:"deletions"`
-				Changes   int    `json:"changes"`
-			}
-
-			type SimplifiedCommit struct {
-				SHA       string                  `json:"sha,omitempty"`
-				NodeID    string                  `json:"node_id,omitempty"`
-				HTMLURL   string                  `json:"html_url,omitempty"`
-				Author    *SimplifiedUser         `json:"author,omitempty"`
-				Committer *SimplifiedUser         `json:"committer,omitempty"`
-				Commit    SimplifiedCommitDetails `json:"commit,omitempty"`
-				Files     []SimplifiedFile        `json:"files,omitempty"`
-				Stats     struct {
-					Additions int `json:"additions"`
-					Deletions int `json:"deletions"`
-					Total     int `json:"total"`
-				} `json:"stats,omitempty"`
-			}
-
-			// Create a simplified commit
-			commitDetails := SimplifiedCommitDetails{}
-			if commit.Commit != nil {
-				if commit.Commit.Author != nil {
-					commitDetails.Author.Name = commit.Commit.Author.GetName()
-					commitDetails.Author.Email = commit.Commit.Author.GetEmail()
-					if commit.Commit.Author.Date != nil {
-						commitDetails.Author.Date = commit.Commit.Author.Date.Format(time.RFC3339)
-					}
-				}
-				if commit.Commit.Committer != nil {
-					commitDetails.Committer.Name = commit.Commit.Committer.GetName()
-					commitDetails.Committer.Email = commit.Commit.Committer.GetEmail()
-					if commit.Commit.Committer.Date != nil {
-						commitDetails.Committer.Date = commit.Commit.Committer.Date.Format(time.RFC3339)
-					}
-				}
-				commitDetails.Message = commit.Commit.GetMessage()
-			}
-
-			// Process author
-			var author *SimplifiedUser
-			if commit.Author != nil {
-				author = &SimplifiedUser{
-					Login:     commit.Author.GetLogin(),
-					AvatarURL: commit.Author.GetAvatarURL(),
-					HTMLURL:   commit.Author.GetHTMLURL(),
-				}
-			}
-
-			// Process committer
-			var committer *SimplifiedUser
-			if commit.Committer != nil {
-				committer = &SimplifiedUser{
-					Login:     commit.Committer.GetLogin(),
-					AvatarURL: commit.Committer.GetAvatarURL(),
-					HTMLURL:   commit.Committer.GetHTMLURL(),
-				}
-			}
-
-			// Process files
-			files := make([]SimplifiedFile, 0, len(commit.Files))
-			for _, file := range commit.Files {
-				files = append(files, SimplifiedFile{
-					SHA:       file.GetSHA(),
-					Filename:  file.GetFilename(),
-					Status:    file.GetStatus(),
-					Additions: file.GetAdditions(),
-					Deletions: file.GetDeletions(),
-					Changes:   file.GetChanges(),
-				})
-			}
-
-			// Create simplified commit
-			simplifiedCommit := SimplifiedCommit{
-				SHA:       commit.GetSHA(),
-				NodeID:    commit.GetNodeID(),
-				HTMLURL:   commit.GetHTMLURL(),
-				Author:    author,
-				Committer: committer,
-				Commit:    commitDetails,
-				Files:     files,
-			}
-
-			// Add stats if available
-			if commit.Stats != nil {
-				simplifiedCommit.Stats.Additions = commit.Stats.GetAdditions()
-				simplifiedCommit.Stats.Deletions = commit.Stats.GetDeletions()
-				simplifiedCommit.Stats.Total = commit.Stats.GetTotal()
-			}
-
-			r, err := json.Marshal(simplifiedCommit)
+			minimalCommit := newMinimalCommit(commit)
+			r, err := json.Marshal(minimalCommit)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal simplified commit: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return mcp.NewToolResultStructured(minimalCommit, string(r)), nil
 		}
 }
 
@@ -205,6 +98,9 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_commits",
 			mcp.WithDescription(t("TOOL_LIST_COMMITS_DESCRIPTION", "Get list of commits of a branch in a GitHub repository")),
+			mcp.WithOutputSchema[MinimalList[MinimalCommit]](),
+			WithFieldSelection(),
+			WithOutputFormat(),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_COMMITS_USER_TITLE", "List commits"),
 				ReadOnlyHint: toBoolPtr(true),
@@ -220,6 +116,18 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.WithString("sha",
 				mcp.Description("SHA or Branch name"),
 			),
+			mcp.WithString("path",
+				mcp.Description("Only commits containing this file path"),
+			),
+			mcp.WithString("author",
+				mcp.Description("Only commits by this GitHub login or email address"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only commits after this date (ISO 8601 timestamp)"),
+			),
+			mcp.WithString("until",
+				mcp.Description("Only commits before this date (ISO 8601 timestamp)"),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -235,18 +143,50 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			path, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			author, err := OptionalParam[string](request, "author")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			since, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			until, err := OptionalParam[string](request, "until")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			opts := &github.CommitsListOptions{
-				SHA: sha,
+				SHA:    sha,
+				Path:   path,
+				Author: author,
 				ListOptions: github.ListOptions{
 					Page:    pagination.page,
 					PerPage: pagination.perPage,
 				},
 			}
+			if since != "" {
+				timestamp, err := parseISOTimestamp(since)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list commits: %s", err.Error())), nil
+				}
+				opts.Since = timestamp
+			}
+			if until != "" {
+				timestamp, err := parseISOTimestamp(until)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list commits: %s", err.Error())), nil
+				}
+				opts.Until = timestamp
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -257,6 +197,7 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return nil, fmt.Errorf("failed to list commits: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
 
 			if resp.StatusCode != 200 {
 				body, err := io.ReadAll(resp.Body)
@@ -266,97 +207,17 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list commits: %s", string(body))), nil
 			}
 
-			// Create simplified commits response
-			type SimplifiedUser struct {
-				Login     string `json:"login,omitempty"`
-				AvatarURL string `json:"avatar_url,omitempty"`
-				HTMLURL   string `json:"html_url,omitempty"`
-			}
-
-			type SimplifiedCommitDetails struct {
-				Author struct {
-					Name  string `json:"name,omitempty"`
-					Email string `json:"email,omitempty"`
-					Date  string `json:"date,omitempty"`
-				} `json:"author,omitempty"`
-				Committer struct {
-					Name  string `json:"name,omitempty"`
-					Email string `json:"email,omitempty"`
-					Date  string `json:"date,omitempty"`
-				} `json:"committer,omitempty"`
-				Message string `json:"message,omitempty"`
-			}
-
-			type SimplifiedCommitEntry struct {
-				SHA       string                  `json:"sha,omitempty"`
-				NodeID    string                  `json:"node_id,omitempty"`
-				HTMLURL   string                  `json:"html_url,omitempty"`
-				Author    *SimplifiedUser         `json:"author,omitempty"`
-				Committer *SimplifiedUser         `json:"committer,omitempty"`
-				Commit    SimplifiedCommitDetails `json:"commit,omitempty"`
-			}
-
-			// Create simplified commits list
-			simplifiedCommits := make([]SimplifiedCommitEntry, 0, len(commits))
-
+			minimalCommits := make([]MinimalCommit, 0, len(commits))
 			for _, commit := range commits {
-				// Create commit details
-				commitDetails := SimplifiedCommitDetails{}
-				if commit.Commit != nil {
-					if commit.Commit.Author != nil {
-						commitDetails.Author.Name = commit.Commit.Author.GetName()
-						commitDetails.Author.Email = commit.Commit.Author.GetEmail()
-						if commit.Commit.Author.Date != nil {
-							commitDetails.Author.Date = commit.Commit.Author.Date.Format(time.RFC3339)
-						}
-					}
-					if commit.Commit.Committer != nil {
-						commitDetails.Committer.Name = commit.Commit.Committer.GetName()
-						commitDetails.Committer.Email = commit.Commit.Committer.GetEmail()
-						if commit.Commit.Committer.Date != nil {
-							commitDetails.Committer.Date = commit.Commit.Committer.Date.Format(time.RFC3339)
-						}
-					}
-					commitDetails.Message = commit.Commit.GetMessage()
-				}
-
-				// Create simplified author
-				var author *SimplifiedUser
-				if commit.Author != nil {
-					author = &SimplifiedUser{
-						Login:     commit.Author.GetLogin(),
-						AvatarURL: commit.Author.GetAvatarURL(),
-						HTMLURL:   commit.Author.GetHTMLURL(),
-					}
-				}
-
-				// Create simplified committer
-				var committer *SimplifiedUser
-				if commit.Committer != nil {
-					committer = &SimplifiedUser{
-						Login:     commit.Committer.GetLogin(),
-						AvatarURL: commit.Committer.GetAvatarURL(),
-						HTMLURL:   commit.Committer.GetHTMLURL(),
-					}
-				}
-
-				// Add to list
-				simplifiedCommits = append(simplifiedCommits, SimplifiedCommitEntry{
-					SHA:       commit.GetSHA(),
-					NodeID:    commit.GetNodeID(),
-					HTMLURL:   commit.GetHTMLURL(),
-					Author:    author,
-					Committer: committer,
-					Commit:    commitDetails,
-				})
+				minimalCommits = append(minimalCommits, newMinimalCommit(commit))
 			}
 
-			r, err := json.Marshal(simplifiedCommits)
+			r, err := json.Marshal(minimalCommits)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal simplified commits: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return mcp.NewToolResultStructured(MinimalList[MinimalCommit]{Items: minimalCommits}, string(r)), nil
 		}
 }
 
@@ -409,6 +270,7 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return nil, fmt.Errorf("failed to list branches: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
 
 			if resp.StatusCode != http.StatusOK {
 				body, err := io.ReadAll(resp.Body)
@@ -1322,12 +1184,16 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
+			// Reading the branch, creating the tree and the commit and updating the branch
+			progress := newProgressReporter(ctx, request, 5)
+
 			// Get the reference for the branch
 			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get branch reference: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
+			progress.Step("Got branch " + branch)
 
 			// Get the commit object that the branch points to
 			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
@@ -1335,6 +1201,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return nil, fmt.Errorf("failed to get base commit: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
+			progress.Step("Got base commit " + baseCommit.GetSHA())
 
 			// Create tree entries for all files
 			var entries []*github.TreeEntry
@@ -1370,6 +1237,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return nil, fmt.Errorf("failed to create tree: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
+			progress.Step(fmt.Sprintf("Created tree with %d files", len(entries)))
 
 			// Create a new commit
 			commit := &github.Commit{
@@ -1382,6 +1250,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return nil, fmt.Errorf("failed to create commit: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
+			progress.Step("Created commit " + newCommit.GetSHA())
 
 			// Update the reference to point to the new commit
 			ref.Object.SHA = newCommit.SHA
@@ -1390,6 +1259,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return nil, fmt.Errorf("failed to update reference: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
+			progress.Step("Updated branch " + branch)
 
 			// Create simplified reference structure
 			type SimplifiedReference struct {
@@ -1463,6 +1333,7 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				return nil, fmt.Errorf("failed to list tags: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
 
 			if resp.StatusCode != http.StatusOK {
 				body, err := io.ReadAll(resp.Body)
@@ -1638,3 +1509,1012 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// CompareRefs creates a tool to compare two refs in a GitHub repository.
+func CompareRefs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("compare_refs",
+			mcp.WithDescription(t("TOOL_COMPARE_REFS_DESCRIPTION", "Compare two refs (branches, tags or commit SHAs) in a GitHub repository, returning ahead/behind counts, the commits on head that are not on base, and per-file change stats")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_COMPARE_REFS_USER_TITLE", "Compare refs"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("Base ref to compare from (branch, tag or commit SHA)"),
+			),
+			mcp.WithString("head",
+				mcp.Required(),
+				mcp.Description("Head ref to compare to (branch, tag or commit SHA). Use 'owner:branch' to compare across forks"),
+			),
+			mcp.WithArray("paths",
+				mcp.Description("Only include files matching these glob patterns, e.g. 'pkg/**/*.go'. Patterns without a '/' match file names in any directory"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithBoolean("includeDiff",
+				mcp.Description("Include a unified diff of the (filtered) files in the result"),
+			),
+			mcp.WithNumber("maxDiffLines",
+				mcp.Description("Maximum number of diff lines to return when includeDiff is set (default 500)"),
+				mcp.Min(1),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := requiredParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := requiredParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paths, err := OptionalStringArrayParam(request, "paths")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for _, pattern := range paths {
+				if err := validatePathGlob(pattern); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+			includeDiff, err := OptionalParam[bool](request, "includeDiff")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxDiffLines, err := OptionalIntParamWithDefault(request, "maxDiffLines", 500)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compare refs: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to compare refs: %s", string(body))), nil
+			}
+
+			// Create simplified comparison structure
+			type SimplifiedCommit struct {
+				SHA     string `json:"sha,omitempty"`
+				Message string `json:"message,omitempty"`
+				Author  string `json:"author,omitempty"`
+				Date    string `json:"date,omitempty"`
+				HTMLURL string `json:"html_url,omitempty"`
+			}
+
+			type SimplifiedFile struct {
+				Filename         string `json:"filename,omitempty"`
+				PreviousFilename string `json:"previous_filename,omitempty"`
+				Status           string `json:"status,omitempty"`
+				Additions        int    `json:"additions"`
+				Deletions        int    `json:"deletions"`
+				Changes          int    `json:"changes"`
+			}
+
+			type SimplifiedComparison struct {
+				Status        string             `json:"status,omitempty"`
+				AheadBy       int                `json:"ahead_by"`
+				BehindBy      int                `json:"behind_by"`
+				TotalCommits  int                `json:"total_commits"`
+				MergeBaseSHA  string             `json:"merge_base_sha,omitempty"`
+				HTMLURL       string             `json:"html_url,omitempty"`
+				Commits       []SimplifiedCommit `json:"commits"`
+				Files         []SimplifiedFile   `json:"files"`
+				Additions     int                `json:"additions"`
+				Deletions     int                `json:"deletions"`
+				Diff          string             `json:"diff,omitempty"`
+				DiffTruncated bool               `json:"diff_truncated,omitempty"`
+			}
+
+			simplifiedComparison := SimplifiedComparison{
+				Status:       comparison.GetStatus(),
+				AheadBy:      comparison.GetAheadBy(),
+				BehindBy:     comparison.GetBehindBy(),
+				TotalCommits: comparison.GetTotalCommits(),
+				MergeBaseSHA: comparison.GetMergeBaseCommit().GetSHA(),
+				HTMLURL:      comparison.GetHTMLURL(),
+				Commits:      make([]SimplifiedCommit, 0, len(comparison.Commits)),
+				Files:        make([]SimplifiedFile, 0, len(comparison.Files)),
+			}
+
+			for _, commit := range comparison.Commits {
+				simplifiedCommit := SimplifiedCommit{
+					SHA:     commit.GetSHA(),
+					Message: commit.GetCommit().GetMessage(),
+					Author:  commit.GetCommit().GetAuthor().GetName(),
+					HTMLURL: commit.GetHTMLURL(),
+				}
+				if date := commit.GetCommit().GetAuthor().Date; date != nil {
+					simplifiedCommit.Date = date.Format(time.RFC3339)
+				}
+				if login := commit.GetAuthor().GetLogin(); login != "" {
+					simplifiedCommit.Author = login
+				}
+				simplifiedComparison.Commits = append(simplifiedComparison.Commits, simplifiedCommit)
+			}
+
+			var diff strings.Builder
+			diffLines := 0
+			for _, file := range comparison.Files {
+				if !matchesAnyPathGlob(paths, file.GetFilename()) {
+					continue
+				}
+
+				simplifiedComparison.Files = append(simplifiedComparison.Files, SimplifiedFile{
+					Filename:         file.GetFilename(),
+					PreviousFilename: file.GetPreviousFilename(),
+					Status:           file.GetStatus(),
+					Additions:        file.GetAdditions(),
+					Deletions:        file.GetDeletions(),
+					Changes:          file.GetChanges(),
+				})
+				simplifiedComparison.Additions += file.GetAdditions()
+				simplifiedComparison.Deletions += file.GetDeletions()
+
+				if !includeDiff || simplifiedComparison.DiffTruncated {
+					continue
+				}
+
+				// The compare API only gives us per-file patches, so rebuild the unified diff headers around them.
+				// Binary and very large files have no patch, which matches what the web UI shows.
+				oldName := file.GetFilename()
+				if file.GetPreviousFilename() != "" {
+					oldName = file.GetPreviousFilename()
+				}
+				lines := []string{fmt.Sprintf("diff --git a/%s b/%s", oldName, file.GetFilename())}
+				if file.GetPatch() != "" {
+					lines = append(lines, fmt.Sprintf("--- a/%s", oldName), fmt.Sprintf("+++ b/%s", file.GetFilename()))
+					lines = append(lines, strings.Split(file.GetPatch(), "\n")...)
+				}
+				for _, line := range lines {
+					if diffLines >= maxDiffLines {
+						simplifiedComparison.DiffTruncated = true
+						break
+					}
+					diff.WriteString(line)
+					diff.WriteString("\n")
+					diffLines++
+				}
+			}
+			simplifiedComparison.Diff = diff.String()
+
+			r, err := json.Marshal(simplifiedComparison)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal comparison: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// validatePathGlob checks that a glob pattern accepted by matchesPathGlob is well formed.
+func validatePathGlob(pattern string) error {
+	for _, segment := range strings.Split(pattern, "/") {
+		if segment == "**" {
+			continue
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// matchesAnyPathGlob reports whether name matches any of the patterns. An empty list of patterns matches everything.
+func matchesAnyPathGlob(patterns []string, name string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matchesPathGlob(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPathGlob matches a slash separated path against a glob pattern. In addition to the path.Match syntax,
+// a "**" segment matches zero or more directories, and a pattern without a "/" matches the base name of the path,
+// so "*.go" matches Go files at any depth.
+func matchesPathGlob(pattern, name string) bool {
+	pattern = strings.TrimPrefix(pattern, "/")
+	if !strings.Contains(pattern, "/") && pattern != "**" {
+		ok, _ := path.Match(pattern, path.Base(name))
+		return ok
+	}
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchGlobSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// Try every possible number of directories for the "**" segment, including none.
+			for i := 0; i <= len(name); i++ {
+				if matchGlobSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// GetRepositoryTree creates a tool to list the tree of a GitHub repository using the Git Trees API.
+func GetRepositoryTree(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_tree",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_TREE_DESCRIPTION", "List the files and directories of a GitHub repository at a ref, recursively by default. Unlike get_file_contents, this returns the whole tree in one call, with file sizes, and can be filtered by path prefix and glob patterns.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_TREE_USER_TITLE", "Get repository tree"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag, commit or tree SHA to list (defaults to the default branch)"),
+			),
+			mcp.WithString("pathPrefix",
+				mcp.Description("Only include entries under this directory, e.g. 'pkg/github'"),
+			),
+			mcp.WithArray("patterns",
+				mcp.Description("Only include entries matching these glob patterns, e.g. '**/*_test.go'. Patterns without a '/' match file names in any directory"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithString("type",
+				mcp.Description("Only include entries of this type"),
+				mcp.Enum("blob", "tree", "commit"),
+			),
+			mcp.WithBoolean("recursive",
+				mcp.Description("List the tree recursively (default true). When false, only the top level (or the level at pathPrefix) is listed"),
+				mcp.DefaultBool(true),
+			),
+			mcp.WithNumber("maxEntries",
+				mcp.Description("Maximum number of entries to return (default 2000)"),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pathPrefix, err := OptionalParam[string](request, "pathPrefix")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			patterns, err := OptionalStringArrayParam(request, "patterns")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for _, pattern := range patterns {
+				if err := validatePathGlob(pattern); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+			entryType, err := OptionalParam[string](request, "type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			recursive, ok, err := OptionalParamOK[bool](request, "recursive")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				recursive = true
+			}
+			maxEntries, err := OptionalIntParamWithDefault(request, "maxEntries", 2000)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The trees API resolves branch and tag names as well as SHAs, and HEAD resolves to the default branch.
+			if ref == "" {
+				ref = "HEAD"
+			}
+
+			tree, resp, err := client.Git.GetTree(ctx, owner, repo, ref, recursive)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository tree: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get repository tree: %s", string(body))), nil
+			}
+
+			// For a non-recursive listing of a subdirectory, walk down to that directory one level at a time,
+			// rather than fetching the whole repository just to throw most of it away.
+			pathPrefix = strings.Trim(pathPrefix, "/")
+			entryPrefix := ""
+			if pathPrefix != "" && !recursive {
+				for _, segment := range strings.Split(pathPrefix, "/") {
+					var subtreeSHA string
+					for _, entry := range tree.Entries {
+						if entry.GetPath() == segment && entry.GetType() == "tree" {
+							subtreeSHA = entry.GetSHA()
+							break
+						}
+					}
+					if subtreeSHA == "" {
+						return mcp.NewToolResultError(fmt.Sprintf("directory %s not found at %s", pathPrefix, ref)), nil
+					}
+
+					var subtreeResp *github.Response
+					tree, subtreeResp, err = client.Git.GetTree(ctx, owner, repo, subtreeSHA, false)
+					if err != nil {
+						return nil, fmt.Errorf("failed to get repository tree: %w", err)
+					}
+					_ = subtreeResp.Body.Close()
+				}
+				entryPrefix = pathPrefix + "/"
+			}
+
+			type SimplifiedTreeEntry struct {
+				Path string `json:"path"`
+				Type string `json:"type"`
+				Size int    `json:"size,omitempty"`
+				SHA  string `json:"sha,omitempty"`
+			}
+
+			type SimplifiedTree struct {
+				SHA        string                `json:"sha,omitempty"`
+				Ref        string                `json:"ref"`
+				TotalCount int                   `json:"total_count"`
+				Entries    []SimplifiedTreeEntry `json:"entries"`
+				// Truncated is set when maxEntries was reached, TreeTruncated when GitHub itself truncated the
+				// recursive listing because the repository is too large, in which case non-recursive listings
+				// of subdirectories must be used to see the rest.
+				Truncated     bool `json:"truncated"`
+				TreeTruncated bool `json:"tree_truncated"`
+			}
+
+			result := SimplifiedTree{
+				SHA:           tree.GetSHA(),
+				Ref:           ref,
+				Entries:       []SimplifiedTreeEntry{},
+				TreeTruncated: tree.GetTruncated(),
+			}
+
+			for _, entry := range tree.Entries {
+				entryPath := entryPrefix + entry.GetPath()
+				if pathPrefix != "" && entryPrefix == "" && !strings.HasPrefix(entryPath, pathPrefix+"/") {
+					continue
+				}
+				if entryType != "" && entry.GetType() != entryType {
+					continue
+				}
+				if !matchesAnyPathGlob(patterns, entryPath) {
+					continue
+				}
+
+				result.TotalCount++
+				if len(result.Entries) >= maxEntries {
+					result.Truncated = true
+					continue
+				}
+				result.Entries = append(result.Entries, SimplifiedTreeEntry{
+					Path: entryPath,
+					Type: entry.GetType(),
+					Size: entry.GetSize(),
+					SHA:  entry.GetSHA(),
+				})
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal repository tree: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// blameQuery fetches the blame ranges of a file, along with the commit and pull requests behind each range.
+type blameQuery struct {
+	Repository struct {
+		Object struct {
+			Commit struct {
+				OID   githubv4.GitObjectID
+				Blame struct {
+					Ranges []struct {
+						StartingLine githubv4.Int
+						EndingLine   githubv4.Int
+						Age          githubv4.Int
+						Commit       struct {
+							OID           githubv4.GitObjectID
+							Message       githubv4.String
+							CommittedDate githubv4.DateTime
+							URL           githubv4.URI
+							Author        struct {
+								Name  githubv4.String
+								Email githubv4.String
+								User  struct {
+									Login githubv4.String
+								}
+							}
+							AssociatedPullRequests struct {
+								Nodes []struct {
+									Number githubv4.Int
+									Title  githubv4.String
+									URL    githubv4.URI
+								}
+							} `graphql:"associatedPullRequests(first: 3)"`
+						}
+					}
+				} `graphql:"blame(path: $path)"`
+			} `graphql:"... on Commit"`
+		} `graphql:"object(expression: $ref)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// GetFileBlame creates a tool to get the blame of a file in a GitHub repository.
+func GetFileBlame(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_file_blame",
+			mcp.WithDescription(t("TOOL_GET_FILE_BLAME_DESCRIPTION", "Get the blame of a file in a GitHub repository: for each range of lines, the commit that last changed them, its author, date and message, and the pull requests associated with that commit. Use this to find out who last changed some lines and why, e.g. to find the pull request that introduced a regression.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_FILE_BLAME_USER_TITLE", "Get file blame"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path to the file"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to blame at (defaults to the default branch)"),
+			),
+			mcp.WithNumber("startLine",
+				mcp.Description("Only return blame for lines from this line number (1-based, inclusive)"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("endLine",
+				mcp.Description("Only return blame for lines up to this line number (1-based, inclusive)"),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner     string
+				Repo      string
+				Path      string
+				Ref       string
+				StartLine int32
+				EndLine   int32
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.Owner == "" || params.Repo == "" || params.Path == "" {
+				return mcp.NewToolResultError("owner, repo and path are required"), nil
+			}
+			if params.EndLine != 0 && params.StartLine > params.EndLine {
+				return mcp.NewToolResultError("startLine must not be greater than endLine"), nil
+			}
+			if params.Ref == "" {
+				params.Ref = "HEAD"
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query blameQuery
+			variables := map[string]any{
+				"owner": githubv4.String(params.Owner),
+				"name":  githubv4.String(params.Repo),
+				"ref":   githubv4.String(params.Ref),
+				"path":  githubv4.String(strings.TrimPrefix(params.Path, "/")),
+			}
+			if err := client.Query(ctx, &query, variables); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get file blame: %v", err)), nil
+			}
+
+			commit := query.Repository.Object.Commit
+			if commit.OID == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("ref %s could not be resolved to a commit", params.Ref)), nil
+			}
+
+			type BlamePullRequest struct {
+				Number int    `json:"number"`
+				Title  string `json:"title,omitempty"`
+				URL    string `json:"url,omitempty"`
+			}
+
+			type BlameCommit struct {
+				SHA          string             `json:"sha"`
+				Message      string             `json:"message,omitempty"`
+				Date         string             `json:"date,omitempty"`
+				AuthorName   string             `json:"author_name,omitempty"`
+				AuthorEmail  string             `json:"author_email,omitempty"`
+				AuthorLogin  string             `json:"author_login,omitempty"`
+				URL          string             `json:"url,omitempty"`
+				PullRequests []BlamePullRequest `json:"pull_requests,omitempty"`
+			}
+
+			type BlameRange struct {
+				StartLine int         `json:"start_line"`
+				EndLine   int         `json:"end_line"`
+				Age       int         `json:"age"`
+				Commit    BlameCommit `json:"commit"`
+			}
+
+			type BlameResult struct {
+				Path   string       `json:"path"`
+				Ref    string       `json:"ref"`
+				SHA    string       `json:"sha"`
+				Ranges []BlameRange `json:"ranges"`
+			}
+
+			result := BlameResult{
+				Path:   params.Path,
+				Ref:    params.Ref,
+				SHA:    string(commit.OID),
+				Ranges: []BlameRange{},
+			}
+
+			for _, r := range commit.Blame.Ranges {
+				startLine, endLine := int(r.StartingLine), int(r.EndingLine)
+				// Keep any range that overlaps the requested lines, clamped to them.
+				if params.StartLine != 0 {
+					if endLine < int(params.StartLine) {
+						continue
+					}
+					startLine = max(startLine, int(params.StartLine))
+				}
+				if params.EndLine != 0 {
+					if startLine > int(params.EndLine) {
+						continue
+					}
+					endLine = min(endLine, int(params.EndLine))
+				}
+
+				blameCommit := BlameCommit{
+					SHA:         string(r.Commit.OID),
+					Message:     string(r.Commit.Message),
+					AuthorName:  string(r.Commit.Author.Name),
+					AuthorEmail: string(r.Commit.Author.Email),
+					AuthorLogin: string(r.Commit.Author.User.Login),
+				}
+				if !r.Commit.CommittedDate.IsZero() {
+					blameCommit.Date = r.Commit.CommittedDate.Format(time.RFC3339)
+				}
+				if r.Commit.URL.URL != nil {
+					blameCommit.URL = r.Commit.URL.String()
+				}
+				for _, pr := range r.Commit.AssociatedPullRequests.Nodes {
+					blamePR := BlamePullRequest{
+						Number: int(pr.Number),
+						Title:  string(pr.Title),
+					}
+					if pr.URL.URL != nil {
+						blamePR.URL = pr.URL.String()
+					}
+					blameCommit.PullRequests = append(blameCommit.PullRequests, blamePR)
+				}
+
+				result.Ranges = append(result.Ranges, BlameRange{
+					StartLine: startLine,
+					EndLine:   endLine,
+					Age:       int(r.Age),
+					Commit:    blameCommit,
+				})
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal file blame: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListCommitPullRequests creates a tool to list the pull requests associated with a commit.
+func ListCommitPullRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_commit_pull_requests",
+			mcp.WithDescription(t("TOOL_LIST_COMMIT_PULL_REQUESTS_DESCRIPTION", "List the pull requests associated with a commit: the merged pull request that introduced it into the default branch, or open pull requests that contain it")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_COMMIT_PULL_REQUESTS_USER_TITLE", "List pull requests for a commit"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("Commit SHA"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := requiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			prs, resp, err := client.PullRequests.ListPullRequestsWithCommit(ctx, owner, repo, sha, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list pull requests for commit: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list pull requests for commit: %s", string(body))), nil
+			}
+
+			type SimplifiedPullRequest struct {
+				Number   int    `json:"number"`
+				Title    string `json:"title,omitempty"`
+				State    string `json:"state,omitempty"`
+				User     string `json:"user,omitempty"`
+				HTMLURL  string `json:"html_url,omitempty"`
+				BaseRef  string `json:"base_ref,omitempty"`
+				HeadRef  string `json:"head_ref,omitempty"`
+				MergedAt string `json:"merged_at,omitempty"`
+			}
+
+			simplifiedPRs := make([]SimplifiedPullRequest, 0, len(prs))
+			for _, pr := range prs {
+				simplifiedPR := SimplifiedPullRequest{
+					Number:  pr.GetNumber(),
+					Title:   pr.GetTitle(),
+					State:   pr.GetState(),
+					User:    pr.GetUser().GetLogin(),
+					HTMLURL: pr.GetHTMLURL(),
+					BaseRef: pr.GetBase().GetRef(),
+					HeadRef: pr.GetHead().GetRef(),
+				}
+				if pr.MergedAt != nil && !pr.MergedAt.IsZero() {
+					simplifiedPR.MergedAt = pr.MergedAt.Format(time.RFC3339)
+				}
+				simplifiedPRs = append(simplifiedPRs, simplifiedPR)
+			}
+
+			r, err := json.Marshal(simplifiedPRs)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal pull requests: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetFileHistory creates a tool to get the history of a file, following renames where possible.
+func GetFileHistory(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_file_history",
+			mcp.WithDescription(t("TOOL_GET_FILE_HISTORY_DESCRIPTION", "Get the history of a file in a GitHub repository, newest first, with a snippet of the patch each commit applied to it. Renames are followed on a best effort basis, by continuing with the previous file name from before the rename commit.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_FILE_HISTORY_USER_TITLE", "Get file history"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path to the file"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to start the history from (defaults to the default branch)"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only commits after this date (ISO 8601 timestamp)"),
+			),
+			mcp.WithNumber("maxCommits",
+				mcp.Description("Maximum number of commits to return (default 10, max 50)"),
+				mcp.Min(1),
+				mcp.Max(50),
+			),
+			mcp.WithBoolean("followRenames",
+				mcp.Description("Continue the history across renames of the file (default true)"),
+				mcp.DefaultBool(true),
+			),
+			mcp.WithNumber("maxPatchLines",
+				mcp.Description("Maximum number of patch lines to include per commit, 0 to omit patches (default 20)"),
+				mcp.Min(0),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filePath, err := requiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			since, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxCommits, err := OptionalIntParamWithDefault(request, "maxCommits", 10)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxCommits = min(maxCommits, 50)
+			followRenames, ok, err := OptionalParamOK[bool](request, "followRenames")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				followRenames = true
+			}
+			maxPatchLines, ok, err := OptionalParamOK[float64](request, "maxPatchLines")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				maxPatchLines = 20
+			}
+
+			var sinceTime time.Time
+			if since != "" {
+				sinceTime, err = parseISOTimestamp(since)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get file history: %s", err.Error())), nil
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			type FileHistoryEntry struct {
+				SHA            string `json:"sha"`
+				Message        string `json:"message,omitempty"`
+				Author         string `json:"author,omitempty"`
+				Date           string `json:"date,omitempty"`
+				HTMLURL        string `json:"html_url,omitempty"`
+				Path           string `json:"path"`
+				Status         string `json:"status,omitempty"`
+				Additions      int    `json:"additions"`
+				Deletions      int    `json:"deletions"`
+				Patch          string `json:"patch,omitempty"`
+				PatchTruncated bool   `json:"patch_truncated,omitempty"`
+			}
+
+			type FileRename struct {
+				SHA  string `json:"sha"`
+				From string `json:"from"`
+				To   string `json:"to"`
+			}
+
+			type FileHistory struct {
+				Path    string             `json:"path"`
+				Ref     string             `json:"ref,omitempty"`
+				Commits []FileHistoryEntry `json:"commits"`
+				Renames []FileRename       `json:"renames,omitempty"`
+				HasMore bool               `json:"has_more"`
+			}
+
+			history := FileHistory{
+				Path:    filePath,
+				Ref:     ref,
+				Commits: []FileHistoryEntry{},
+			}
+
+			currentPath := filePath
+			opts := &github.CommitsListOptions{
+				SHA:         ref,
+				Path:        currentPath,
+				Since:       sinceTime,
+				ListOptions: github.ListOptions{PerPage: maxCommits},
+			}
+
+			// Each commit has to be fetched to see what it did to the file, which adds up on long histories
+			progress := newProgressReporter(ctx, request, maxCommits)
+
+		history:
+			for {
+				commits, resp, err := client.Repositories.ListCommits(ctx, owner, repo, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list commits: %w", err)
+				}
+				_ = resp.Body.Close()
+
+				for _, commit := range commits {
+					if len(history.Commits) >= maxCommits {
+						history.HasMore = true
+						break history
+					}
+
+					// The list endpoint doesn't include files, so fetch the commit to see what it did to our file.
+					detail, detailResp, err := client.Repositories.GetCommit(ctx, owner, repo, commit.GetSHA(), nil)
+					if err != nil {
+						return nil, fmt.Errorf("failed to get commit: %w", err)
+					}
+					_ = detailResp.Body.Close()
+
+					entry := FileHistoryEntry{
+						SHA:     commit.GetSHA(),
+						Message: commit.GetCommit().GetMessage(),
+						Author:  commit.GetCommit().GetAuthor().GetName(),
+						HTMLURL: commit.GetHTMLURL(),
+						Path:    currentPath,
+					}
+					if login := commit.GetAuthor().GetLogin(); login != "" {
+						entry.Author = login
+					}
+					if date := commit.GetCommit().GetAuthor().Date; date != nil {
+						entry.Date = date.Format(time.RFC3339)
+					}
+
+					var previousPath string
+					for _, file := range detail.Files {
+						if file.GetFilename() != currentPath {
+							continue
+						}
+						entry.Status = file.GetStatus()
+						entry.Additions = file.GetAdditions()
+						entry.Deletions = file.GetDeletions()
+						if maxPatchLines > 0 {
+							lines := strings.Split(file.GetPatch(), "\n")
+							if len(lines) > int(maxPatchLines) {
+								lines = lines[:int(maxPatchLines)]
+								entry.PatchTruncated = true
+							}
+							entry.Patch = strings.Join(lines, "\n")
+						}
+						if file.GetStatus() == "renamed" {
+							previousPath = file.GetPreviousFilename()
+						}
+						break
+					}
+					history.Commits = append(history.Commits, entry)
+					progress.Step("Read commit " + commit.GetSHA())
+
+					// A rename is the oldest commit listed for the new path, so carry on from its parent with the old path.
+					if followRenames && previousPath != "" && len(detail.Parents) > 0 {
+						history.Renames = append(history.Renames, FileRename{SHA: commit.GetSHA(), From: previousPath, To: currentPath})
+						currentPath = previousPath
+						opts.SHA = detail.Parents[0].GetSHA()
+						opts.Path = currentPath
+						opts.Page = 0
+						continue history
+					}
+				}
+
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			r, err := json.Marshal(history)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal file history: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
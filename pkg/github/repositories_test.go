@@ -7,9 +7,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v72/github"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -468,6 +471,7 @@ func Test_GetCommit(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
 	tool, _ := GetCommit(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "get_commit", tool.Name)
 	assert.NotEmpty(t, tool.Description)
@@ -593,12 +597,17 @@ func Test_ListCommits(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
 	tool, _ := ListCommits(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "list_commits", tool.Name)
 	assert.NotEmpty(t, tool.Description)
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "author")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "until")
 	assert.Contains(t, tool.InputSchema.Properties, "page")
 	assert.Contains(t, tool.InputSchema.Properties, "perPage")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
@@ -704,6 +713,34 @@ func Test_ListCommits(t *testing.T) {
 			expectError:     false,
 			expectedCommits: mockCommits,
 		},
+		{
+			name: "successful commits fetch with path, author and date filters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"path":     "pkg/github/server.go",
+						"author":   "testuser",
+						"since":    "2025-01-01T00:00:00Z",
+						"until":    "2025-02-01T00:00:00Z",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockCommits),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"path":   "pkg/github/server.go",
+				"author": "testuser",
+				"since":  "2025-01-01",
+				"until":  "2025-02-01T00:00:00Z",
+			},
+			expectError:     false,
+			expectedCommits: mockCommits,
+		},
 		{
 			name: "commits fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -1963,3 +2000,1042 @@ func Test_GetTag(t *testing.T) {
 		})
 	}
 }
+
+func Test_CompareRefs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CompareRefs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "compare_refs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "head")
+	assert.Contains(t, tool.InputSchema.Properties, "paths")
+	assert.Contains(t, tool.InputSchema.Properties, "includeDiff")
+	assert.Contains(t, tool.InputSchema.Properties, "maxDiffLines")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base", "head"})
+
+	mockComparison := &github.CommitsComparison{
+		Status:       github.Ptr("ahead"),
+		AheadBy:      github.Ptr(2),
+		BehindBy:     github.Ptr(0),
+		TotalCommits: github.Ptr(2),
+		HTMLURL:      github.Ptr("https://github.com/owner/repo/compare/v1.0.0...main"),
+		MergeBaseCommit: &github.RepositoryCommit{
+			SHA: github.Ptr("base-sha"),
+		},
+		Commits: []*github.RepositoryCommit{
+			{
+				SHA: github.Ptr("abc123"),
+				Commit: &github.Commit{
+					Message: github.Ptr("Add feature"),
+					Author: &github.CommitAuthor{
+						Name: github.Ptr("Test User"),
+						Date: &github.Timestamp{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+					},
+				},
+				Author: &github.User{Login: github.Ptr("testuser")},
+			},
+			{
+				SHA: github.Ptr("def456"),
+				Commit: &github.Commit{
+					Message: github.Ptr("Update docs"),
+					Author:  &github.CommitAuthor{Name: github.Ptr("Other User")},
+				},
+			},
+		},
+		Files: []*github.CommitFile{
+			{
+				Filename:  github.Ptr("pkg/feature/feature.go"),
+				Status:    github.Ptr("modified"),
+				Additions: github.Ptr(2),
+				Deletions: github.Ptr(1),
+				Changes:   github.Ptr(3),
+				Patch:     github.Ptr("@@ -1,2 +1,3 @@\n package feature\n-var a = 1\n+var a = 2\n+var b = 3"),
+			},
+			{
+				Filename:  github.Ptr("README.md"),
+				Status:    github.Ptr("modified"),
+				Additions: github.Ptr(1),
+				Deletions: github.Ptr(0),
+				Changes:   github.Ptr(1),
+				Patch:     github.Ptr("@@ -1 +1,2 @@\n # Repo\n+More docs"),
+			},
+		},
+	}
+
+	type comparisonResult struct {
+		Status       string `json:"status"`
+		AheadBy      int    `json:"ahead_by"`
+		BehindBy     int    `json:"behind_by"`
+		TotalCommits int    `json:"total_commits"`
+		MergeBaseSHA string `json:"merge_base_sha"`
+		Commits      []struct {
+			SHA     string `json:"sha"`
+			Message string `json:"message"`
+			Author  string `json:"author"`
+			Date    string `json:"date"`
+		} `json:"commits"`
+		Files []struct {
+			Filename  string `json:"filename"`
+			Additions int    `json:"additions"`
+			Deletions int    `json:"deletions"`
+		} `json:"files"`
+		Additions     int    `json:"additions"`
+		Deletions     int    `json:"deletions"`
+		Diff          string `json:"diff"`
+		DiffTruncated bool   `json:"diff_truncated"`
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedErrMsg    string
+		expectedFiles     []string
+		expectedAdditions int
+		expectedDiff      string
+		expectedTruncated bool
+	}{
+		{
+			name: "successful comparison",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectPath(t, "/repos/owner/repo/compare/v1.0.0...main").andThen(
+						mockResponse(t, http.StatusOK, mockComparison),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v1.0.0",
+				"head":  "main",
+			},
+			expectError:       false,
+			expectedFiles:     []string{"pkg/feature/feature.go", "README.md"},
+			expectedAdditions: 3,
+		},
+		{
+			name: "comparison filtered by path with diff",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					mockComparison,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"base":        "v1.0.0",
+				"head":        "main",
+				"paths":       []interface{}{"pkg/**/*.go"},
+				"includeDiff": true,
+			},
+			expectError:       false,
+			expectedFiles:     []string{"pkg/feature/feature.go"},
+			expectedAdditions: 2,
+			expectedDiff:      "diff --git a/pkg/feature/feature.go b/pkg/feature/feature.go\n--- a/pkg/feature/feature.go\n+++ b/pkg/feature/feature.go\n@@ -1,2 +1,3 @@\n package feature\n-var a = 1\n+var a = 2\n+var b = 3\n",
+		},
+		{
+			name: "diff is truncated",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					mockComparison,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"base":         "v1.0.0",
+				"head":         "main",
+				"includeDiff":  true,
+				"maxDiffLines": float64(4),
+			},
+			expectError:       false,
+			expectedFiles:     []string{"pkg/feature/feature.go", "README.md"},
+			expectedAdditions: 3,
+			expectedDiff:      "diff --git a/pkg/feature/feature.go b/pkg/feature/feature.go\n--- a/pkg/feature/feature.go\n+++ b/pkg/feature/feature.go\n@@ -1,2 +1,3 @@\n",
+			expectedTruncated: true,
+		},
+		{
+			name:         "invalid path pattern",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v1.0.0",
+				"head":  "main",
+				"paths": []interface{}{"pkg/[.go"},
+			},
+			expectError:    false,
+			expectedErrMsg: "invalid path pattern",
+		},
+		{
+			name: "comparison fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "missing",
+				"head":  "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to compare refs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CompareRefs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned comparisonResult
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+
+			assert.Equal(t, "ahead", returned.Status)
+			assert.Equal(t, 2, returned.AheadBy)
+			assert.Equal(t, 0, returned.BehindBy)
+			assert.Equal(t, "base-sha", returned.MergeBaseSHA)
+			require.Len(t, returned.Commits, 2)
+			assert.Equal(t, "abc123", returned.Commits[0].SHA)
+			assert.Equal(t, "testuser", returned.Commits[0].Author)
+			assert.Equal(t, "2025-01-02T03:04:05Z", returned.Commits[0].Date)
+			assert.Equal(t, "Other User", returned.Commits[1].Author)
+
+			filenames := make([]string, 0, len(returned.Files))
+			for _, file := range returned.Files {
+				filenames = append(filenames, file.Filename)
+			}
+			assert.Equal(t, tc.expectedFiles, filenames)
+			assert.Equal(t, tc.expectedAdditions, returned.Additions)
+			assert.Equal(t, tc.expectedDiff, returned.Diff)
+			assert.Equal(t, tc.expectedTruncated, returned.DiffTruncated)
+		})
+	}
+}
+
+func Test_MatchesPathGlob(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		name     string
+		expected bool
+	}{
+		{pattern: "*.go", name: "main.go", expected: true},
+		{pattern: "*.go", name: "pkg/github/server.go", expected: true},
+		{pattern: "pkg/*.go", name: "pkg/github/server.go", expected: false},
+		{pattern: "pkg/**/*.go", name: "pkg/github/server.go", expected: true},
+		{pattern: "pkg/**/*.go", name: "pkg/server.go", expected: true},
+		{pattern: "pkg/**", name: "pkg/github/server.go", expected: true},
+		{pattern: "/docs/*.md", name: "docs/README.md", expected: true},
+		{pattern: "docs/*.md", name: "pkg/docs/README.md", expected: false},
+		{pattern: "**", name: "anything/at/all", expected: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.pattern+" "+tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, matchesPathGlob(tc.pattern, tc.name))
+		})
+	}
+}
+
+func Test_GetRepositoryTree(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryTree(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_repository_tree", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "pathPrefix")
+	assert.Contains(t, tool.InputSchema.Properties, "patterns")
+	assert.Contains(t, tool.InputSchema.Properties, "type")
+	assert.Contains(t, tool.InputSchema.Properties, "recursive")
+	assert.Contains(t, tool.InputSchema.Properties, "maxEntries")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockTree := &github.Tree{
+		SHA: github.Ptr("tree-sha"),
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("README.md"), Type: github.Ptr("blob"), Size: github.Ptr(120), SHA: github.Ptr("sha1")},
+			{Path: github.Ptr("pkg"), Type: github.Ptr("tree"), SHA: github.Ptr("sha2")},
+			{Path: github.Ptr("pkg/github"), Type: github.Ptr("tree"), SHA: github.Ptr("sha3")},
+			{Path: github.Ptr("pkg/github/server.go"), Type: github.Ptr("blob"), Size: github.Ptr(2048), SHA: github.Ptr("sha4")},
+			{Path: github.Ptr("pkg/github/server_test.go"), Type: github.Ptr("blob"), Size: github.Ptr(4096), SHA: github.Ptr("sha5")},
+			{Path: github.Ptr("pkg/log/io.go"), Type: github.Ptr("blob"), Size: github.Ptr(512), SHA: github.Ptr("sha6")},
+		},
+		Truncated: github.Ptr(false),
+	}
+
+	type treeResult struct {
+		SHA        string `json:"sha"`
+		Ref        string `json:"ref"`
+		TotalCount int    `json:"total_count"`
+		Entries    []struct {
+			Path string `json:"path"`
+			Type string `json:"type"`
+			Size int    `json:"size"`
+		} `json:"entries"`
+		Truncated     bool `json:"truncated"`
+		TreeTruncated bool `json:"tree_truncated"`
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedErrMsg    string
+		expectedPaths     []string
+		expectedTotal     int
+		expectedTruncated bool
+	}{
+		{
+			name: "recursive listing of the default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					expect(t, expectations{
+						path:        "/repos/owner/repo/git/trees/HEAD",
+						queryParams: map[string]string{"recursive": "1"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockTree),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedPaths: []string{"README.md", "pkg", "pkg/github", "pkg/github/server.go", "pkg/github/server_test.go", "pkg/log/io.go"},
+			expectedTotal: 6,
+		},
+		{
+			name: "filtered by prefix, pattern and type",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					expectPath(t, "/repos/owner/repo/git/trees/main").andThen(
+						mockResponse(t, http.StatusOK, mockTree),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"ref":        "main",
+				"pathPrefix": "pkg/",
+				"patterns":   []interface{}{"*.go"},
+				"type":       "blob",
+			},
+			expectedPaths: []string{"pkg/github/server.go", "pkg/github/server_test.go", "pkg/log/io.go"},
+			expectedTotal: 3,
+		},
+		{
+			name: "non-recursive listing of a directory",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Empty(t, r.URL.Query().Get("recursive"))
+						switch r.URL.Path {
+						case "/repos/owner/repo/git/trees/main":
+							mockResponse(t, http.StatusOK, &github.Tree{
+								SHA: github.Ptr("root-sha"),
+								Entries: []*github.TreeEntry{
+									{Path: github.Ptr("README.md"), Type: github.Ptr("blob"), SHA: github.Ptr("sha1")},
+									{Path: github.Ptr("pkg"), Type: github.Ptr("tree"), SHA: github.Ptr("sha2")},
+								},
+							})(w, r)
+						case "/repos/owner/repo/git/trees/sha2":
+							mockResponse(t, http.StatusOK, &github.Tree{
+								SHA: github.Ptr("sha2"),
+								Entries: []*github.TreeEntry{
+									{Path: github.Ptr("github"), Type: github.Ptr("tree"), SHA: github.Ptr("sha3")},
+									{Path: github.Ptr("log"), Type: github.Ptr("tree"), SHA: github.Ptr("sha7")},
+								},
+							})(w, r)
+						case "/repos/owner/repo/git/trees/sha3":
+							mockResponse(t, http.StatusOK, &github.Tree{
+								SHA: github.Ptr("sha3"),
+								Entries: []*github.TreeEntry{
+									{Path: github.Ptr("server.go"), Type: github.Ptr("blob"), Size: github.Ptr(2048)},
+									{Path: github.Ptr("server_test.go"), Type: github.Ptr("blob"), Size: github.Ptr(4096)},
+								},
+							})(w, r)
+						default:
+							t.Errorf("unexpected path %s", r.URL.Path)
+							w.WriteHeader(http.StatusNotFound)
+						}
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"ref":        "main",
+				"pathPrefix": "pkg/github",
+				"recursive":  false,
+			},
+			expectedPaths: []string{"pkg/github/server.go", "pkg/github/server_test.go"},
+			expectedTotal: 2,
+		},
+		{
+			name: "truncated at max entries",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					mockTree,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"maxEntries": float64(2),
+			},
+			expectedPaths:     []string{"README.md", "pkg"},
+			expectedTotal:     6,
+			expectedTruncated: true,
+		},
+		{
+			name: "get tree fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository tree",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryTree(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returned treeResult
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+
+			paths := make([]string, 0, len(returned.Entries))
+			for _, entry := range returned.Entries {
+				paths = append(paths, entry.Path)
+			}
+			assert.Equal(t, tc.expectedPaths, paths)
+			assert.Equal(t, tc.expectedTotal, returned.TotalCount)
+			assert.Equal(t, tc.expectedTruncated, returned.Truncated)
+			assert.False(t, returned.TreeTruncated)
+		})
+	}
+}
+
+func Test_GetFileBlame(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := GetFileBlame(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_file_blame", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "startLine")
+	assert.Contains(t, tool.InputSchema.Properties, "endLine")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path"})
+
+	blameResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"object": map[string]any{
+				"oid": "head-sha",
+				"blame": map[string]any{
+					"ranges": []any{
+						map[string]any{
+							"startingLine": 1,
+							"endingLine":   10,
+							"age":          3,
+							"commit": map[string]any{
+								"oid":           "old-sha",
+								"message":       "Initial commit",
+								"committedDate": "2024-01-01T00:00:00Z",
+								"url":           "https://github.com/owner/repo/commit/old-sha",
+								"author": map[string]any{
+									"name":  "Old Author",
+									"email": "old@example.com",
+									"user":  map[string]any{"login": "oldauthor"},
+								},
+								"associatedPullRequests": map[string]any{"nodes": []any{}},
+							},
+						},
+						map[string]any{
+							"startingLine": 11,
+							"endingLine":   20,
+							"age":          10,
+							"commit": map[string]any{
+								"oid":           "new-sha",
+								"message":       "Fix the thing\n\nIt was broken.",
+								"committedDate": "2025-02-03T04:05:06Z",
+								"url":           "https://github.com/owner/repo/commit/new-sha",
+								"author": map[string]any{
+									"name":  "New Author",
+									"email": "new@example.com",
+									"user":  map[string]any{"login": "newauthor"},
+								},
+								"associatedPullRequests": map[string]any{
+									"nodes": []any{
+										map[string]any{
+											"number": 42,
+											"title":  "Fix the thing",
+											"url":    "https://github.com/owner/repo/pull/42",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	type blameResult struct {
+		Path   string `json:"path"`
+		Ref    string `json:"ref"`
+		SHA    string `json:"sha"`
+		Ranges []struct {
+			StartLine int `json:"start_line"`
+			EndLine   int `json:"end_line"`
+			Age       int `json:"age"`
+			Commit    struct {
+				SHA          string `json:"sha"`
+				Message      string `json:"message"`
+				Date         string `json:"date"`
+				AuthorLogin  string `json:"author_login"`
+				PullRequests []struct {
+					Number int    `json:"number"`
+					URL    string `json:"url"`
+				} `json:"pull_requests"`
+			} `json:"commit"`
+		} `json:"ranges"`
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedRef        string
+		expectedRanges     [][2]int
+	}{
+		{
+			name: "blame of the whole file on the default branch",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					blameQuery{},
+					map[string]any{
+						"owner": githubv4.String("owner"),
+						"name":  githubv4.String("repo"),
+						"ref":   githubv4.String("HEAD"),
+						"path":  githubv4.String("main.go"),
+					},
+					blameResponse,
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "main.go",
+			},
+			expectedRef:    "HEAD",
+			expectedRanges: [][2]int{{1, 10}, {11, 20}},
+		},
+		{
+			name: "blame restricted to a line range",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					blameQuery{},
+					map[string]any{
+						"owner": githubv4.String("owner"),
+						"name":  githubv4.String("repo"),
+						"ref":   githubv4.String("v1.0.0"),
+						"path":  githubv4.String("main.go"),
+					},
+					blameResponse,
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":     "owner",
+				"repo":      "repo",
+				"path":      "main.go",
+				"ref":       "v1.0.0",
+				"startLine": float64(12),
+				"endLine":   float64(15),
+			},
+			expectedRef:    "v1.0.0",
+			expectedRanges: [][2]int{{12, 15}},
+		},
+		{
+			name:         "invalid line range",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":     "owner",
+				"repo":      "repo",
+				"path":      "main.go",
+				"startLine": float64(20),
+				"endLine":   float64(10),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "startLine must not be greater than endLine",
+		},
+		{
+			name: "query fails",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					blameQuery{},
+					map[string]any{
+						"owner": githubv4.String("owner"),
+						"name":  githubv4.String("repo"),
+						"ref":   githubv4.String("HEAD"),
+						"path":  githubv4.String("missing.go"),
+					},
+					githubv4mock.ErrorResponse("Could not resolve file"),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "missing.go",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "failed to get file blame",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := GetFileBlame(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			var returned blameResult
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+
+			assert.Equal(t, "main.go", returned.Path)
+			assert.Equal(t, tc.expectedRef, returned.Ref)
+			assert.Equal(t, "head-sha", returned.SHA)
+
+			ranges := make([][2]int, 0, len(returned.Ranges))
+			for _, r := range returned.Ranges {
+				ranges = append(ranges, [2]int{r.StartLine, r.EndLine})
+			}
+			assert.Equal(t, tc.expectedRanges, ranges)
+
+			last := returned.Ranges[len(returned.Ranges)-1]
+			assert.Equal(t, "new-sha", last.Commit.SHA)
+			assert.Equal(t, "newauthor", last.Commit.AuthorLogin)
+			assert.Equal(t, "2025-02-03T04:05:06Z", last.Commit.Date)
+			require.Len(t, last.Commit.PullRequests, 1)
+			assert.Equal(t, 42, last.Commit.PullRequests[0].Number)
+		})
+	}
+}
+
+func Test_ListCommitPullRequests(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCommitPullRequests(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_commit_pull_requests", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
+
+	mockPRs := []*github.PullRequest{
+		{
+			Number:   github.Ptr(42),
+			Title:    github.Ptr("Fix the thing"),
+			State:    github.Ptr("closed"),
+			HTMLURL:  github.Ptr("https://github.com/owner/repo/pull/42"),
+			User:     &github.User{Login: github.Ptr("testuser")},
+			Base:     &github.PullRequestBranch{Ref: github.Ptr("main")},
+			Head:     &github.PullRequestBranch{Ref: github.Ptr("fix-thing")},
+			MergedAt: &github.Timestamp{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful pull requests fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsPullsByOwnerByRepoByCommitSha,
+					expectPath(t, "/repos/owner/repo/commits/abc123/pulls").andThen(
+						mockResponse(t, http.StatusOK, mockPRs),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+			},
+		},
+		{
+			name: "pull requests fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsPullsByOwnerByRepoByCommitSha,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "No commit found for SHA: nope"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "nope",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list pull requests for commit",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCommitPullRequests(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedPRs []struct {
+				Number   int    `json:"number"`
+				State    string `json:"state"`
+				User     string `json:"user"`
+				BaseRef  string `json:"base_ref"`
+				MergedAt string `json:"merged_at"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &returnedPRs)
+			require.NoError(t, err)
+			require.Len(t, returnedPRs, 1)
+			assert.Equal(t, 42, returnedPRs[0].Number)
+			assert.Equal(t, "testuser", returnedPRs[0].User)
+			assert.Equal(t, "main", returnedPRs[0].BaseRef)
+			assert.Equal(t, "2025-01-02T03:04:05Z", returnedPRs[0].MergedAt)
+		})
+	}
+}
+
+func Test_GetFileHistory(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetFileHistory(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_file_history", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "maxCommits")
+	assert.Contains(t, tool.InputSchema.Properties, "followRenames")
+	assert.Contains(t, tool.InputSchema.Properties, "maxPatchLines")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path"})
+
+	listedCommit := func(sha, message string) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			SHA: github.Ptr(sha),
+			Commit: &github.Commit{
+				Message: github.Ptr(message),
+				Author:  &github.CommitAuthor{Name: github.Ptr("Test User")},
+			},
+		}
+	}
+
+	// new.go was edited in c1, renamed from old.go in c2, and old.go was edited in c3.
+	commitsByPath := map[string][]*github.RepositoryCommit{
+		"new.go": {listedCommit("c1", "Edit new.go"), listedCommit("c2", "Rename old.go to new.go")},
+		"old.go": {listedCommit("c3", "Edit old.go")},
+	}
+	commitDetails := map[string]*github.RepositoryCommit{
+		"c1": {
+			SHA:     github.Ptr("c1"),
+			Parents: []*github.Commit{{SHA: github.Ptr("c2")}},
+			Files: []*github.CommitFile{
+				{Filename: github.Ptr("new.go"), Status: github.Ptr("modified"), Additions: github.Ptr(1), Deletions: github.Ptr(1), Patch: github.Ptr("@@ -1 +1 @@\n-a\n+b")},
+			},
+		},
+		"c2": {
+			SHA:     github.Ptr("c2"),
+			Parents: []*github.Commit{{SHA: github.Ptr("c3")}},
+			Files: []*github.CommitFile{
+				{Filename: github.Ptr("new.go"), PreviousFilename: github.Ptr("old.go"), Status: github.Ptr("renamed")},
+			},
+		},
+		"c3": {
+			SHA:     github.Ptr("c3"),
+			Parents: []*github.Commit{{SHA: github.Ptr("c4")}},
+			Files: []*github.CommitFile{
+				{Filename: github.Ptr("old.go"), Status: github.Ptr("added"), Additions: github.Ptr(1), Patch: github.Ptr("@@ -0,0 +1 @@\n+a")},
+			},
+		},
+	}
+
+	mockedClient := func() *http.Client {
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposCommitsByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					path := r.URL.Query().Get("path")
+					if path == "old.go" {
+						// The history of the old path continues from the parent of the rename commit.
+						assert.Equal(t, "c3", r.URL.Query().Get("sha"))
+					}
+					mockResponse(t, http.StatusOK, commitsByPath[path])(w, r)
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposCommitsByOwnerByRepoByRef,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					sha := r.URL.Path[len("/repos/owner/repo/commits/"):]
+					mockResponse(t, http.StatusOK, commitDetails[sha])(w, r)
+				}),
+			),
+		)
+	}
+
+	type historyResult struct {
+		Path    string `json:"path"`
+		Commits []struct {
+			SHA            string `json:"sha"`
+			Path           string `json:"path"`
+			Status         string `json:"status"`
+			Patch          string `json:"patch"`
+			PatchTruncated bool   `json:"patch_truncated"`
+		} `json:"commits"`
+		Renames []struct {
+			SHA  string `json:"sha"`
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"renames"`
+		HasMore bool `json:"has_more"`
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedSHAs    []string
+		expectedPaths   []string
+		expectedRenames int
+		expectedHasMore bool
+	}{
+		{
+			name:         "follows renames",
+			mockedClient: mockedClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "new.go",
+			},
+			expectedSHAs:    []string{"c1", "c2", "c3"},
+			expectedPaths:   []string{"new.go", "new.go", "old.go"},
+			expectedRenames: 1,
+		},
+		{
+			name:         "without following renames",
+			mockedClient: mockedClient(),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"path":          "new.go",
+				"followRenames": false,
+			},
+			expectedSHAs:  []string{"c1", "c2"},
+			expectedPaths: []string{"new.go", "new.go"},
+		},
+		{
+			name:         "limited number of commits",
+			mockedClient: mockedClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "new.go",
+				"maxCommits": float64(1),
+			},
+			expectedSHAs:    []string{"c1"},
+			expectedPaths:   []string{"new.go"},
+			expectedHasMore: true,
+		},
+		{
+			name: "list commits fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "new.go",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list commits",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetFileHistory(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returned historyResult
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+
+			shas := make([]string, 0, len(returned.Commits))
+			paths := make([]string, 0, len(returned.Commits))
+			for _, commit := range returned.Commits {
+				shas = append(shas, commit.SHA)
+				paths = append(paths, commit.Path)
+			}
+			assert.Equal(t, tc.expectedSHAs, shas)
+			assert.Equal(t, tc.expectedPaths, paths)
+			assert.Len(t, returned.Renames, tc.expectedRenames)
+			assert.Equal(t, tc.expectedHasMore, returned.HasMore)
+			assert.Equal(t, "@@ -1 +1 @@\n-a\n+b", returned.Commits[0].Patch)
+			assert.Equal(t, "modified", returned.Commits[0].Status)
+		})
+	}
+}
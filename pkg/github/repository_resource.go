@@ -86,25 +86,8 @@ func RepositoryResourceContentsHandler(getClient GetClientFn) func(ctx context.C
 			path = strings.Join(p, "/")
 		}
 
-		opts := &github.RepositoryContentGetOptions{}
-
-		sha, ok := request.Params.Arguments["sha"].([]string)
-		if ok && len(sha) > 0 {
-			opts.Ref = sha[0]
-		}
-
-		branch, ok := request.Params.Arguments["branch"].([]string)
-		if ok && len(branch) > 0 {
-			opts.Ref = "refs/heads/" + branch[0]
-		}
-
-		tag, ok := request.Params.Arguments["tag"].([]string)
-		if ok && len(tag) > 0 {
-			opts.Ref = "refs/tags/" + tag[0]
-		}
-		prNumber, ok := request.Params.Arguments["prNumber"].([]string)
-		if ok && len(prNumber) > 0 {
-			opts.Ref = "refs/pull/" + prNumber[0] + "/head"
+		opts := &github.RepositoryContentGetOptions{
+			Ref: resourceContentsRef(request.Params.Arguments),
 		}
 
 		client, err := getClient(ctx)
@@ -142,7 +125,7 @@ func RepositoryResourceContentsHandler(getClient GetClientFn) func(ctx context.C
 			if fileContent.Content != nil {
 				// download the file content from fileContent.GetDownloadURL() and use the content-type header to determine the MIME type
 				// and return the content as a blob unless it is a text file, where you can return the content as text
-				req, err := http.NewRequest("GET", fileContent.GetDownloadURL(), nil)
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileContent.GetDownloadURL(), nil)
 				if err != nil {
 					return nil, fmt.Errorf("failed to create request: %w", err)
 				}
@@ -205,3 +188,30 @@ func RepositoryResourceContentsHandler(getClient GetClientFn) func(ctx context.C
 		return nil, errors.New("no repository resource content found")
 	}
 }
+
+// resourceContentsRef returns the git ref named by the matched variables of a repository content template,
+// or an empty string for the default branch.
+func resourceContentsRef(arguments map[string]any) string {
+	ref := ""
+
+	sha, ok := arguments["sha"].([]string)
+	if ok && len(sha) > 0 {
+		ref = sha[0]
+	}
+
+	branch, ok := arguments["branch"].([]string)
+	if ok && len(branch) > 0 {
+		ref = "refs/heads/" + branch[0]
+	}
+
+	tag, ok := arguments["tag"].([]string)
+	if ok && len(tag) > 0 {
+		ref = "refs/tags/" + tag[0]
+	}
+	prNumber, ok := arguments["prNumber"].([]string)
+	if ok && len(prNumber) > 0 {
+		ref = "refs/pull/" + prNumber[0] + "/head"
+	}
+
+	return ref
+}
@@ -0,0 +1,600 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RepositoryDetails describes a repository together with the settings a maintainer usually wants to audit.
+type RepositoryDetails struct {
+	Name                     string                   `json:"name"`
+	FullName                 string                   `json:"full_name"`
+	Owner                    string                   `json:"owner"`
+	Description              string                   `json:"description,omitempty"`
+	Homepage                 string                   `json:"homepage,omitempty"`
+	HTMLURL                  string                   `json:"html_url"`
+	Visibility               string                   `json:"visibility"`
+	Fork                     bool                     `json:"fork"`
+	Archived                 bool                     `json:"archived"`
+	IsTemplate               bool                     `json:"is_template"`
+	DefaultBranch            string                   `json:"default_branch"`
+	Topics                   []string                 `json:"topics"`
+	License                  *RepositoryLicense       `json:"license,omitempty"`
+	Features                 RepositoryFeatures       `json:"features"`
+	MergeSettings            RepositoryMergeSettings  `json:"merge_settings"`
+	WebCommitSignoffRequired bool                     `json:"web_commit_signoff_required"`
+	Languages                map[string]int           `json:"languages,omitempty"`
+	CustomProperties         map[string]any           `json:"custom_properties,omitempty"`
+	CreatedAt                string                   `json:"created_at,omitempty"`
+	UpdatedAt                string                   `json:"updated_at,omitempty"`
+	PushedAt                 string                   `json:"pushed_at,omitempty"`
+	Counts                   RepositoryActivityCounts `json:"counts"`
+}
+
+// RepositoryLicense is the license GitHub detected for a repository.
+type RepositoryLicense struct {
+	SPDXID string `json:"spdx_id"`
+	Name   string `json:"name"`
+}
+
+// RepositoryFeatures lists which optional repository features are turned on.
+type RepositoryFeatures struct {
+	HasIssues      bool `json:"has_issues"`
+	HasProjects    bool `json:"has_projects"`
+	HasWiki        bool `json:"has_wiki"`
+	HasDiscussions bool `json:"has_discussions"`
+}
+
+// RepositoryMergeSettings lists how pull requests can be merged into a repository.
+type RepositoryMergeSettings struct {
+	AllowMergeCommit         bool   `json:"allow_merge_commit"`
+	AllowSquashMerge         bool   `json:"allow_squash_merge"`
+	AllowRebaseMerge         bool   `json:"allow_rebase_merge"`
+	AllowAutoMerge           bool   `json:"allow_auto_merge"`
+	AllowUpdateBranch        bool   `json:"allow_update_branch"`
+	DeleteBranchOnMerge      bool   `json:"delete_branch_on_merge"`
+	SquashMergeCommitTitle   string `json:"squash_merge_commit_title,omitempty"`
+	SquashMergeCommitMessage string `json:"squash_merge_commit_message,omitempty"`
+	MergeCommitTitle         string `json:"merge_commit_title,omitempty"`
+	MergeCommitMessage       string `json:"merge_commit_message,omitempty"`
+}
+
+// RepositoryActivityCounts holds the popularity and activity counters of a repository.
+type RepositoryActivityCounts struct {
+	Stars      int `json:"stars"`
+	Watchers   int `json:"watchers"`
+	Forks      int `json:"forks"`
+	OpenIssues int `json:"open_issues"`
+}
+
+func newRepositoryDetails(repository *github.Repository) RepositoryDetails {
+	details := RepositoryDetails{
+		Name:          repository.GetName(),
+		FullName:      repository.GetFullName(),
+		Owner:         repository.GetOwner().GetLogin(),
+		Description:   repository.GetDescription(),
+		Homepage:      repository.GetHomepage(),
+		HTMLURL:       repository.GetHTMLURL(),
+		Visibility:    repository.GetVisibility(),
+		Fork:          repository.GetFork(),
+		Archived:      repository.GetArchived(),
+		IsTemplate:    repository.GetIsTemplate(),
+		DefaultBranch: repository.GetDefaultBranch(),
+		Topics:        repository.Topics,
+		Features: RepositoryFeatures{
+			HasIssues:      repository.GetHasIssues(),
+			HasProjects:    repository.GetHasProjects(),
+			HasWiki:        repository.GetHasWiki(),
+			HasDiscussions: repository.GetHasDiscussions(),
+		},
+		MergeSettings: RepositoryMergeSettings{
+			AllowMergeCommit:         repository.GetAllowMergeCommit(),
+			AllowSquashMerge:         repository.GetAllowSquashMerge(),
+			AllowRebaseMerge:         repository.GetAllowRebaseMerge(),
+			AllowAutoMerge:           repository.GetAllowAutoMerge(),
+			AllowUpdateBranch:        repository.GetAllowUpdateBranch(),
+			DeleteBranchOnMerge:      repository.GetDeleteBranchOnMerge(),
+			SquashMergeCommitTitle:   repository.GetSquashMergeCommitTitle(),
+			SquashMergeCommitMessage: repository.GetSquashMergeCommitMessage(),
+			MergeCommitTitle:         repository.GetMergeCommitTitle(),
+			MergeCommitMessage:       repository.GetMergeCommitMessage(),
+		},
+		WebCommitSignoffRequired: repository.GetWebCommitSignoffRequired(),
+		Counts: RepositoryActivityCounts{
+			Stars:      repository.GetStargazersCount(),
+			Watchers:   repository.GetSubscribersCount(),
+			Forks:      repository.GetForksCount(),
+			OpenIssues: repository.GetOpenIssuesCount(),
+		},
+	}
+
+	// Older GitHub Enterprise Server versions only report whether the repository is private.
+	if details.Visibility == "" {
+		details.Visibility = "public"
+		if repository.GetPrivate() {
+			details.Visibility = "private"
+		}
+	}
+	if details.Topics == nil {
+		details.Topics = []string{}
+	}
+	if license := repository.GetLicense(); license != nil {
+		details.License = &RepositoryLicense{
+			SPDXID: license.GetSPDXID(),
+			Name:   license.GetName(),
+		}
+	}
+	if repository.CreatedAt != nil {
+		details.CreatedAt = repository.CreatedAt.Format(time.RFC3339)
+	}
+	if repository.UpdatedAt != nil {
+		details.UpdatedAt = repository.UpdatedAt.Format(time.RFC3339)
+	}
+	if repository.PushedAt != nil {
+		details.PushedAt = repository.PushedAt.Format(time.RFC3339)
+	}
+
+	return details
+}
+
+// GetRepository creates a tool to get the metadata and settings of a repository.
+func GetRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_DESCRIPTION", "Get the metadata and settings of a GitHub repository: description, topics, default branch, visibility, enabled features, merge settings, languages, license and custom properties")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_USER_TITLE", "Get repository"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get repository: %s", string(body))), nil
+			}
+
+			details := newRepositoryDetails(repository)
+
+			languages, resp, err := client.Repositories.ListLanguages(ctx, owner, repo)
+			closeResponse(resp)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repository languages: %w", err)
+			}
+			details.Languages = languages
+
+			// Custom properties only exist for organization repositories, and reading them
+			// can be forbidden for the current user, in which case they are left out.
+			if repository.GetOwner().GetType() == "Organization" {
+				values, resp, err := client.Repositories.GetAllCustomPropertyValues(ctx, owner, repo)
+				closeResponse(resp)
+				if err == nil {
+					details.CustomProperties = make(map[string]any, len(values))
+					for _, value := range values {
+						details.CustomProperties[value.PropertyName] = value.Value
+					}
+				}
+			}
+
+			return MarshalledTextResult(details), nil
+		}
+}
+
+// repositorySettingParams maps the boolean parameters of update_repository_settings to the repository fields they set.
+var repositorySettingParams = []struct {
+	name        string
+	description string
+	field       func(*github.Repository) **bool
+}{
+	{"has_issues", "Enable issues", func(r *github.Repository) **bool { return &r.HasIssues }},
+	{"has_projects", "Enable projects", func(r *github.Repository) **bool { return &r.HasProjects }},
+	{"has_wiki", "Enable the wiki", func(r *github.Repository) **bool { return &r.HasWiki }},
+	{"has_discussions", "Enable discussions", func(r *github.Repository) **bool { return &r.HasDiscussions }},
+	{"is_template", "Make the repository a template repository", func(r *github.Repository) **bool { return &r.IsTemplate }},
+	{"allow_merge_commit", "Allow merging pull requests with a merge commit", func(r *github.Repository) **bool { return &r.AllowMergeCommit }},
+	{"allow_squash_merge", "Allow squash-merging pull requests", func(r *github.Repository) **bool { return &r.AllowSquashMerge }},
+	{"allow_rebase_merge", "Allow rebase-merging pull requests", func(r *github.Repository) **bool { return &r.AllowRebaseMerge }},
+	{"allow_auto_merge", "Allow auto-merge on pull requests", func(r *github.Repository) **bool { return &r.AllowAutoMerge }},
+	{"allow_update_branch", "Suggest updating pull request branches that are behind the base branch", func(r *github.Repository) **bool { return &r.AllowUpdateBranch }},
+	{"delete_branch_on_merge", "Delete head branches when pull requests are merged", func(r *github.Repository) **bool { return &r.DeleteBranchOnMerge }},
+	{"web_commit_signoff_required", "Require contributors to sign off on commits made through the web interface", func(r *github.Repository) **bool { return &r.WebCommitSignoffRequired }},
+}
+
+// UpdateRepositorySettings creates a tool to change the settings of a repository.
+func UpdateRepositorySettings(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_UPDATE_REPOSITORY_SETTINGS_DESCRIPTION", "Update the settings of a GitHub repository. Only the given settings are changed. Requires admin access to the repository.")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:          t("TOOL_UPDATE_REPOSITORY_SETTINGS_USER_TITLE", "Update repository settings"),
+			ReadOnlyHint:   toBoolPtr(false),
+			IdempotentHint: toBoolPtr(true),
+		}),
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		),
+		mcp.WithString("description",
+			mcp.Description("Short description of the repository"),
+		),
+		mcp.WithString("homepage",
+			mcp.Description("URL of the project homepage"),
+		),
+		mcp.WithString("visibility",
+			mcp.Description("Visibility of the repository. internal is only available for organizations on GitHub Enterprise."),
+			mcp.Enum("public", "private", "internal"),
+		),
+	}
+	for _, setting := range repositorySettingParams {
+		options = append(options, mcp.WithBoolean(setting.name, mcp.Description(setting.description)))
+	}
+
+	return mcp.NewTool("update_repository_settings", options...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			edit := &github.Repository{}
+			changed := false
+			for _, param := range []struct {
+				name  string
+				field **string
+			}{
+				{"description", &edit.Description},
+				{"homepage", &edit.Homepage},
+				{"visibility", &edit.Visibility},
+			} {
+				value, ok, err := OptionalParamOK[string](request, param.name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*param.field = github.Ptr(value)
+					changed = true
+				}
+			}
+			for _, setting := range repositorySettingParams {
+				value, ok, err := OptionalParamOK[bool](request, setting.name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*setting.field(edit) = github.Ptr(value)
+					changed = true
+				}
+			}
+			if !changed {
+				return mcp.NewToolResultError("at least one setting to update must be given"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			return editRepository(ctx, client, owner, repo, edit, "failed to update repository settings")
+		}
+}
+
+// editRepository sends a repository edit and responds with the resulting repository details.
+func editRepository(ctx context.Context, client *github.Client, owner, repo string, edit *github.Repository, errPrefix string) (*mcp.CallToolResult, error) {
+	repository, resp, err := client.Repositories.Edit(ctx, owner, repo, edit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errPrefix, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("%s: %s", errPrefix, string(body))), nil
+	}
+
+	return MarshalledTextResult(newRepositoryDetails(repository)), nil
+}
+
+// SetRepositoryTopics creates a tool to replace the topics of a repository.
+func SetRepositoryTopics(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_repository_topics",
+			mcp.WithDescription(t("TOOL_SET_REPOSITORY_TOPICS_DESCRIPTION", "Replace the topics of a GitHub repository. Topics are lowercase, and may contain letters, numbers and hyphens.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_SET_REPOSITORY_TOPICS_USER_TITLE", "Set repository topics"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("topics",
+				mcp.Required(),
+				mcp.Description("The full list of topics. An empty list removes all topics."),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, ok := request.GetArguments()["topics"]; !ok {
+				return mcp.NewToolResultError("missing required parameter: topics"), nil
+			}
+			topics, err := OptionalStringArrayParam(request, "topics")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			updated, resp, err := client.Repositories.ReplaceAllTopics(ctx, owner, repo, topics)
+			if err != nil {
+				return nil, fmt.Errorf("failed to set repository topics: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to set repository topics: %s", string(body))), nil
+			}
+
+			if updated == nil {
+				updated = []string{}
+			}
+			return MarshalledTextResult(map[string]any{
+				"topics": updated,
+			}), nil
+		}
+}
+
+// RenameDefaultBranch creates a tool to rename the default branch of a repository.
+func RenameDefaultBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("rename_default_branch",
+			mcp.WithDescription(t("TOOL_RENAME_DEFAULT_BRANCH_DESCRIPTION", "Rename the default branch of a GitHub repository. GitHub retargets open pull requests and branch protection to the new name, and redirects the old name. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_RENAME_DEFAULT_BRANCH_USER_TITLE", "Rename default branch"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("new_name",
+				mcp.Required(),
+				mcp.Description("New name of the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newName, err := requiredParam[string](request, "new_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			closeResponse(resp)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			oldName := repository.GetDefaultBranch()
+			if oldName == newName {
+				return mcp.NewToolResultError(fmt.Sprintf("the default branch is already named %s", newName)), nil
+			}
+
+			branch, resp, err := client.Repositories.RenameBranch(ctx, owner, repo, oldName, newName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to rename default branch: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to rename default branch: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"old_name": oldName,
+				"new_name": branch.GetName(),
+			}), nil
+		}
+}
+
+// ArchiveRepository creates a tool to archive a repository, making it read-only.
+func ArchiveRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return setRepositoryArchived(getClient, t, true)
+}
+
+// UnarchiveRepository creates a tool to unarchive a repository.
+func UnarchiveRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return setRepositoryArchived(getClient, t, false)
+}
+
+func setRepositoryArchived(getClient GetClientFn, t translations.TranslationHelperFunc, archived bool) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	name, description, title := "unarchive_repository", t("TOOL_UNARCHIVE_REPOSITORY_DESCRIPTION", "Unarchive a GitHub repository, making it writable again. Requires admin access to the repository."), t("TOOL_UNARCHIVE_REPOSITORY_USER_TITLE", "Unarchive repository")
+	if archived {
+		name, description, title = "archive_repository", t("TOOL_ARCHIVE_REPOSITORY_DESCRIPTION", "Archive a GitHub repository, making it read-only for everyone. It can be unarchived later. Requires admin access to the repository."), t("TOOL_ARCHIVE_REPOSITORY_USER_TITLE", "Archive repository")
+	}
+
+	return mcp.NewTool(name,
+			mcp.WithDescription(description),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          title,
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			return editRepository(ctx, client, owner, repo, &github.Repository{Archived: github.Ptr(archived)}, fmt.Sprintf("failed to %s repository", strings.TrimSuffix(name, "_repository")))
+		}
+}
+
+// DeleteRepository creates a tool to permanently delete a repository.
+func DeleteRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_repository",
+			mcp.WithDescription(t("TOOL_DELETE_REPOSITORY_DESCRIPTION", "Permanently delete a GitHub repository, including its issues, pull requests and wiki. This cannot be undone; consider archive_repository instead. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_REPOSITORY_USER_TITLE", "Delete repository"),
+				ReadOnlyHint:    toBoolPtr(false),
+				DestructiveHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("confirm",
+				mcp.Required(),
+				mcp.Description("The full name of the repository to delete, as owner/repo. Must match owner and repo, as a safeguard against deleting the wrong repository."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			confirm, err := requiredParam[string](request, "confirm")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fullName := owner + "/" + repo
+			if !strings.EqualFold(confirm, fullName) {
+				return mcp.NewToolResultError(fmt.Sprintf("confirm must be %q to delete this repository, got %q", fullName, confirm)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.Delete(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete repository: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete repository: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("deleted repository %s", fullName)), nil
+		}
+}
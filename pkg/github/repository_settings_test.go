@@ -0,0 +1,406 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	orgRepository := &github.Repository{
+		Name:             github.Ptr("repo"),
+		FullName:         github.Ptr("owner/repo"),
+		Owner:            &github.User{Login: github.Ptr("owner"), Type: github.Ptr("Organization")},
+		Description:      github.Ptr("A test repository"),
+		Visibility:       github.Ptr("internal"),
+		DefaultBranch:    github.Ptr("main"),
+		Topics:           []string{"go", "mcp"},
+		License:          &github.License{SPDXID: github.Ptr("MIT"), Name: github.Ptr("MIT License")},
+		HasIssues:        github.Ptr(true),
+		AllowSquashMerge: github.Ptr(true),
+		StargazersCount:  github.Ptr(12),
+	}
+	userRepository := &github.Repository{
+		Name:          github.Ptr("repo"),
+		FullName:      github.Ptr("owner/repo"),
+		Owner:         &github.User{Login: github.Ptr("owner"), Type: github.Ptr("User")},
+		Private:       github.Ptr(true),
+		DefaultBranch: github.Ptr("master"),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectedDetails RepositoryDetails
+	}{
+		{
+			name: "organization repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, orgRepository),
+				mock.WithRequestMatch(mock.GetReposLanguagesByOwnerByRepo, map[string]int{"Go": 12345, "Shell": 100}),
+				mock.WithRequestMatch(mock.GetReposPropertiesValuesByOwnerByRepo, []*github.CustomPropertyValue{
+					{PropertyName: "team", Value: "platform"},
+				}),
+			),
+			expectedDetails: RepositoryDetails{
+				Name:          "repo",
+				FullName:      "owner/repo",
+				Owner:         "owner",
+				Description:   "A test repository",
+				Visibility:    "internal",
+				DefaultBranch: "main",
+				Topics:        []string{"go", "mcp"},
+				License:       &RepositoryLicense{SPDXID: "MIT", Name: "MIT License"},
+				Features:      RepositoryFeatures{HasIssues: true},
+				MergeSettings: RepositoryMergeSettings{AllowSquashMerge: true},
+				Languages:     map[string]int{"Go": 12345, "Shell": 100},
+				CustomProperties: map[string]any{
+					"team": "platform",
+				},
+				Counts: RepositoryActivityCounts{Stars: 12},
+			},
+		},
+		{
+			name: "user repository without custom properties",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, userRepository),
+				mock.WithRequestMatch(mock.GetReposLanguagesByOwnerByRepo, map[string]int{}),
+			),
+			expectedDetails: RepositoryDetails{
+				Name:          "repo",
+				FullName:      "owner/repo",
+				Owner:         "owner",
+				Visibility:    "private",
+				DefaultBranch: "master",
+				Topics:        []string{},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returned RepositoryDetails
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedDetails, returned)
+		})
+	}
+}
+
+func Test_UpdateRepositorySettings(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRepositorySettings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_repository_settings", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.Contains(t, tool.InputSchema.Properties, "visibility")
+	assert.Contains(t, tool.InputSchema.Properties, "has_wiki")
+	assert.Contains(t, tool.InputSchema.Properties, "allow_squash_merge")
+	assert.Contains(t, tool.InputSchema.Properties, "delete_branch_on_merge")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "only the given settings are sent",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"description":            "",
+						"has_wiki":               false,
+						"delete_branch_on_merge": true,
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{
+							Name:                github.Ptr("repo"),
+							FullName:            github.Ptr("owner/repo"),
+							DeleteBranchOnMerge: github.Ptr(true),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":                  "owner",
+				"repo":                   "repo",
+				"description":            "",
+				"has_wiki":               false,
+				"delete_branch_on_merge": true,
+			},
+		},
+		{
+			name:         "no settings",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one setting to update must be given",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRepositorySettings(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Equal(t, tc.expectedErrMsg, textContent.Text)
+				return
+			}
+
+			var returned RepositoryDetails
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.True(t, returned.MergeSettings.DeleteBranchOnMerge)
+		})
+	}
+}
+
+func Test_SetRepositoryTopics(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetRepositoryTopics(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_repository_topics", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "topics")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "topics"})
+
+	tests := []struct {
+		name           string
+		requestTopics  []any
+		expectedBody   map[string]any
+		expectedTopics []string
+	}{
+		{
+			name:           "replace topics",
+			requestTopics:  []any{"go", "mcp"},
+			expectedBody:   map[string]any{"names": []any{"go", "mcp"}},
+			expectedTopics: []string{"go", "mcp"},
+		},
+		{
+			name:           "remove all topics",
+			requestTopics:  []any{},
+			expectedBody:   map[string]any{"names": []any{}},
+			expectedTopics: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, tc.expectedBody).andThen(
+						mockResponse(t, http.StatusOK, map[string]any{"names": tc.expectedTopics}),
+					),
+				),
+			))
+			_, handler := SetRepositoryTopics(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"topics": tc.requestTopics,
+			}))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returned struct {
+				Topics []string `json:"topics"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedTopics, returned.Topics)
+		})
+	}
+}
+
+func Test_RenameDefaultBranch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RenameDefaultBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "rename_default_branch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "new_name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "new_name"})
+
+	repository := &github.Repository{DefaultBranch: github.Ptr("master")}
+
+	t.Run("renames the default branch", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposByOwnerByRepo, repository),
+			mock.WithRequestMatchHandler(
+				mock.PostReposBranchesRenameByOwnerByRepoByBranch,
+				expectPath(t, "/repos/owner/repo/branches/master/rename").andThen(
+					expectRequestBody(t, map[string]any{"new_name": "main"}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Branch{Name: github.Ptr("main")}),
+					),
+				),
+			),
+		))
+		_, handler := RenameDefaultBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":    "owner",
+			"repo":     "repo",
+			"new_name": "main",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		assert.JSONEq(t, `{"old_name": "master", "new_name": "main"}`, textContent.Text)
+	})
+
+	t.Run("already named", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposByOwnerByRepo, repository),
+		))
+		_, handler := RenameDefaultBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":    "owner",
+			"repo":     "repo",
+			"new_name": "master",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Equal(t, "the default branch is already named master", getTextResult(t, result).Text)
+	})
+}
+
+func Test_ArchiveRepository(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		tool     func(GetClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+		archived bool
+	}{
+		{name: "archive_repository", tool: ArchiveRepository, archived: true},
+		{name: "unarchive_repository", tool: UnarchiveRepository, archived: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := github.NewClient(nil)
+			tool, _ := tc.tool(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+			assert.Equal(t, tc.name, tool.Name)
+			assert.NotEmpty(t, tool.Description)
+			assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"archived": tc.archived}).andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{
+							Name:     github.Ptr("repo"),
+							Archived: github.Ptr(tc.archived),
+						}),
+					),
+				),
+			))
+			_, handler := tc.tool(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+
+			var returned RepositoryDetails
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.archived, returned.Archived)
+		})
+	}
+}
+
+func Test_DeleteRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "confirm"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		confirm        string
+		expectError    bool
+		expectedResult string
+	}{
+		{
+			name: "confirmed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposByOwnerByRepo,
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+			confirm:        "Owner/Repo",
+			expectedResult: "deleted repository owner/repo",
+		},
+		{
+			name:           "confirmation does not match",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			confirm:        "owner/other",
+			expectError:    true,
+			expectedResult: `confirm must be "owner/repo" to delete this repository, got "owner/other"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"confirm": tc.confirm,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectError, result.IsError)
+			assert.Equal(t, tc.expectedResult, getTextResult(t, result).Text)
+		})
+	}
+}
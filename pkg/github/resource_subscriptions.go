@@ -0,0 +1,355 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	MethodResourcesSubscribe   = "resources/subscribe"
+	MethodResourcesUnsubscribe = "resources/unsubscribe"
+	MethodResourcesUpdated     = "notifications/resources/updated"
+
+	// DefaultMaxSubscriptionsPerSession is the number of resources a single session may subscribe to at once.
+	DefaultMaxSubscriptionsPerSession = 20
+)
+
+// ResourceSubscriptions tracks which sessions are subscribed to which repo:// resources, and polls
+// GitHub to tell those sessions when a resource changes.
+//
+// Polling uses conditional requests, so a poll that finds nothing has changed does not count
+// against the rate limit.
+type ResourceSubscriptions struct {
+	server        *server.MCPServer
+	getClient     GetClientFn
+	maxPerSession int
+
+	mu       sync.Mutex
+	watches  map[string]*resourceWatch  // by resource URI
+	sessions map[string]map[string]bool // resource URIs by session ID
+	pollMu   sync.Mutex                 // held for a whole poll round so rounds never overlap
+	notify   func(sessionID, uri string) error
+}
+
+// resourceWatch is the set of API endpoints polled for a subscribed resource.
+type resourceWatch struct {
+	owner      string
+	repo       string
+	endpoints  []string
+	pullNumber int    // for pull requests, whose checks are found through the head commit
+	headSHA    string // the pull request head last seen
+	etags      map[string]string
+	sessions   map[string]bool
+}
+
+// NewResourceSubscriptions creates subscription tracking for the resources offered by s.
+// maxPerSession limits how many resources each session may subscribe to.
+func NewResourceSubscriptions(s *server.MCPServer, getClient GetClientFn, maxPerSession int) *ResourceSubscriptions {
+	rs := &ResourceSubscriptions{
+		server:        s,
+		getClient:     getClient,
+		maxPerSession: maxPerSession,
+		watches:       make(map[string]*resourceWatch),
+		sessions:      make(map[string]map[string]bool),
+	}
+	rs.notify = func(sessionID, uri string) error {
+		return rs.server.SendNotificationToSpecificClient(sessionID, MethodResourcesUpdated, map[string]any{"uri": uri})
+	}
+	return rs
+}
+
+// Subscribe subscribes a session to changes to the resource at uri.
+func (rs *ResourceSubscriptions) Subscribe(sessionID, uri string) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if rs.sessions[sessionID][uri] {
+		return nil
+	}
+	if len(rs.sessions[sessionID]) >= rs.maxPerSession {
+		return fmt.Errorf("a session can subscribe to at most %d resources", rs.maxPerSession)
+	}
+
+	watch, ok := rs.watches[uri]
+	if !ok {
+		var err error
+		watch, err = newResourceWatch(uri)
+		if err != nil {
+			return err
+		}
+		rs.watches[uri] = watch
+	}
+	watch.sessions[sessionID] = true
+
+	if rs.sessions[sessionID] == nil {
+		rs.sessions[sessionID] = make(map[string]bool)
+	}
+	rs.sessions[sessionID][uri] = true
+	return nil
+}
+
+// Unsubscribe removes a session's subscription to the resource at uri.
+func (rs *ResourceSubscriptions) Unsubscribe(sessionID, uri string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.unsubscribe(sessionID, uri)
+}
+
+// UnsubscribeAll removes all of a session's subscriptions, e.g. when the session ends.
+func (rs *ResourceSubscriptions) UnsubscribeAll(sessionID string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	for uri := range rs.sessions[sessionID] {
+		rs.unsubscribe(sessionID, uri)
+	}
+}
+
+func (rs *ResourceSubscriptions) unsubscribe(sessionID, uri string) {
+	delete(rs.sessions[sessionID], uri)
+	if len(rs.sessions[sessionID]) == 0 {
+		delete(rs.sessions, sessionID)
+	}
+
+	watch, ok := rs.watches[uri]
+	if !ok {
+		return
+	}
+	delete(watch.sessions, sessionID)
+	if len(watch.sessions) == 0 {
+		delete(rs.watches, uri)
+	}
+}
+
+// HandleMessage answers resources/subscribe and resources/unsubscribe requests, which the MCP server
+// advertises but does not route to a handler itself. It reports whether message was one of them.
+func (rs *ResourceSubscriptions) HandleMessage(_ context.Context, sessionID string, message json.RawMessage) (mcp.JSONRPCMessage, bool) {
+	var request struct {
+		ID     mcp.RequestId `json:"id"`
+		Method string        `json:"method"`
+		Params struct {
+			URI string `json:"uri"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(message, &request); err != nil {
+		return nil, false
+	}
+
+	switch request.Method {
+	case MethodResourcesSubscribe:
+		if err := rs.Subscribe(sessionID, request.Params.URI); err != nil {
+			return mcp.NewJSONRPCError(request.ID, mcp.INVALID_PARAMS, err.Error(), nil), true
+		}
+	case MethodResourcesUnsubscribe:
+		rs.Unsubscribe(sessionID, request.Params.URI)
+	default:
+		return nil, false
+	}
+	return mcp.NewJSONRPCResponse(request.ID, mcp.Result{}), true
+}
+
+// Run polls the subscribed resources every interval until ctx is done.
+func (rs *ResourceSubscriptions) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			rs.Poll(ctx)
+		}
+	}
+}
+
+// Poll checks each subscribed resource once and notifies its sessions if it has changed since the
+// previous poll. The first poll of a resource only records its current state.
+func (rs *ResourceSubscriptions) Poll(ctx context.Context) {
+	rs.pollMu.Lock()
+	defer rs.pollMu.Unlock()
+
+	rs.mu.Lock()
+	watches := make(map[string]*resourceWatch, len(rs.watches))
+	for uri, watch := range rs.watches {
+		watches[uri] = watch
+	}
+	rs.mu.Unlock()
+
+	if len(watches) == 0 {
+		return
+	}
+
+	client, err := rs.getClient(ctx)
+	if err != nil {
+		return
+	}
+
+	for uri, watch := range watches {
+		if ctx.Err() != nil {
+			return
+		}
+		if !watch.poll(ctx, client) {
+			continue
+		}
+
+		rs.mu.Lock()
+		var sessionIDs []string
+		for sessionID := range watch.sessions {
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+		rs.mu.Unlock()
+
+		for _, sessionID := range sessionIDs {
+			if err := rs.notify(sessionID, uri); errors.Is(err, server.ErrSessionNotFound) {
+				rs.UnsubscribeAll(sessionID)
+			}
+		}
+	}
+}
+
+// newResourceWatch works out which endpoints to poll for the resource at uri.
+func newResourceWatch(uri string) (*resourceWatch, error) {
+	for _, resourceTemplate := range resourceTemplateFuncs {
+		tmpl, _ := resourceTemplate(nil, translations.NullTranslationHelper)
+		if !tmpl.URITemplate.Regexp().MatchString(uri) {
+			continue
+		}
+
+		request := mcp.ReadResourceRequest{}
+		request.Params.Arguments = make(map[string]any)
+		for name, value := range tmpl.URITemplate.Match(uri) {
+			request.Params.Arguments[name] = value.V
+		}
+
+		owner, err := resourceArgument(request, "owner")
+		if err != nil {
+			return nil, err
+		}
+		repo, err := resourceArgument(request, "repo")
+		if err != nil {
+			return nil, err
+		}
+		watch := &resourceWatch{
+			owner:    owner,
+			repo:     repo,
+			etags:    make(map[string]string),
+			sessions: make(map[string]bool),
+		}
+
+		repoPath := fmt.Sprintf("repos/%s/%s", url.PathEscape(owner), url.PathEscape(repo))
+		raw := tmpl.URITemplate.Raw()
+		switch {
+		case strings.HasSuffix(raw, "/issues/{number}"):
+			_, _, number, err := resourceIssueArguments(request)
+			if err != nil {
+				return nil, err
+			}
+			watch.endpoints = []string{
+				fmt.Sprintf("%s/issues/%d", repoPath, number),
+				fmt.Sprintf("%s/issues/%d/comments?per_page=100", repoPath, number),
+			}
+		case strings.HasSuffix(raw, "/pulls/{number}"):
+			_, _, number, err := resourceIssueArguments(request)
+			if err != nil {
+				return nil, err
+			}
+			watch.pullNumber = number
+			watch.endpoints = []string{
+				fmt.Sprintf("%s/pulls/%d", repoPath, number),
+				fmt.Sprintf("%s/pulls/%d/reviews?per_page=100", repoPath, number),
+				fmt.Sprintf("%s/pulls/%d/comments?per_page=100", repoPath, number),
+				fmt.Sprintf("%s/issues/%d/comments?per_page=100", repoPath, number),
+			}
+		default:
+			if number, ok := request.Params.Arguments["number"]; ok {
+				request.Params.Arguments["prNumber"] = number
+			}
+			path := ""
+			if p, ok := request.Params.Arguments["path"].([]string); ok {
+				path = strings.Join(p, "/")
+			}
+			endpoint := fmt.Sprintf("%s/contents/%s", repoPath, path)
+			if ref := resourceContentsRef(request.Params.Arguments); ref != "" {
+				endpoint += "?ref=" + url.QueryEscape(ref)
+			}
+			watch.endpoints = []string{endpoint}
+		}
+		return watch, nil
+	}
+	return nil, fmt.Errorf("resource %s is not a GitHub resource that can be subscribed to", uri)
+}
+
+// poll makes a conditional request to each of the watch's endpoints and reports whether any of
+// them has changed. An endpoint that fails is retried on the next poll.
+func (w *resourceWatch) poll(ctx context.Context, client *github.Client) bool {
+	endpoints := w.endpoints
+	if w.headSHA != "" {
+		// Check results belong to the head commit rather than the pull request
+		repoPath := fmt.Sprintf("repos/%s/%s", url.PathEscape(w.owner), url.PathEscape(w.repo))
+		endpoints = append(endpoints[:len(endpoints):len(endpoints)],
+			fmt.Sprintf("%s/commits/%s/status", repoPath, w.headSHA),
+			fmt.Sprintf("%s/commits/%s/check-runs?per_page=100", repoPath, w.headSHA),
+		)
+	}
+
+	changed := false
+	for _, endpoint := range endpoints {
+		req, err := client.NewRequest(http.MethodGet, endpoint, nil)
+		if err != nil {
+			continue
+		}
+		previous, seen := w.etags[endpoint]
+		if previous != "" {
+			req.Header.Set("If-None-Match", previous)
+		}
+
+		resp, err := client.Client().Do(req.WithContext(ctx))
+		if err != nil {
+			continue
+		}
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil || resp.StatusCode != http.StatusOK {
+			continue
+		}
+
+		etag := resp.Header.Get("ETag")
+		if etag == "" {
+			sum := sha256.Sum256(body)
+			etag = hex.EncodeToString(sum[:])
+		}
+		w.etags[endpoint] = etag
+		if seen && etag != previous {
+			changed = true
+		}
+
+		if w.pullNumber != 0 && endpoint == w.endpoints[0] {
+			var pr struct {
+				Head struct {
+					SHA string `json:"sha"`
+				} `json:"head"`
+			}
+			if err := json.Unmarshal(body, &pr); err == nil && pr.Head.SHA != w.headSHA {
+				for _, stale := range endpoints[len(w.endpoints):] {
+					delete(w.etags, stale)
+				}
+				w.headSHA = pr.Head.SHA
+			}
+		}
+	}
+	return changed
+}
@@ -0,0 +1,172 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newResourceWatch(t *testing.T) {
+	tests := []struct {
+		name              string
+		uri               string
+		expectedEndpoints []string
+		expectError       string
+	}{
+		{
+			name:              "repository content",
+			uri:               "repo://owner/repo/contents/docs/README.md",
+			expectedEndpoints: []string{"repos/owner/repo/contents/docs/README.md"},
+		},
+		{
+			name:              "branch content",
+			uri:               "repo://owner/repo/refs/heads/main/contents/README.md",
+			expectedEndpoints: []string{"repos/owner/repo/contents/README.md?ref=refs%2Fheads%2Fmain"},
+		},
+		{
+			name:              "pull request file",
+			uri:               "repo://owner/repo/pulls/42/files/main.go",
+			expectedEndpoints: []string{"repos/owner/repo/contents/main.go?ref=refs%2Fpull%2F42%2Fhead"},
+		},
+		{
+			name: "issue",
+			uri:  "repo://owner/repo/issues/7",
+			expectedEndpoints: []string{
+				"repos/owner/repo/issues/7",
+				"repos/owner/repo/issues/7/comments?per_page=100",
+			},
+		},
+		{
+			name: "pull request",
+			uri:  "repo://owner/repo/pulls/42",
+			expectedEndpoints: []string{
+				"repos/owner/repo/pulls/42",
+				"repos/owner/repo/pulls/42/reviews?per_page=100",
+				"repos/owner/repo/pulls/42/comments?per_page=100",
+				"repos/owner/repo/issues/42/comments?per_page=100",
+			},
+		},
+		{
+			name:        "not a repository resource",
+			uri:         "file:///etc/passwd",
+			expectError: "resource file:///etc/passwd is not a GitHub resource that can be subscribed to",
+		},
+		{
+			name:        "invalid issue number",
+			uri:         "repo://owner/repo/issues/abc",
+			expectError: "number must be a positive integer",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			watch, err := newResourceWatch(tc.uri)
+			if tc.expectError != "" {
+				require.EqualError(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedEndpoints, watch.endpoints)
+		})
+	}
+}
+
+func Test_ResourceSubscriptions_HandleMessage(t *testing.T) {
+	rs := NewResourceSubscriptions(nil, nil, 2)
+
+	handle := func(method, uri string) mcp.JSONRPCMessage {
+		message, err := json.Marshal(map[string]any{
+			"jsonrpc": mcp.JSONRPC_VERSION,
+			"id":      1,
+			"method":  method,
+			"params":  map[string]any{"uri": uri},
+		})
+		require.NoError(t, err)
+		response, handled := rs.HandleMessage(context.Background(), "session", message)
+		require.True(t, handled)
+		return response
+	}
+
+	assert.IsType(t, mcp.JSONRPCResponse{}, handle(MethodResourcesSubscribe, "repo://owner/repo/issues/1"))
+	// Subscribing again does not count against the limit
+	assert.IsType(t, mcp.JSONRPCResponse{}, handle(MethodResourcesSubscribe, "repo://owner/repo/issues/1"))
+	assert.IsType(t, mcp.JSONRPCResponse{}, handle(MethodResourcesSubscribe, "repo://owner/repo/pulls/2"))
+
+	response := handle(MethodResourcesSubscribe, "repo://owner/repo/issues/3")
+	require.IsType(t, mcp.JSONRPCError{}, response)
+	assert.Equal(t, "a session can subscribe to at most 2 resources", response.(mcp.JSONRPCError).Error.Message)
+
+	// Another session has its own limit
+	require.NoError(t, rs.Subscribe("other", "repo://owner/repo/issues/3"))
+
+	assert.IsType(t, mcp.JSONRPCResponse{}, handle(MethodResourcesUnsubscribe, "repo://owner/repo/pulls/2"))
+	assert.IsType(t, mcp.JSONRPCResponse{}, handle(MethodResourcesSubscribe, "repo://owner/repo/issues/3"))
+	assert.Len(t, rs.watches, 2)
+
+	rs.UnsubscribeAll("session")
+	assert.Len(t, rs.watches, 1)
+	assert.Contains(t, rs.watches, "repo://owner/repo/issues/3")
+
+	_, handled := rs.HandleMessage(context.Background(), "session", json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"resources/list"}`))
+	assert.False(t, handled)
+}
+
+func Test_ResourceSubscriptions_Poll(t *testing.T) {
+	var issueVersion atomic.Int32
+	conditionalResponse := func(etag func() string, body any) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("If-None-Match") == etag() {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", etag())
+			mockResponse(t, http.StatusOK, body)(w, r)
+		}
+	}
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+			conditionalResponse(func() string {
+				return fmt.Sprintf(`"issue-%d"`, issueVersion.Load())
+			}, &github.Issue{Number: github.Ptr(7)}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+			conditionalResponse(func() string { return `"comments"` }, []*github.IssueComment{}),
+		),
+	)
+
+	rs := NewResourceSubscriptions(nil, stubGetClientFn(github.NewClient(mockedClient)), DefaultMaxSubscriptionsPerSession)
+	var notified []string
+	rs.notify = func(sessionID, uri string) error {
+		notified = append(notified, sessionID+" "+uri)
+		return nil
+	}
+
+	require.NoError(t, rs.Subscribe("watcher", "repo://owner/repo/issues/7"))
+	require.NoError(t, rs.Subscribe("bystander", "repo://owner/repo/contents/README.md"))
+
+	// The first poll only records the current state
+	rs.Poll(context.Background())
+	assert.Empty(t, notified)
+
+	rs.Poll(context.Background())
+	assert.Empty(t, notified)
+
+	issueVersion.Store(1)
+	rs.Poll(context.Background())
+	assert.Equal(t, []string{"watcher repo://owner/repo/issues/7"}, notified)
+
+	rs.Poll(context.Background())
+	assert.Len(t, notified, 1)
+}
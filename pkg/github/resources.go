@@ -2,13 +2,24 @@ package github
 
 import (
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// resourceTemplateFuncs are the resource templates the server offers, in the order they are matched.
+var resourceTemplateFuncs = []func(GetClientFn, translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc){
+	GetRepositoryResourceContent,
+	GetRepositoryResourceBranchContent,
+	GetRepositoryResourceCommitContent,
+	GetRepositoryResourceTagContent,
+	GetRepositoryResourcePrContent,
+	GetIssueResource,
+	GetPullRequestResource,
+	GetPullRequestResourceFileContent,
+}
+
 func RegisterResources(s *server.MCPServer, getClient GetClientFn, t translations.TranslationHelperFunc) {
-	s.AddResourceTemplate(GetRepositoryResourceContent(getClient, t))
-	s.AddResourceTemplate(GetRepositoryResourceBranchContent(getClient, t))
-	s.AddResourceTemplate(GetRepositoryResourceCommitContent(getClient, t))
-	s.AddResourceTemplate(GetRepositoryResourceTagContent(getClient, t))
-	s.AddResourceTemplate(GetRepositoryResourcePrContent(getClient, t))
+	for _, resourceTemplate := range resourceTemplateFuncs {
+		s.AddResourceTemplate(resourceTemplate(getClient, t))
+	}
 }
@@ -0,0 +1,428 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultMaxResponseSize is the default limit, in bytes, on the JSON a tool returns. Results are not
+// limited unless a limit is configured.
+const DefaultMaxResponseSize = 0
+
+// minStringLimit is the length long strings are shortened to before items are dropped from a list.
+const minStringLimit = 500
+
+const truncatedMarker = "… [truncated]"
+
+// WithFieldSelection adds a "fields" parameter to a tool, so that callers can ask for only the
+// fields of the result they need. It must come after mcp.WithOutputSchema, since the fields a
+// caller leaves out can no longer be required by the output schema.
+func WithFieldSelection() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithArray("fields",
+			mcp.Description("Optional: only return these fields of each result, e.g. [\"number\", \"title\", \"user.login\"]. Use dots for nested fields"),
+			mcp.Items(map[string]any{
+				"type": "string",
+			}),
+		)(tool)
+
+		if len(tool.RawOutputSchema) == 0 {
+			return
+		}
+		var schema map[string]any
+		if err := json.Unmarshal(tool.RawOutputSchema, &schema); err != nil {
+			return
+		}
+		removeRequired(schema)
+		if raw, err := json.Marshal(schema); err == nil {
+			tool.RawOutputSchema = raw
+		}
+	}
+}
+
+func removeRequired(schema map[string]any) {
+	delete(schema, "required")
+	if properties, ok := schema["properties"].(map[string]any); ok {
+		for _, property := range properties {
+			if property, ok := property.(map[string]any); ok {
+				removeRequired(property)
+			}
+		}
+	}
+	if items, ok := schema["items"].(map[string]any); ok {
+		removeRequired(items)
+	}
+}
+
+// ResponseShaper trims tool results down to the fields a caller asked for, and to the server's
+// size limit. Results that are too large first have their long strings shortened and then, if
+// they are lists, lose items from the end, with a note telling the caller how to get the rest.
+type ResponseShaper struct {
+	maxSize   int
+	paginated map[string]bool // by tool name
+}
+
+// NewResponseShaper creates a ResponseShaper that keeps results within maxSize bytes, or does not
+// limit them if maxSize is 0. The tools in tsg are used to tell which tools take a page.
+func NewResponseShaper(maxSize int, tsg *toolsets.ToolsetGroup) *ResponseShaper {
+	paginated := make(map[string]bool)
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			_, hasPage := tool.Tool.InputSchema.Properties["page"]
+			_, hasPerPage := tool.Tool.InputSchema.Properties["perPage"]
+			_, hasPerPageAlias := tool.Tool.InputSchema.Properties["per_page"]
+			paginated[tool.Tool.Name] = hasPage && (hasPerPage || hasPerPageAlias)
+		}
+	}
+	return &ResponseShaper{maxSize: maxSize, paginated: paginated}
+}
+
+// Middleware shapes the results of the tool handler it wraps.
+func (s *ResponseShaper) Middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		fields, err := OptionalStringArrayParam(request, "fields")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		result, err := next(ctx, request)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+
+		shaped, stats := s.shape(result, newFieldTree(fields), listFieldOf(request.Params.Name))
+		next, _ := shaped.Meta["nextCursor"].(string)
+		if cursor, ok := parsePageCursor(next); ok && cursor.After != "" {
+			// Results paged by GraphQL cursor can't be continued from the middle of a page
+			return shaped, nil
+		}
+		if s.paginated[request.Params.Name] && stats.shown < stats.total {
+			// The rest of the results start right after the ones shown
+			pageRequest, skip, err := withPageCursor(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(pageRequest)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			offset := (pagination.page-1)*pagination.perPage + skip + stats.shown
+			setNextCursor(shaped, cursorAt(offset, pagination.perPage).String())
+		}
+		return shaped, nil
+	}
+}
+
+func (s *ResponseShaper) shape(result *mcp.CallToolResult, fields fieldTree, listField string) (*mcp.CallToolResult, shapingStats) {
+	var note string
+	var shaping *resultShaping
+	var listStats shapingStats
+	content := make([]mcp.Content, 0, len(result.Content)+1)
+	for _, c := range result.Content {
+		text, ok := c.(mcp.TextContent)
+		if !ok {
+			content = append(content, c)
+			continue
+		}
+
+		value, err := decodeJSON([]byte(text.Text))
+		if err != nil {
+			// Not JSON, so all that can be done is to cut it short
+			if s.maxSize > 0 && len(text.Text) > s.maxSize {
+				text.Text = shortenString(text.Text, s.maxSize)
+				note = "The result was too long, so it was cut short."
+			}
+			content = append(content, text)
+			continue
+		}
+
+		if shaping == nil {
+			shaping = fitResult(value, fields, listField, s.maxSize)
+		}
+		if shaping.isNoop() {
+			content = append(content, text)
+			continue
+		}
+		shaped, stats := shaping.apply(value)
+		data, err := json.Marshal(shaped)
+		if err != nil {
+			content = append(content, text)
+			continue
+		}
+		text.Text = string(data)
+		content = append(content, text)
+		if n := stats.note(); n != "" {
+			note = n
+			listStats = stats
+		}
+	}
+
+	shaped := *result
+	if shaping != nil && !shaping.isNoop() && result.StructuredContent != nil {
+		if data, err := json.Marshal(result.StructuredContent); err == nil {
+			if value, err := decodeJSON(data); err == nil {
+				structured, _ := shaping.apply(value)
+				if object, ok := structured.(map[string]any); ok && note != "" {
+					object["truncated"] = note
+				}
+				shaped.StructuredContent = structured
+			}
+		}
+	}
+	if note != "" {
+		content = append(content, mcp.NewTextContent(note))
+	}
+	shaped.Content = content
+	return &shaped, listStats
+}
+
+// fieldTree is the set of fields a caller asked for. A nil subtree selects the whole value.
+type fieldTree map[string]fieldTree
+
+func newFieldTree(paths []string) fieldTree {
+	if len(paths) == 0 {
+		return nil
+	}
+	tree := fieldTree{}
+	for _, path := range paths {
+		node := tree
+		parts := strings.Split(path, ".")
+		for i, part := range parts {
+			subtree, seen := node[part]
+			if seen && subtree == nil {
+				// The whole value is already selected
+				break
+			}
+			if i == len(parts)-1 {
+				node[part] = nil
+				break
+			}
+			if subtree == nil {
+				subtree = fieldTree{}
+				node[part] = subtree
+			}
+			node = subtree
+		}
+	}
+	return tree
+}
+
+func (f fieldTree) project(v any) any {
+	if f == nil {
+		return v
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		projected := make(map[string]any, len(f))
+		for name, subtree := range f {
+			if value, ok := v[name]; ok {
+				projected[name] = subtree.project(value)
+			}
+		}
+		return projected
+	case []any:
+		projected := make([]any, len(v))
+		for i, item := range v {
+			projected[i] = f.project(item)
+		}
+		return projected
+	default:
+		return v
+	}
+}
+
+// resultShaping is how a result is cut down: which fields are kept, how long strings may be, and
+// how many items of a list are kept.
+type resultShaping struct {
+	fields      fieldTree
+	listField   string
+	stringLimit int // 0 for no limit
+	itemLimit   int // -1 for no limit
+}
+
+type shapingStats struct {
+	shown, total int
+	shortened    int
+}
+
+func (s *resultShaping) isNoop() bool {
+	return s.fields == nil && s.stringLimit == 0 && s.itemLimit < 0
+}
+
+func (s *resultShaping) apply(v any) (any, shapingStats) {
+	var stats shapingStats
+	items, isList := listItems(v, s.listField)
+	if !isList {
+		return shortenStrings(s.fields.project(v), s.stringLimit, &stats.shortened), stats
+	}
+
+	stats.total = len(items)
+	if s.itemLimit >= 0 && len(items) > s.itemLimit {
+		items = items[:s.itemLimit]
+	}
+	stats.shown = len(items)
+	shaped := make([]any, len(items))
+	for i, item := range items {
+		shaped[i] = shortenStrings(s.fields.project(item), s.stringLimit, &stats.shortened)
+	}
+	return withListItems(v, s.listField, shaped), stats
+}
+
+// fitResult works out how v has to be shaped to keep only fields and fit in maxSize bytes. If v
+// is a list, its items are in listField.
+func fitResult(v any, fields fieldTree, listField string, maxSize int) *resultShaping {
+	s := &resultShaping{fields: fields, listField: listField, itemLimit: -1}
+	fits := func() bool {
+		shaped, _ := s.apply(v)
+		return jsonSize(shaped) <= maxSize
+	}
+	if maxSize <= 0 || fits() {
+		return s
+	}
+
+	for limit := maxSize / 2; limit >= minStringLimit; limit /= 2 {
+		s.stringLimit = limit
+		if fits() {
+			return s
+		}
+	}
+	s.stringLimit = minStringLimit
+
+	items, isList := listItems(v, listField)
+	if !isList || len(items) == 0 {
+		return s
+	}
+	// Keep as many items as fit, but always at least one
+	n := sort.Search(len(items)+1, func(n int) bool {
+		s.itemLimit = n
+		return !fits()
+	}) - 1
+	s.itemLimit = max(n, 1)
+	return s
+}
+
+func (s shapingStats) note() string {
+	var notes []string
+	if s.shown < s.total {
+		notes = append(notes, fmt.Sprintf("Truncated: showing the first %d of %d results to stay within the response size limit.", s.shown, s.total))
+	}
+	if s.shortened > 0 {
+		notes = append(notes, fmt.Sprintf("%d long text fields were shortened and end with %q.", s.shortened, truncatedMarker))
+	}
+	return strings.Join(notes, " ")
+}
+
+// listFields names the field holding the list of the tools whose results are objects with their
+// items somewhere other than "items".
+var listFields = map[string]string{
+	"get_issue_timeline": "events",
+	"list_reactions":     "reactions",
+}
+
+// listFieldOf returns the field of the given tool's results that holds its list of items.
+func listFieldOf(tool string) string {
+	if field, ok := listFields[tool]; ok {
+		return field
+	}
+	return "items"
+}
+
+// listItems returns the items of a list result, which is either a JSON array or an object with
+// an array in field. Other objects, such as a single issue with its labels, are not lists.
+func listItems(v any, field string) ([]any, bool) {
+	switch v := v.(type) {
+	case []any:
+		return v, true
+	case map[string]any:
+		items, ok := v[field].([]any)
+		return items, ok
+	default:
+		return nil, false
+	}
+}
+
+func withListItems(v any, field string, items []any) any {
+	object, ok := v.(map[string]any)
+	if !ok {
+		return items
+	}
+	if _, ok := object[field].([]any); !ok {
+		return v
+	}
+	shaped := make(map[string]any, len(object))
+	for k, value := range object {
+		shaped[k] = value
+	}
+	shaped[field] = items
+	return shaped
+}
+
+func shortenStrings(v any, limit int, shortened *int) any {
+	if limit <= 0 {
+		return v
+	}
+	switch v := v.(type) {
+	case string:
+		if len(v) > limit {
+			*shortened++
+			return shortenString(v, limit)
+		}
+		return v
+	case map[string]any:
+		shaped := make(map[string]any, len(v))
+		for k, value := range v {
+			shaped[k] = shortenStrings(value, limit, shortened)
+		}
+		return shaped
+	case []any:
+		shaped := make([]any, len(v))
+		for i, value := range v {
+			shaped[i] = shortenStrings(value, limit, shortened)
+		}
+		return shaped
+	default:
+		return v
+	}
+}
+
+// shortenString cuts s without splitting a character and marks it, so that it is at most limit
+// bytes with the marker.
+func shortenString(s string, limit int) string {
+	cut := max(limit-len(truncatedMarker), 0)
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + truncatedMarker
+}
+
+func decodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	switch v.(type) {
+	case map[string]any, []any:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("not a JSON object or array")
+	}
+}
+
+func jsonSize(v any) int {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
@@ -0,0 +1,202 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithFieldSelection(t *testing.T) {
+	tool := mcp.NewTool("list_things",
+		mcp.WithOutputSchema[MinimalList[MinimalIssue]](),
+		WithFieldSelection(),
+	)
+
+	assert.Contains(t, tool.InputSchema.Properties, "fields")
+
+	// Fields left out of a result can no longer be required by its schema
+	assert.NotContains(t, string(tool.RawOutputSchema), `"required"`)
+}
+
+func Test_ResponseShaper(t *testing.T) {
+	issues := make([]map[string]any, 10)
+	for i := range issues {
+		issues[i] = map[string]any{
+			"number": i + 1,
+			"title":  fmt.Sprintf("Issue %d", i+1),
+			"body":   strings.Repeat("x", 200),
+			"user":   map[string]any{"login": "octocat", "id": 1},
+		}
+	}
+	listResult := func() *mcp.CallToolResult {
+		r, _ := json.Marshal(issues)
+		return mcp.NewToolResultText(string(r))
+	}
+
+	tests := []struct {
+		name          string
+		maxSize       int
+		args          map[string]any
+		result        *mcp.CallToolResult
+		expectedItems int
+		expectedKeys  []string
+		expectedNotes []string
+	}{
+		{
+			name:          "leaves small results alone",
+			maxSize:       50000,
+			args:          map[string]any{},
+			result:        listResult(),
+			expectedItems: 10,
+			expectedKeys:  []string{"body", "number", "title", "user"},
+		},
+		{
+			name:          "keeps only the selected fields",
+			maxSize:       DefaultMaxResponseSize,
+			args:          map[string]any{"fields": []any{"number", "user.login"}},
+			result:        listResult(),
+			expectedItems: 10,
+			expectedKeys:  []string{"number", "user"},
+		},
+		{
+			name:          "does not limit results by default",
+			maxSize:       DefaultMaxResponseSize,
+			args:          map[string]any{"page": float64(1), "perPage": float64(10)},
+			result:        listResult(),
+			expectedItems: 10,
+			expectedKeys:  []string{"body", "number", "title", "user"},
+		},
+		{
+			name:          "drops items that do not fit and says which page has the rest",
+			maxSize:       1000,
+			args:          map[string]any{"page": float64(1), "perPage": float64(10)},
+			result:        listResult(),
+			expectedItems: 3,
+			expectedKeys:  []string{"body", "number", "title", "user"},
+			expectedNotes: []string{
+				"Truncated: showing the first 3 of 10 results to stay within the response size limit.",
+				pageNote(pageCursor{Page: 1, PerPage: 10, Skip: 3}.String()),
+			},
+		},
+		{
+			name:          "cuts text that is not JSON",
+			maxSize:       100,
+			args:          map[string]any{},
+			result:        mcp.NewToolResultText(strings.Repeat("y", 500)),
+			expectedNotes: []string{"The result was too long, so it was cut short."},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			shaper := &ResponseShaper{maxSize: tc.maxSize, paginated: map[string]bool{"list_things": true}}
+			handler := shaper.Middleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return tc.result, nil
+			})
+
+			request := createMCPRequest(tc.args)
+			request.Params.Name = "list_things"
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			require.NotEmpty(t, result.Content)
+
+			text, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			notes := make([]string, 0, len(result.Content)-1)
+			for _, c := range result.Content[1:] {
+				notes = append(notes, c.(mcp.TextContent).Text)
+			}
+			assert.ElementsMatch(t, tc.expectedNotes, notes)
+
+			if tc.expectedKeys == nil {
+				assert.LessOrEqual(t, len(text.Text), tc.maxSize)
+				assert.True(t, strings.HasSuffix(text.Text, truncatedMarker))
+				return
+			}
+			var returned []map[string]any
+			require.NoError(t, json.Unmarshal([]byte(text.Text), &returned))
+			require.Len(t, returned, tc.expectedItems)
+			for _, item := range returned {
+				keys := make([]string, 0, len(item))
+				for k := range item {
+					keys = append(keys, k)
+				}
+				assert.ElementsMatch(t, tc.expectedKeys, keys)
+			}
+		})
+	}
+}
+
+func Test_ResponseShaper_ShortensLongStrings(t *testing.T) {
+	issue := map[string]any{"number": 1, "body": strings.Repeat("é", 2000)}
+	r, err := json.Marshal(issue)
+	require.NoError(t, err)
+
+	shaper := &ResponseShaper{maxSize: 1500}
+	handler := shaper.Middleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultStructured(issue, string(r)), nil
+	})
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	require.Len(t, result.Content, 2)
+
+	var returned map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &returned))
+	body := returned["body"].(string)
+	assert.True(t, strings.HasSuffix(body, truncatedMarker))
+	assert.LessOrEqual(t, len(body), 1500/2)
+	assert.Equal(t, fmt.Sprintf("1 long text fields were shortened and end with %q.", truncatedMarker), result.Content[1].(mcp.TextContent).Text)
+
+	// The structured result is shaped the same way, and says it was truncated
+	structured, ok := result.StructuredContent.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, body, structured["body"])
+	assert.Contains(t, structured, "truncated")
+}
+
+func Test_ShortenString(t *testing.T) {
+	for _, limit := range []int{0, 5, len(truncatedMarker), 16, 17, 100} {
+		out := shortenString(strings.Repeat("é", 100), limit)
+		assert.True(t, strings.HasSuffix(out, truncatedMarker))
+		assert.True(t, utf8.ValidString(out))
+		if limit >= len(truncatedMarker) {
+			assert.LessOrEqual(t, len(out), limit)
+		}
+	}
+}
+
+func Test_ResponseShaper_SingleObjectWithArrayField(t *testing.T) {
+	// A single issue whose only array field is its labels is not a list of labels
+	issue := map[string]any{
+		"number": 7,
+		"title":  "t",
+		"body":   "b",
+		"state":  "open",
+		"labels": []any{map[string]any{"name": "bug"}},
+	}
+	r, err := json.Marshal(issue)
+	require.NoError(t, err)
+
+	shaper := &ResponseShaper{maxSize: DefaultMaxResponseSize}
+	handler := shaper.Middleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(string(r)), nil
+	})
+
+	request := createMCPRequest(map[string]any{"fields": []any{"number", "title"}})
+	request.Params.Name = "get_issue"
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+
+	var returned map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, map[string]any{"number": float64(7), "title": "t"}, returned)
+}
@@ -156,7 +156,9 @@ func Test_ListSecretScanningAlerts(t *testing.T) {
 				mock.WithRequestMatchHandler(
 					mock.GetReposSecretScanningAlertsByOwnerByRepo,
 					expectQueryParams(t, map[string]string{
-						"state": "resolved",
+						"state":    "resolved",
+						"page":     "1",
+						"per_page": "30",
 					}).andThen(
 						mockResponse(t, http.StatusOK, []*github.SecretScanningAlert{&resolvedAlert}),
 					),
@@ -175,7 +177,10 @@ func Test_ListSecretScanningAlerts(t *testing.T) {
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
 					mock.GetReposSecretScanningAlertsByOwnerByRepo,
-					expectQueryParams(t, map[string]string{}).andThen(
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
 						mockResponse(t, http.StatusOK, []*github.SecretScanningAlert{&resolvedAlert, &openAlert}),
 					),
 				),
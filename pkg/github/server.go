@@ -17,7 +17,9 @@ func NewServer(version string, opts ...server.ServerOption) *server.MCPServer {
 	defaultOpts := []server.ServerOption{
 		server.WithToolCapabilities(true),
 		server.WithResourceCapabilities(true, true),
+		server.WithPromptCapabilities(true),
 		server.WithLogging(),
+		server.WithToolHandlerMiddleware(CancellationMiddleware),
 	}
 	opts = append(defaultOpts, opts...)
 
@@ -174,8 +176,39 @@ func OptionalStringArrayParam(r mcp.CallToolRequest, p string) ([]string, error)
 	}
 }
 
+// OptionalIntArrayParam is a helper function that can be used to fetch a requested parameter from the request.
+// It does the following checks:
+// 1. Checks if the parameter is present in the request, if not, it returns its zero-value
+// 2. If it is present, iterates the elements and checks each is a whole number
+func OptionalIntArrayParam(r mcp.CallToolRequest, p string) ([]int, error) {
+	// Check if the parameter is present in the request
+	if _, ok := r.GetArguments()[p]; !ok {
+		return []int{}, nil
+	}
+
+	switch v := r.GetArguments()[p].(type) {
+	case nil:
+		return []int{}, nil
+	case []int:
+		return v, nil
+	case []any:
+		intSlice := make([]int, len(v))
+		for i, v := range v {
+			f, ok := v.(float64)
+			if !ok || f != float64(int(f)) {
+				return []int{}, fmt.Errorf("parameter %s is not of type int, is %T", p, v)
+			}
+			intSlice[i] = int(f)
+		}
+		return intSlice, nil
+	default:
+		return []int{}, fmt.Errorf("parameter %s could not be coerced to []int, is %T", p, r.GetArguments()[p])
+	}
+}
+
 // WithPagination returns a ToolOption that adds "page" and "perPage" parameters to the tool.
 // The "page" parameter is optional, min 1. The "perPage" parameter is optional, min 1, max 100.
+// It also adds the cursor parameters of WithCursorPagination.
 func WithPagination() mcp.ToolOption {
 	return func(tool *mcp.Tool) {
 		mcp.WithNumber("page",
@@ -188,6 +221,8 @@ func WithPagination() mcp.ToolOption {
 			mcp.Min(1),
 			mcp.Max(100),
 		)(tool)
+
+		WithCursorPagination()(tool)
 	}
 }
 
@@ -197,16 +232,17 @@ type PaginationParams struct {
 }
 
 // OptionalPaginationParams returns the "page" and "perPage" parameters from the request,
-// or their default values if not present, "page" default is 1, "perPage" default is 30.
-// In future, we may want to make the default values configurable, or even have this
-// function returned from `withPagination`, where the defaults are provided alongside
-// the min/max values.
+// or their default values if not present, "page" default is 1, "perPage" default is 30
+// unless the tool has its own default page size. "per_page" is accepted in place of
+// "perPage", for the tools that took it before the shared pagination parameters existed.
+// In future, we may want to have this function returned from `withPagination`, where
+// the defaults are provided alongside the min/max values.
 func OptionalPaginationParams(r mcp.CallToolRequest) (PaginationParams, error) {
 	page, err := OptionalIntParamWithDefault(r, "page", 1)
 	if err != nil {
 		return PaginationParams{}, err
 	}
-	perPage, err := OptionalIntParamWithDefault(r, "perPage", 30)
+	perPage, err := OptionalIntParamWithDefault(r, pageSizeParam(r), defaultPageSizeOf(r.Params.Name))
 	if err != nil {
 		return PaginationParams{}, err
 	}
@@ -0,0 +1,391 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+const (
+	// BM25 parameters, using the usual defaults.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+	// titleWeight is how much more a term in a title counts than one in a body, both in the draft and in candidates.
+	titleWeight = 2
+	// similarIssuesPerSearch is how many results are fetched for every derived search query.
+	similarIssuesPerSearch = 30
+)
+
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}_]+`)
+
+// similarityStopWords are words too common in issues to tell them apart.
+var similarityStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "are": true, "but": true, "not": true, "you": true, "all": true,
+	"any": true, "can": true, "had": true, "her": true, "was": true, "one": true, "our": true, "out": true,
+	"has": true, "have": true, "his": true, "how": true, "its": true, "may": true, "new": true, "now": true,
+	"see": true, "two": true, "who": true, "did": true, "get": true, "got": true, "let": true, "use": true,
+	"used": true, "using": true, "this": true, "that": true, "with": true, "from": true, "they": true,
+	"will": true, "would": true, "there": true, "their": true, "what": true, "when": true, "where": true,
+	"which": true, "while": true, "about": true, "into": true, "than": true, "then": true, "them": true,
+	"these": true, "those": true, "some": true, "such": true, "only": true, "also": true, "just": true,
+	"like": true, "been": true, "being": true, "were": true, "does": true, "doesn": true, "don": true,
+	"isn": true, "cannot": true, "should": true, "could": true, "after": true, "before": true,
+	"because": true, "here": true, "more": true, "most": true, "other": true, "same": true, "very": true,
+	"each": true, "both": true, "over": true, "under": true, "again": true, "still": true, "even": true,
+	"please": true, "thanks": true, "thank": true, "issue": true, "problem": true, "happens": true,
+	"steps": true, "reproduce": true, "expected": true, "actual": true, "behavior": true, "behaviour": true,
+	"describe": true, "description": true, "version": true, "seems": true, "something": true, "anything": true,
+}
+
+// tokenize splits text into lower case words, dropping stop words and words too short to be meaningful.
+func tokenize(text string) []string {
+	words := wordPattern.FindAllString(strings.ToLower(text), -1)
+	tokens := words[:0]
+	for _, word := range words {
+		if len(word) < 3 || similarityStopWords[word] {
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+// stem strips common English suffixes, so that e.g. "crashes", "crashed" and "crashing" are scored as the same term.
+func stem(word string) string {
+	switch {
+	case len(word) > 5 && strings.HasSuffix(word, "ing"):
+		return word[:len(word)-3]
+	case len(word) > 4 && strings.HasSuffix(word, "ies"):
+		return word[:len(word)-3] + "y"
+	case len(word) > 4 && strings.HasSuffix(word, "ed"):
+		return word[:len(word)-2]
+	case len(word) > 4 && strings.HasSuffix(word, "es") && !strings.HasSuffix(word, "ses"):
+		return word[:len(word)-2]
+	case len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		return word[:len(word)-1]
+	}
+	return word
+}
+
+// termFrequencies counts the stemmed terms of a title and body, with terms of the title weighted by titleWeight.
+func termFrequencies(title, body string) (map[string]float64, float64) {
+	tf := make(map[string]float64)
+	var length float64
+	for _, token := range tokenize(title) {
+		tf[stem(token)] += titleWeight
+		length += titleWeight
+	}
+	for _, token := range tokenize(body) {
+		tf[stem(token)]++
+		length++
+	}
+	return tf, length
+}
+
+// searchKeywords picks the words of a draft to search for: the words of the title in order, and the
+// words of the title and body together ranked by how often they occur, with title words weighted up.
+func searchKeywords(title, body string) (titleKeywords, keywords []string) {
+	seen := make(map[string]bool)
+	for _, token := range tokenize(title) {
+		if !seen[token] {
+			seen[token] = true
+			titleKeywords = append(titleKeywords, token)
+		}
+	}
+
+	weights := make(map[string]int)
+	var order []string
+	for _, token := range titleKeywords {
+		weights[token] += titleWeight
+		order = append(order, token)
+	}
+	for _, token := range tokenize(body) {
+		if _, ok := weights[token]; !ok {
+			order = append(order, token)
+		}
+		weights[token]++
+	}
+	keywords = order
+	// Longer words tend to be more specific, so use length to break ties.
+	sort.SliceStable(keywords, func(i, j int) bool {
+		wi, wj := weights[keywords[i]], weights[keywords[j]]
+		if wi != wj {
+			return wi > wj
+		}
+		return len(keywords[i]) > len(keywords[j])
+	})
+	return titleKeywords, keywords
+}
+
+// similarIssueQueries derives the search queries to find issues similar to a draft, from narrow to broad.
+func similarIssueQueries(owner, repo, state string, titleKeywords, keywords []string) []string {
+	qualifiers := fmt.Sprintf("repo:%s/%s is:issue", owner, repo)
+	if state == "open" || state == "closed" {
+		qualifiers += " is:" + state
+	}
+
+	var queries []string
+	add := func(terms []string, suffix string) {
+		if len(terms) == 0 {
+			return
+		}
+		q := qualifiers + " " + strings.Join(terms, " ") + suffix
+		if !slices.Contains(queries, q) {
+			queries = append(queries, q)
+		}
+	}
+	add(titleKeywords[:min(4, len(titleKeywords))], " in:title")
+	add(titleKeywords[:min(2, len(titleKeywords))], "")
+	add(keywords[:min(3, len(keywords))], "")
+	return queries
+}
+
+// SimilarIssue is an existing issue found to be similar to a draft issue.
+type SimilarIssue struct {
+	Number       int      `json:"number"`
+	Title        string   `json:"title"`
+	State        string   `json:"state"`
+	StateReason  string   `json:"state_reason,omitempty"`
+	HTMLURL      string   `json:"html_url"`
+	Score        float64  `json:"score"`
+	MatchedTerms []string `json:"matched_terms"`
+	Reasons      []string `json:"reasons"`
+}
+
+type similarityCandidate struct {
+	issue  *github.Issue
+	tf     map[string]float64
+	length float64
+	hits   int
+}
+
+// rankSimilarIssues scores the candidates against the draft with BM25, using the candidates themselves as the
+// corpus for document frequencies, and explains every match.
+func rankSimilarIssues(title, body string, candidates []*similarityCandidate, searches int) []SimilarIssue {
+	queryTF, _ := termFrequencies(title, body)
+	titleTerms := make(map[string]bool)
+	for _, token := range tokenize(title) {
+		titleTerms[stem(token)] = true
+	}
+
+	df := make(map[string]int)
+	var totalLength float64
+	for _, c := range candidates {
+		for term := range c.tf {
+			df[term]++
+		}
+		totalLength += c.length
+	}
+	n := float64(len(candidates))
+	avgLength := totalLength / math.Max(n, 1)
+
+	type contribution struct {
+		term  string
+		score float64
+	}
+
+	results := make([]SimilarIssue, 0, len(candidates))
+	for _, c := range candidates {
+		var score float64
+		var contributions []contribution
+		for term, weight := range queryTF {
+			tf := c.tf[term]
+			if tf == 0 {
+				continue
+			}
+			idf := math.Log(1 + (n-float64(df[term])+0.5)/(float64(df[term])+0.5))
+			s := weight * idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*c.length/math.Max(avgLength, 1)))
+			score += s
+			contributions = append(contributions, contribution{term, s})
+		}
+		if score == 0 {
+			continue
+		}
+		sort.Slice(contributions, func(i, j int) bool {
+			if contributions[i].score != contributions[j].score {
+				return contributions[i].score > contributions[j].score
+			}
+			return contributions[i].term < contributions[j].term
+		})
+
+		matched := make([]string, 0, len(contributions))
+		for _, contribution := range contributions {
+			matched = append(matched, contribution.term)
+		}
+
+		var sharedTitleTerms []string
+		for _, token := range tokenize(c.issue.GetTitle()) {
+			term := stem(token)
+			if titleTerms[term] && !slices.Contains(sharedTitleTerms, term) {
+				sharedTitleTerms = append(sharedTitleTerms, term)
+			}
+		}
+
+		var reasons []string
+		if len(sharedTitleTerms) > 0 {
+			reasons = append(reasons, fmt.Sprintf("title shares %d of %d title terms: %s", len(sharedTitleTerms), len(titleTerms), strings.Join(sharedTitleTerms, ", ")))
+		}
+		reasons = append(reasons, fmt.Sprintf("strongest matching terms: %s", strings.Join(matched[:min(5, len(matched))], ", ")))
+		reasons = append(reasons, fmt.Sprintf("found by %d of %d searches", c.hits, searches))
+		if c.issue.GetState() == "closed" {
+			if reason := c.issue.GetStateReason(); reason != "" {
+				reasons = append(reasons, fmt.Sprintf("closed as %s", strings.ReplaceAll(reason, "_", " ")))
+			} else {
+				reasons = append(reasons, "closed")
+			}
+		}
+
+		results = append(results, SimilarIssue{
+			Number:       c.issue.GetNumber(),
+			Title:        c.issue.GetTitle(),
+			State:        c.issue.GetState(),
+			StateReason:  c.issue.GetStateReason(),
+			HTMLURL:      c.issue.GetHTMLURL(),
+			Score:        math.Round(score*100) / 100,
+			MatchedTerms: matched,
+			Reasons:      reasons,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Number > results[j].Number
+	})
+	return results
+}
+
+// FindSimilarIssues creates a tool to find existing issues that are similar to a draft issue.
+func FindSimilarIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("find_similar_issues",
+			mcp.WithDescription(t("TOOL_FIND_SIMILAR_ISSUES_DESCRIPTION", "Find existing issues in a GitHub repository that are similar to a draft issue, to check for duplicates before creating an issue. Searches the repository with keywords taken from the draft and ranks the results by text similarity, explaining every match.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FIND_SIMILAR_ISSUES_USER_TITLE", "Find similar issues"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Title of the draft issue"),
+			),
+			mcp.WithString("body",
+				mcp.Description("Body of the draft issue"),
+			),
+			mcp.WithString("state",
+				mcp.Description("Only consider issues in this state, defaults to all"),
+				mcp.Enum("open", "closed", "all"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Maximum number of similar issues to return (min 1, max 30, default 10)"),
+				mcp.Min(1),
+				mcp.Max(30),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := requiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := OptionalParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limit, err := OptionalIntParamWithDefault(request, "limit", 10)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if limit < 1 || limit > 30 {
+				return mcp.NewToolResultError("limit must be between 1 and 30"), nil
+			}
+
+			titleKeywords, keywords := searchKeywords(title, body)
+			if len(keywords) == 0 {
+				return mcp.NewToolResultError("could not derive any search keywords from the title and body"), nil
+			}
+			queries := similarIssueQueries(owner, repo, state, titleKeywords, keywords)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			progress := newProgressReporter(ctx, request, len(queries))
+
+			var candidates []*similarityCandidate
+			byNumber := make(map[int]*similarityCandidate)
+			for i, query := range queries {
+				result, resp, err := client.Search.Issues(ctx, query, &github.SearchOptions{
+					ListOptions: github.ListOptions{PerPage: similarIssuesPerSearch},
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to search issues: %w", err)
+				}
+				if resp.StatusCode != http.StatusOK {
+					body, err := io.ReadAll(resp.Body)
+					_ = resp.Body.Close()
+					if err != nil {
+						return nil, fmt.Errorf("failed to read response body: %w", err)
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to search issues: %s", string(body))), nil
+				}
+				_ = resp.Body.Close()
+
+				for _, issue := range result.Issues {
+					if issue.IsPullRequest() {
+						continue
+					}
+					if c, ok := byNumber[issue.GetNumber()]; ok {
+						c.hits++
+						continue
+					}
+					tf, length := termFrequencies(issue.GetTitle(), issue.GetBody())
+					c := &similarityCandidate{issue: issue, tf: tf, length: length, hits: 1}
+					byNumber[issue.GetNumber()] = c
+					candidates = append(candidates, c)
+				}
+				progress.Step(fmt.Sprintf("Ran search %d of %d", i+1, len(queries)))
+			}
+
+			similar := rankSimilarIssues(title, body, candidates, len(queries))
+			if len(similar) > limit {
+				similar = similar[:limit]
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"queries":        queries,
+				"similar_issues": similar,
+			}), nil
+		}
+}
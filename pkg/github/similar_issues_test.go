@@ -0,0 +1,148 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SimilarIssueQueries(t *testing.T) {
+	titleKeywords, keywords := searchKeywords(
+		"Server crashes when the config file is missing",
+		"Starting the server without a config file crashes with a nil pointer panic. The config loader should fall back to defaults.",
+	)
+	assert.Equal(t, []string{"server", "crashes", "config", "file", "missing"}, titleKeywords)
+	assert.Equal(t, []string{"config", "crashes", "server", "file", "missing"}, keywords[:5])
+
+	assert.Equal(t, []string{
+		"repo:owner/repo is:issue server crashes config file in:title",
+		"repo:owner/repo is:issue server crashes",
+		"repo:owner/repo is:issue config crashes server",
+	}, similarIssueQueries("owner", "repo", "all", titleKeywords, keywords))
+
+	assert.Equal(t, []string{
+		"repo:owner/repo is:issue is:open panic in:title",
+		"repo:owner/repo is:issue is:open panic",
+	}, similarIssueQueries("owner", "repo", "open", []string{"panic"}, []string{"panic"}))
+}
+
+func Test_FindSimilarIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := FindSimilarIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "find_similar_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "title")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "limit")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "title"})
+
+	duplicate := &github.Issue{
+		Number:      github.Ptr(10),
+		Title:       github.Ptr("Crash on startup when config file is missing"),
+		Body:        github.Ptr("The server panics with a nil pointer when there is no config file."),
+		State:       github.Ptr("closed"),
+		StateReason: github.Ptr("completed"),
+		HTMLURL:     github.Ptr("https://github.com/owner/repo/issues/10"),
+	}
+	related := &github.Issue{
+		Number:  github.Ptr(20),
+		Title:   github.Ptr("Document config file options"),
+		Body:    github.Ptr("The docs do not list all the options of the config file."),
+		State:   github.Ptr("open"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/issues/20"),
+	}
+	unrelated := &github.Issue{
+		Number:  github.Ptr(30),
+		Title:   github.Ptr("Add dark mode"),
+		Body:    github.Ptr("It would be nice to have a dark theme."),
+		State:   github.Ptr("open"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/issues/30"),
+	}
+	pullRequest := &github.Issue{
+		Number:           github.Ptr(40),
+		Title:            github.Ptr("Fix crash when config file is missing"),
+		State:            github.Ptr("open"),
+		PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/40")},
+	}
+
+	var queries []string
+	searchHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		queries = append(queries, q)
+		issues := []*github.Issue{duplicate, pullRequest}
+		if q == "repo:owner/repo is:issue config crashes server" {
+			issues = []*github.Issue{duplicate, related, unrelated}
+		}
+		mockResponse(t, http.StatusOK, &github.IssuesSearchResult{
+			Total:  github.Ptr(len(issues)),
+			Issues: issues,
+		})(w, r)
+	})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(mock.GetSearchIssues, searchHandler),
+	))
+	_, handler := FindSimilarIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner": "owner",
+		"repo":  "repo",
+		"title": "Server crashes when the config file is missing",
+		"body":  "Starting the server without a config file crashes with a nil pointer panic. The config loader should fall back to defaults.",
+		"limit": float64(2),
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var response struct {
+		Queries       []string       `json:"queries"`
+		SimilarIssues []SimilarIssue `json:"similar_issues"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+	assert.Equal(t, queries, response.Queries)
+	assert.Len(t, response.Queries, 3)
+
+	// The pull request is skipped, the unrelated issue does not share any terms, and the limit cuts the rest.
+	require.Len(t, response.SimilarIssues, 2)
+	top := response.SimilarIssues[0]
+	assert.Equal(t, 10, top.Number)
+	assert.Equal(t, "closed", top.State)
+	assert.Equal(t, "completed", top.StateReason)
+	assert.Contains(t, top.MatchedTerms, "crash")
+	assert.Contains(t, top.MatchedTerms, "config")
+	assert.Contains(t, top.Reasons, "title shares 4 of 5 title terms: crash, config, file, miss")
+	assert.Contains(t, top.Reasons, "found by 3 of 3 searches")
+	assert.Contains(t, top.Reasons, "closed as completed")
+
+	assert.Equal(t, 20, response.SimilarIssues[1].Number)
+	assert.Less(t, response.SimilarIssues[1].Score, top.Score)
+	assert.Contains(t, response.SimilarIssues[1].Reasons, "found by 1 of 3 searches")
+}
+
+func Test_FindSimilarIssuesWithoutKeywords(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient())
+	_, handler := FindSimilarIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner": "owner",
+		"repo":  "repo",
+		"title": "it is not ok",
+	}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Equal(t, "could not derive any search keywords from the title and body", getTextResult(t, result).Text)
+}
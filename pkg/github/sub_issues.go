@@ -0,0 +1,668 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+const (
+	// maxIssueHierarchyDepth bounds how many levels of sub-issues get_issue_hierarchy expands,
+	// and how many parents it follows upwards.
+	maxIssueHierarchyDepth = 5
+	// maxIssueHierarchyIssues bounds the number of issues get_issue_hierarchy expands, since every
+	// expanded issue costs a query.
+	maxIssueHierarchyIssues = 100
+)
+
+// SubIssue is a simplified view of an issue that is the sub-issue of another issue.
+type SubIssue struct {
+	ID        int64    `json:"id"`
+	Number    int      `json:"number"`
+	Title     string   `json:"title"`
+	State     string   `json:"state"`
+	HTMLURL   string   `json:"html_url"`
+	Assignees []string `json:"assignees,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+}
+
+func newSubIssue(issue *github.Issue) SubIssue {
+	subIssue := SubIssue{
+		ID:      issue.GetID(),
+		Number:  issue.GetNumber(),
+		Title:   issue.GetTitle(),
+		State:   issue.GetState(),
+		HTMLURL: issue.GetHTMLURL(),
+	}
+	for _, assignee := range issue.Assignees {
+		subIssue.Assignees = append(subIssue.Assignees, assignee.GetLogin())
+	}
+	for _, label := range issue.Labels {
+		subIssue.Labels = append(subIssue.Labels, label.GetName())
+	}
+	return subIssue
+}
+
+// subIssueRequest is the body of the sub-issue write endpoints, which identify issues by ID rather than by number.
+type subIssueRequest struct {
+	SubIssueID    int64  `json:"sub_issue_id"`
+	ReplaceParent *bool  `json:"replace_parent,omitempty"`
+	AfterID       *int64 `json:"after_id,omitempty"`
+	BeforeID      *int64 `json:"before_id,omitempty"`
+}
+
+// getIssueID resolves an issue number to the issue ID expected by the sub-issue endpoints.
+func getIssueID(ctx context.Context, client *github.Client, owner, repo string, number int) (int64, error) {
+	issue, resp, err := client.Issues.Get(ctx, owner, repo, number)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get issue #%d: %w", number, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return issue.GetID(), nil
+}
+
+// ListSubIssues creates a tool to list the sub-issues of an issue.
+func ListSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_sub_issues",
+			mcp.WithDescription(t("TOOL_LIST_SUB_ISSUES_DESCRIPTION", "List the sub-issues of an issue in a GitHub repository, in priority order.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_SUB_ISSUES_USER_TITLE", "List sub-issues"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the parent issue"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			u := fmt.Sprintf("repos/%s/%s/issues/%d/sub_issues?page=%d&per_page=%d", owner, repo, issueNumber, pagination.page, pagination.perPage)
+			req, err := client.NewRequest(http.MethodGet, u, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var issues []*github.Issue
+			resp, err := client.Do(ctx, req, &issues)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list sub-issues: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			reportNextPage(ctx, resp)
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list sub-issues: %s", string(body))), nil
+			}
+
+			subIssues := make([]SubIssue, 0, len(issues))
+			for _, issue := range issues {
+				subIssues = append(subIssues, newSubIssue(issue))
+			}
+
+			r, err := json.Marshal(subIssues)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal sub-issues: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// AddSubIssue creates a tool to add an issue as a sub-issue of another issue.
+func AddSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_sub_issue",
+			mcp.WithDescription(t("TOOL_ADD_SUB_ISSUE_DESCRIPTION", "Add an existing issue as a sub-issue of another issue. The sub-issue is added at the end of the list; use reprioritize_sub_issue to move it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_SUB_ISSUE_USER_TITLE", "Add sub-issue"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the parent issue"),
+			),
+			mcp.WithNumber("sub_issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the issue to add as a sub-issue"),
+			),
+			mcp.WithString("sub_issue_repo",
+				mcp.Description("Repository of the sub-issue, if it is not in the same repository as the parent. It must have the same owner"),
+			),
+			mcp.WithBoolean("replace_parent",
+				mcp.Description("Move the sub-issue from its current parent, if it already has one"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subIssueNumber, err := RequiredInt(request, "sub_issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subIssueRepo, err := OptionalParam[string](request, "sub_issue_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if subIssueRepo == "" {
+				subIssueRepo = repo
+			}
+			replaceParent, ok, err := OptionalParamOK[bool](request, "replace_parent")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			subIssueID, err := getIssueID(ctx, client, owner, subIssueRepo, subIssueNumber)
+			if err != nil {
+				return nil, err
+			}
+
+			body := subIssueRequest{SubIssueID: subIssueID}
+			if ok {
+				body.ReplaceParent = github.Ptr(replaceParent)
+			}
+
+			return doSubIssueWrite(ctx, client, http.MethodPost, fmt.Sprintf("repos/%s/%s/issues/%d/sub_issues", owner, repo, issueNumber), body, http.StatusCreated, "failed to add sub-issue")
+		}
+}
+
+// RemoveSubIssue creates a tool to remove a sub-issue from its parent issue.
+func RemoveSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_sub_issue",
+			mcp.WithDescription(t("TOOL_REMOVE_SUB_ISSUE_DESCRIPTION", "Remove a sub-issue from its parent issue. The sub-issue itself is not closed or deleted.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REMOVE_SUB_ISSUE_USER_TITLE", "Remove sub-issue"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the parent issue"),
+			),
+			mcp.WithNumber("sub_issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the sub-issue to remove"),
+			),
+			mcp.WithString("sub_issue_repo",
+				mcp.Description("Repository of the sub-issue, if it is not in the same repository as the parent"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subIssueNumber, err := RequiredInt(request, "sub_issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subIssueRepo, err := OptionalParam[string](request, "sub_issue_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if subIssueRepo == "" {
+				subIssueRepo = repo
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			subIssueID, err := getIssueID(ctx, client, owner, subIssueRepo, subIssueNumber)
+			if err != nil {
+				return nil, err
+			}
+
+			return doSubIssueWrite(ctx, client, http.MethodDelete, fmt.Sprintf("repos/%s/%s/issues/%d/sub_issue", owner, repo, issueNumber), subIssueRequest{SubIssueID: subIssueID}, http.StatusOK, "failed to remove sub-issue")
+		}
+}
+
+// ReprioritizeSubIssue creates a tool to change the position of a sub-issue in its parent's list of sub-issues.
+func ReprioritizeSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("reprioritize_sub_issue",
+			mcp.WithDescription(t("TOOL_REPRIORITIZE_SUB_ISSUE_DESCRIPTION", "Move a sub-issue to a different position in its parent's list of sub-issues, directly after or before another sub-issue of the same parent.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_REPRIORITIZE_SUB_ISSUE_USER_TITLE", "Reprioritize sub-issue"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the parent issue"),
+			),
+			mcp.WithNumber("sub_issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the sub-issue to move"),
+			),
+			mcp.WithNumber("after_issue_number",
+				mcp.Description("Number of the sub-issue to place it after. Exactly one of after_issue_number and before_issue_number must be set"),
+			),
+			mcp.WithNumber("before_issue_number",
+				mcp.Description("Number of the sub-issue to place it before. Exactly one of after_issue_number and before_issue_number must be set"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subIssueNumber, err := RequiredInt(request, "sub_issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			afterNumber, err := OptionalIntParam(request, "after_issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			beforeNumber, err := OptionalIntParam(request, "before_issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (afterNumber == 0) == (beforeNumber == 0) {
+				return mcp.NewToolResultError("exactly one of after_issue_number and before_issue_number must be set"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Sub-issues can live in other repositories of the same owner, so resolve the IDs from the
+			// parent's own list rather than assuming they are in this repository.
+			u := fmt.Sprintf("repos/%s/%s/issues/%d/sub_issues?per_page=100", owner, repo, issueNumber)
+			req, err := client.NewRequest(http.MethodGet, u, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var issues []*github.Issue
+			resp, err := client.Do(ctx, req, &issues)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list sub-issues: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			ids := make(map[int]int64, len(issues))
+			for _, issue := range issues {
+				ids[issue.GetNumber()] = issue.GetID()
+			}
+			lookup := func(number int) (*int64, error) {
+				id, ok := ids[number]
+				if !ok {
+					return nil, fmt.Errorf("issue #%d is not a sub-issue of #%d", number, issueNumber)
+				}
+				return github.Ptr(id), nil
+			}
+
+			subIssueID, err := lookup(subIssueNumber)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body := subIssueRequest{SubIssueID: *subIssueID}
+			if afterNumber != 0 {
+				body.AfterID, err = lookup(afterNumber)
+			} else {
+				body.BeforeID, err = lookup(beforeNumber)
+			}
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return doSubIssueWrite(ctx, client, http.MethodPatch, fmt.Sprintf("repos/%s/%s/issues/%d/sub_issues/priority", owner, repo, issueNumber), body, http.StatusOK, "failed to reprioritize sub-issue")
+		}
+}
+
+// doSubIssueWrite sends a request to one of the sub-issue write endpoints, all of which respond with the parent issue.
+func doSubIssueWrite(ctx context.Context, client *github.Client, method, u string, body subIssueRequest, expectedStatus int, errPrefix string) (*mcp.CallToolResult, error) {
+	req, err := client.NewRequest(method, u, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	parent := new(github.Issue)
+	resp, err := client.Do(ctx, req, parent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errPrefix, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != expectedStatus {
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("%s: %s", errPrefix, string(respBody))), nil
+	}
+
+	r, err := json.Marshal(newSubIssue(parent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal issue: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(r)), nil
+}
+
+// issueHierarchyFields are the fields of an issue that get_issue_hierarchy reports, including
+// where to find it, since sub-issues and parents can be in other repositories.
+type issueHierarchyFields struct {
+	Number     githubv4.Int
+	Title      githubv4.String
+	State      githubv4.String
+	URL        githubv4.String
+	Repository struct {
+		Name  githubv4.String
+		Owner struct {
+			Login githubv4.String
+		}
+	}
+	SubIssuesSummary struct {
+		Total     githubv4.Int
+		Completed githubv4.Int
+	}
+}
+
+type issueParentQuery struct {
+	Repository struct {
+		Issue struct {
+			issueHierarchyFields
+			Parent *issueHierarchyFields
+		} `graphql:"issue(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+type subIssuesQuery struct {
+	Repository struct {
+		Issue struct {
+			SubIssues struct {
+				TotalCount githubv4.Int
+				Nodes      []issueHierarchyFields
+			} `graphql:"subIssues(first: 100)"`
+		} `graphql:"issue(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// IssueHierarchyNode is an issue in a tree of sub-issues.
+type IssueHierarchyNode struct {
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	State      string `json:"state"`
+	URL        string `json:"url"`
+	Repository string `json:"repository"`
+	// Total and Completed count every issue below this one in the tree, closed issues being completed.
+	Total            int                   `json:"total"`
+	Completed        int                   `json:"completed"`
+	PercentCompleted int                   `json:"percent_completed"`
+	SubIssues        []*IssueHierarchyNode `json:"sub_issues,omitempty"`
+	// Truncated is set when not all sub-issues of this issue were expanded. The counts of an issue
+	// whose sub-issues were not expanded at all come from GitHub's summary of its direct sub-issues.
+	Truncated bool `json:"truncated,omitempty"`
+
+	owner, repo string
+	summary     struct{ total, completed int }
+}
+
+func newIssueHierarchyNode(fields issueHierarchyFields) *IssueHierarchyNode {
+	node := &IssueHierarchyNode{
+		Number:     int(fields.Number),
+		Title:      string(fields.Title),
+		State:      strings.ToLower(string(fields.State)),
+		URL:        string(fields.URL),
+		Repository: fmt.Sprintf("%s/%s", fields.Repository.Owner.Login, fields.Repository.Name),
+		owner:      string(fields.Repository.Owner.Login),
+		repo:       string(fields.Repository.Name),
+	}
+	node.summary.total = int(fields.SubIssuesSummary.Total)
+	node.summary.completed = int(fields.SubIssuesSummary.Completed)
+	return node
+}
+
+// rollUp computes the completion counts of a node from its expanded sub-issues, falling back to
+// GitHub's summary of the direct sub-issues for nodes that were not expanded.
+func (n *IssueHierarchyNode) rollUp() {
+	if n.Truncated && len(n.SubIssues) == 0 {
+		n.Total, n.Completed = n.summary.total, n.summary.completed
+	} else {
+		n.Total, n.Completed = 0, 0
+		for _, child := range n.SubIssues {
+			child.rollUp()
+			n.Total += 1 + child.Total
+			n.Completed += child.Completed
+			if child.State == "closed" {
+				n.Completed++
+			}
+		}
+	}
+	if n.Total > 0 {
+		n.PercentCompleted = n.Completed * 100 / n.Total
+	}
+}
+
+// GetIssueHierarchy creates a tool to get the parents and the tree of sub-issues of an issue.
+func GetIssueHierarchy(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_issue_hierarchy",
+			mcp.WithDescription(t("TOOL_GET_ISSUE_HIERARCHY_DESCRIPTION", "Get where an issue sits in its hierarchy: its chain of parent issues up to the top-level issue, and its tree of sub-issues with completion progress rolled up at every level. Use this to plan work from an epic or to find the epic an issue belongs to.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ISSUE_HIERARCHY_USER_TITLE", "Get issue hierarchy"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number"),
+			),
+			mcp.WithNumber("depth",
+				mcp.Description(fmt.Sprintf("How many levels of sub-issues to expand (default 2, max %d)", maxIssueHierarchyDepth)),
+				mcp.Min(1),
+				mcp.Max(maxIssueHierarchyDepth),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			depth, err := OptionalIntParamWithDefault(request, "depth", 2)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if depth < 1 || depth > maxIssueHierarchyDepth {
+				return mcp.NewToolResultError(fmt.Sprintf("depth must be between 1 and %d", maxIssueHierarchyDepth)), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			// How deep the hierarchy goes isn't known up front, so there is no total
+			progress := newProgressReporter(ctx, request, 0)
+
+			// Walk up the parents, closest first.
+			var root *IssueHierarchyNode
+			ancestors := []*IssueHierarchyNode{}
+			current := struct {
+				owner, repo string
+				number      int
+			}{owner, repo, issueNumber}
+			for i := 0; i <= maxIssueHierarchyDepth; i++ {
+				var query issueParentQuery
+				variables := map[string]any{
+					"owner":  githubv4.String(current.owner),
+					"name":   githubv4.String(current.repo),
+					"number": githubv4.Int(int32(current.number)), // #nosec G115 - issue numbers are always small positive integers
+				}
+				if err := client.Query(ctx, &query, variables); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get issue hierarchy: %v", err)), nil
+				}
+				if root == nil {
+					root = newIssueHierarchyNode(query.Repository.Issue.issueHierarchyFields)
+				}
+				parent := query.Repository.Issue.Parent
+				if parent == nil {
+					break
+				}
+				node := newIssueHierarchyNode(*parent)
+				ancestors = append(ancestors, node)
+				progress.Step(fmt.Sprintf("Found parent %s#%d", node.Repository, node.Number))
+				current.owner, current.repo, current.number = node.owner, node.repo, node.Number
+			}
+
+			// Expand the sub-issues breadth first, so that hitting the limit leaves the deepest levels unexpanded.
+			type pending struct {
+				node  *IssueHierarchyNode
+				level int
+			}
+			queue := []pending{{root, 1}}
+			expanded := 0
+			for len(queue) > 0 {
+				p := queue[0]
+				queue = queue[1:]
+				if p.node.summary.total == 0 {
+					continue
+				}
+				if p.level > depth || expanded >= maxIssueHierarchyIssues {
+					p.node.Truncated = true
+					continue
+				}
+				expanded++
+
+				var query subIssuesQuery
+				variables := map[string]any{
+					"owner":  githubv4.String(p.node.owner),
+					"name":   githubv4.String(p.node.repo),
+					"number": githubv4.Int(int32(p.node.Number)), // #nosec G115 - issue numbers are always small positive integers
+				}
+				if err := client.Query(ctx, &query, variables); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get sub-issues of %s#%d: %v", p.node.Repository, p.node.Number, err)), nil
+				}
+				subIssues := query.Repository.Issue.SubIssues
+				p.node.Truncated = len(subIssues.Nodes) < int(subIssues.TotalCount)
+				progress.Step(fmt.Sprintf("Expanded the sub-issues of %s#%d", p.node.Repository, p.node.Number))
+				for _, fields := range subIssues.Nodes {
+					child := newIssueHierarchyNode(fields)
+					p.node.SubIssues = append(p.node.SubIssues, child)
+					queue = append(queue, pending{child, p.level + 1})
+				}
+			}
+			root.rollUp()
+
+			r, err := json.Marshal(map[string]any{
+				"issue":     root,
+				"ancestors": ancestors,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal issue hierarchy: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,515 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func subIssueFixture(id int64, number int, state string) *github.Issue {
+	return &github.Issue{
+		ID:      github.Ptr(id),
+		Number:  github.Ptr(number),
+		Title:   github.Ptr("Task"),
+		State:   github.Ptr(state),
+		HTMLURL: github.Ptr(fmt.Sprintf("https://github.com/owner/repo/issues/%d", number)),
+	}
+}
+
+func Test_ListSubIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListSubIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_sub_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	subIssues := []*github.Issue{
+		subIssueFixture(1001, 2, "open"),
+		subIssueFixture(1002, 3, "closed"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedIssues []int
+	}{
+		{
+			name: "successful listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					expect(t, expectations{
+						path:        "/repos/owner/repo/issues/1/sub_issues",
+						queryParams: map[string]string{"page": "2", "per_page": "10"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, subIssues),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(1),
+				"page":         float64(2),
+				"perPage":      float64(10),
+			},
+			expectedIssues: []int{2, 3},
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list sub-issues",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListSubIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returned []SubIssue
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+
+			numbers := make([]int, 0, len(returned))
+			for _, issue := range returned {
+				numbers = append(numbers, issue.Number)
+			}
+			assert.Equal(t, tc.expectedIssues, numbers)
+			assert.Equal(t, "closed", returned[1].State)
+		})
+	}
+}
+
+func Test_AddSubIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddSubIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_sub_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "sub_issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "sub_issue_repo")
+	assert.Contains(t, tool.InputSchema.Properties, "replace_parent")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "sub_issue_number"})
+
+	parent := subIssueFixture(1000, 1, "open")
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "add a sub-issue from another repository, replacing its parent",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/other-repo/issues/5").andThen(
+						mockResponse(t, http.StatusOK, subIssueFixture(1005, 5, "open")),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					expect(t, expectations{
+						path: "/repos/owner/repo/issues/1/sub_issues",
+						requestBody: map[string]any{
+							"sub_issue_id":   float64(1005),
+							"replace_parent": true,
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, parent),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"issue_number":     float64(1),
+				"sub_issue_number": float64(5),
+				"sub_issue_repo":   "other-repo",
+				"replace_parent":   true,
+			},
+		},
+		{
+			name: "sub-issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"issue_number":     float64(1),
+				"sub_issue_number": float64(404),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get issue #404",
+		},
+		{
+			name: "already has a parent",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					subIssueFixture(1005, 5, "open"),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"issue_number":     float64(1),
+				"sub_issue_number": float64(5),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add sub-issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddSubIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returned SubIssue
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, 1, returned.Number)
+		})
+	}
+}
+
+func Test_RemoveSubIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveSubIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "remove_sub_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "sub_issue_number"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+			subIssueFixture(1002, 2, "open"),
+		),
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposIssuesSubIssueByOwnerByRepoByIssueNumber,
+			expect(t, expectations{
+				path:        "/repos/owner/repo/issues/1/sub_issue",
+				requestBody: map[string]any{"sub_issue_id": float64(1002)},
+			}).andThen(
+				mockResponse(t, http.StatusOK, subIssueFixture(1000, 1, "open")),
+			),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := RemoveSubIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	request := createMCPRequest(map[string]interface{}{
+		"owner":            "owner",
+		"repo":             "repo",
+		"issue_number":     float64(1),
+		"sub_issue_number": float64(2),
+	})
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+
+	var returned SubIssue
+	err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returned)
+	require.NoError(t, err)
+	assert.Equal(t, 1, returned.Number)
+}
+
+func Test_ReprioritizeSubIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReprioritizeSubIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "reprioritize_sub_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "after_issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "before_issue_number")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "sub_issue_number"})
+
+	subIssues := []*github.Issue{
+		subIssueFixture(1002, 2, "open"),
+		subIssueFixture(1003, 3, "open"),
+		subIssueFixture(1004, 4, "open"),
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectToolError    bool
+		expectedToolErrMsg string
+	}{
+		{
+			name: "move before another sub-issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					subIssues,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesSubIssuesPriorityByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"sub_issue_id": float64(1004),
+						"before_id":    float64(1002),
+					}).andThen(
+						mockResponse(t, http.StatusOK, subIssueFixture(1000, 1, "open")),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":               "owner",
+				"repo":                "repo",
+				"issue_number":        float64(1),
+				"sub_issue_number":    float64(4),
+				"before_issue_number": float64(2),
+			},
+		},
+		{
+			name:         "both after and before",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":               "owner",
+				"repo":                "repo",
+				"issue_number":        float64(1),
+				"sub_issue_number":    float64(4),
+				"after_issue_number":  float64(2),
+				"before_issue_number": float64(3),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "exactly one of after_issue_number and before_issue_number must be set",
+		},
+		{
+			name: "not a sub-issue of the parent",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					subIssues,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":              "owner",
+				"repo":               "repo",
+				"issue_number":       float64(1),
+				"sub_issue_number":   float64(4),
+				"after_issue_number": float64(9),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "issue #9 is not a sub-issue of #1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReprioritizeSubIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			var returned SubIssue
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, 1, returned.Number)
+		})
+	}
+}
+
+func Test_GetIssueHierarchy(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := GetIssueHierarchy(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_issue_hierarchy", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "depth")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	hierarchyIssue := func(number int, state string, total, completed int) map[string]any {
+		return map[string]any{
+			"number": number,
+			"title":  "Issue",
+			"state":  state,
+			"url":    "https://github.com/owner/repo/issues/1",
+			"repository": map[string]any{
+				"name":  "repo",
+				"owner": map[string]any{"login": "owner"},
+			},
+			"subIssuesSummary": map[string]any{"total": total, "completed": completed},
+		}
+	}
+	issueVariables := func(number int) map[string]any {
+		return map[string]any{
+			"owner":  githubv4.String("owner"),
+			"name":   githubv4.String("repo"),
+			"number": githubv4.Int(number),
+		}
+	}
+	withParent := func(issue map[string]any, parent any) githubv4mock.GQLResponse {
+		issue["parent"] = parent
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"issue": issue},
+		})
+	}
+	withSubIssues := func(nodes ...any) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"issue": map[string]any{
+					"subIssues": map[string]any{"totalCount": len(nodes), "nodes": nodes},
+				},
+			},
+		})
+	}
+
+	// An epic #1 with a feature #2, which has a closed task #3 and an open task #4 whose own sub-issue #5 is closed.
+	matchers := []githubv4mock.Matcher{
+		githubv4mock.NewQueryMatcher(issueParentQuery{}, issueVariables(2), withParent(hierarchyIssue(2, "OPEN", 2, 1), hierarchyIssue(1, "OPEN", 1, 0))),
+		githubv4mock.NewQueryMatcher(issueParentQuery{}, issueVariables(1), withParent(hierarchyIssue(1, "OPEN", 1, 0), nil)),
+		githubv4mock.NewQueryMatcher(subIssuesQuery{}, issueVariables(2), withSubIssues(hierarchyIssue(3, "CLOSED", 0, 0), hierarchyIssue(4, "OPEN", 1, 1))),
+		githubv4mock.NewQueryMatcher(subIssuesQuery{}, issueVariables(4), withSubIssues(hierarchyIssue(5, "CLOSED", 0, 0))),
+	}
+
+	type hierarchyResult struct {
+		Issue     IssueHierarchyNode   `json:"issue"`
+		Ancestors []IssueHierarchyNode `json:"ancestors"`
+	}
+
+	tests := []struct {
+		name              string
+		depth             float64
+		expectedTotal     int
+		expectedCompleted int
+		expectedTruncated bool
+	}{
+		{
+			name:              "expand every level",
+			depth:             2,
+			expectedTotal:     3,
+			expectedCompleted: 2,
+		},
+		{
+			name:              "stop at the direct sub-issues",
+			depth:             1,
+			expectedTotal:     3,
+			expectedCompleted: 2,
+			expectedTruncated: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matchers...))
+			_, handler := GetIssueHierarchy(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(2),
+				"depth":        tc.depth,
+			})
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned hierarchyResult
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+
+			require.Len(t, returned.Ancestors, 1)
+			assert.Equal(t, 1, returned.Ancestors[0].Number)
+
+			assert.Equal(t, 2, returned.Issue.Number)
+			assert.Equal(t, tc.expectedTotal, returned.Issue.Total)
+			assert.Equal(t, tc.expectedCompleted, returned.Issue.Completed)
+			require.Len(t, returned.Issue.SubIssues, 2)
+			assert.Equal(t, "closed", returned.Issue.SubIssues[0].State)
+
+			task := returned.Issue.SubIssues[1]
+			assert.Equal(t, 4, task.Number)
+			assert.Equal(t, tc.expectedTruncated, task.Truncated)
+			assert.Equal(t, 1, task.Total)
+			assert.Equal(t, 1, task.Completed)
+			assert.Equal(t, 100, task.PercentCompleted)
+		})
+	}
+}
@@ -31,6 +31,21 @@ func InitToolsets(passedToolsets []string, readOnly bool, getClient GetClientFn,
 			toolsets.NewServerTool(ListBranches(getClient, t)),
 			toolsets.NewServerTool(ListTags(getClient, t)),
 			toolsets.NewServerTool(GetTag(getClient, t)),
+			toolsets.NewServerTool(CompareRefs(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryTree(getClient, t)),
+			toolsets.NewServerTool(GetFileBlame(getGQLClient, t)),
+			toolsets.NewServerTool(GetFileHistory(getClient, t)),
+			toolsets.NewServerTool(ListCommitPullRequests(getClient, t)),
+			toolsets.NewServerTool(GetBranchProtection(getClient, t)),
+			toolsets.NewServerTool(GetRequiredStatusChecks(getClient, t)),
+			toolsets.NewServerTool(GetRulesForBranch(getClient, t)),
+			toolsets.NewServerTool(ListRepositoryRulesets(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryRuleset(getClient, t)),
+			toolsets.NewServerTool(GetRepository(getClient, t)),
+			toolsets.NewServerTool(ListCollaborators(getClient, t)),
+			toolsets.NewServerTool(GetCollaboratorPermission(getClient, t)),
+			toolsets.NewServerTool(ListRepositoryInvitations(getClient, t)),
+			toolsets.NewServerTool(ListRepositoryTeams(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateOrUpdateFile(getClient, t)),
@@ -39,19 +54,45 @@ func InitToolsets(passedToolsets []string, readOnly bool, getClient GetClientFn,
 			toolsets.NewServerTool(CreateBranch(getClient, t)),
 			toolsets.NewServerTool(PushFiles(getClient, t)),
 			toolsets.NewServerTool(DeleteFile(getClient, t)),
+			toolsets.NewServerTool(UpdateBranchProtection(getClient, t)),
+			toolsets.NewServerTool(UpdateRepositorySettings(getClient, t)),
+			toolsets.NewServerTool(SetRepositoryTopics(getClient, t)),
+			toolsets.NewServerTool(RenameDefaultBranch(getClient, t)),
+			toolsets.NewServerTool(ArchiveRepository(getClient, t)),
+			toolsets.NewServerTool(UnarchiveRepository(getClient, t)),
+			toolsets.NewServerTool(DeleteRepository(getClient, t)),
+			toolsets.NewServerTool(AddCollaborator(getClient, t)),
+			toolsets.NewServerTool(RemoveCollaborator(getClient, t)),
+			toolsets.NewServerTool(SetTeamRepositoryAccess(getClient, t)),
+			toolsets.NewServerTool(RemoveTeamRepositoryAccess(getClient, t)),
 		)
 	issues := toolsets.NewToolset("issues", "GitHub Issues related tools").
 		AddReadTools(
 			toolsets.NewServerTool(GetIssue(getClient, t)),
 			toolsets.NewServerTool(SearchIssues(getClient, t)),
+			toolsets.NewServerTool(FindSimilarIssues(getClient, t)),
 			toolsets.NewServerTool(ListIssues(getClient, t)),
 			toolsets.NewServerTool(GetIssueComments(getClient, t)),
+			toolsets.NewServerTool(GetIssueTimeline(getGQLClient, t)),
+			toolsets.NewServerTool(ListSubIssues(getClient, t)),
+			toolsets.NewServerTool(GetIssueHierarchy(getGQLClient, t)),
+			toolsets.NewServerTool(ListIssueTemplates(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateIssue(getClient, t)),
 			toolsets.NewServerTool(AddIssueComment(getClient, t)),
 			toolsets.NewServerTool(UpdateIssue(getClient, t)),
 			toolsets.NewServerTool(AssignCopilotToIssue(getGQLClient, t)),
+			toolsets.NewServerTool(AddSubIssue(getClient, t)),
+			toolsets.NewServerTool(RemoveSubIssue(getClient, t)),
+			toolsets.NewServerTool(ReprioritizeSubIssue(getClient, t)),
+			toolsets.NewServerTool(TransferIssue(getGQLClient, t)),
+			toolsets.NewServerTool(LockIssue(getClient, t)),
+			toolsets.NewServerTool(UnlockIssue(getClient, t)),
+			toolsets.NewServerTool(PinIssue(getGQLClient, t)),
+			toolsets.NewServerTool(UnpinIssue(getGQLClient, t)),
+			toolsets.NewServerTool(CloseIssueAsDuplicate(getGQLClient, t)),
+			toolsets.NewServerTool(BulkUpdateIssues(getClient, t)),
 		)
 	users := toolsets.NewToolset("users", "GitHub User related tools").
 		AddReadTools(
@@ -66,6 +107,7 @@ func InitToolsets(passedToolsets []string, readOnly bool, getClient GetClientFn,
 			toolsets.NewServerTool(GetPullRequestComments(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestReviews(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestDiff(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestStack(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(MergePullRequest(getClient, t)),
@@ -73,6 +115,7 @@ func InitToolsets(passedToolsets []string, readOnly bool, getClient GetClientFn,
 			toolsets.NewServerTool(CreatePullRequest(getClient, t)),
 			toolsets.NewServerTool(UpdatePullRequest(getClient, t)),
 			toolsets.NewServerTool(RequestCopilotReview(getClient, t)),
+			toolsets.NewServerTool(UpdatePullRequestStack(getClient, t)),
 
 			// Reviews
 			toolsets.NewServerTool(CreateAndSubmitPullRequestReview(getGQLClient, t)),
@@ -104,6 +147,15 @@ func InitToolsets(passedToolsets []string, readOnly bool, getClient GetClientFn,
 			toolsets.NewServerTool(ManageRepositoryNotificationSubscription(getClient, t)),
 		)
 
+	reactions := toolsets.NewToolset("reactions", "GitHub Reactions related tools").
+		AddReadTools(
+			toolsets.NewServerTool(ListReactions(getClient, getGQLClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(AddReaction(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(RemoveReaction(getClient, getGQLClient, t)),
+		)
+
 	// Keep experiments alive so the system doesn't error out when it's always enabled
 	experiments := toolsets.NewToolset("experiments", "Experimental features that are not considered stable yet")
 
@@ -115,7 +167,12 @@ func InitToolsets(passedToolsets []string, readOnly bool, getClient GetClientFn,
 	tsg.AddToolset(codeSecurity)
 	tsg.AddToolset(secretProtection)
 	tsg.AddToolset(notifications)
+	tsg.AddToolset(reactions)
 	tsg.AddToolset(experiments)
+
+	// Prompts are only offered once the toolsets they rely on are enabled
+	tsg.AddPrompts(InitPrompts(t)...)
+
 	// Enable the requested features
 
 	if err := tsg.EnableToolsets(passedToolsets); err != nil {
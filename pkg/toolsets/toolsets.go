@@ -11,6 +11,32 @@ func NewServerTool(tool mcp.Tool, handler server.ToolHandlerFunc) server.ServerT
 	return server.ServerTool{Tool: tool, Handler: handler}
 }
 
+// ServerPrompt is a prompt together with the toolsets whose tools it instructs the model to use.
+type ServerPrompt struct {
+	Prompt           mcp.Prompt
+	Handler          server.PromptHandlerFunc
+	RequiredToolsets []string
+	// WriteTools is whether the prompt instructs the model to use write tools, which are not
+	// registered when the server is read-only.
+	WriteTools bool
+}
+
+func NewServerPrompt(prompt mcp.Prompt, handler server.PromptHandlerFunc) ServerPrompt {
+	return ServerPrompt{Prompt: prompt, Handler: handler}
+}
+
+// Requires returns a copy of the prompt that is only offered when the given toolsets are enabled.
+func (p ServerPrompt) Requires(toolsets ...string) ServerPrompt {
+	p.RequiredToolsets = append(p.RequiredToolsets, toolsets...)
+	return p
+}
+
+// RequiresWriteTools returns a copy of the prompt that is not offered when the server is read-only.
+func (p ServerPrompt) RequiresWriteTools() ServerPrompt {
+	p.WriteTools = true
+	return p
+}
+
 type Toolset struct {
 	Name        string
 	Description string
@@ -81,6 +107,7 @@ func (t *Toolset) AddReadTools(tools ...server.ServerTool) *Toolset {
 
 type ToolsetGroup struct {
 	Toolsets     map[string]*Toolset
+	prompts      []ServerPrompt
 	everythingOn bool
 	readOnly     bool
 }
@@ -162,3 +189,38 @@ func (tg *ToolsetGroup) RegisterTools(s *server.MCPServer) {
 		toolset.RegisterTools(s)
 	}
 }
+
+// AddPrompts adds prompts to the group. A prompt is only offered once all of its required toolsets are enabled,
+// and, if it uses write tools, only when the group is not read-only.
+func (tg *ToolsetGroup) AddPrompts(prompts ...ServerPrompt) {
+	tg.prompts = append(tg.prompts, prompts...)
+}
+
+// GetAvailablePrompts returns the prompts whose required toolsets are all enabled, leaving out those
+// that use write tools when the group is read-only.
+func (tg *ToolsetGroup) GetAvailablePrompts() []ServerPrompt {
+	var available []ServerPrompt
+	for _, prompt := range tg.prompts {
+		if prompt.WriteTools && tg.readOnly {
+			continue
+		}
+		enabled := true
+		for _, name := range prompt.RequiredToolsets {
+			if !tg.IsEnabled(name) {
+				enabled = false
+				break
+			}
+		}
+		if enabled {
+			available = append(available, prompt)
+		}
+	}
+	return available
+}
+
+// RegisterPrompts adds the available prompts to the server.
+func (tg *ToolsetGroup) RegisterPrompts(s *server.MCPServer) {
+	for _, prompt := range tg.GetAvailablePrompts() {
+		s.AddPrompt(prompt.Prompt, prompt.Handler)
+	}
+}
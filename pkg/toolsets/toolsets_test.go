@@ -2,6 +2,8 @@ package toolsets
 
 import (
 	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
 )
 
 func TestNewToolsetGroupIsEmptyWithoutEverythingOn(t *testing.T) {
@@ -222,3 +224,58 @@ func TestIsEnabledWithEverythingOn(t *testing.T) {
 		t.Error("Expected IsEnabled to return true for any toolset when everythingOn is true")
 	}
 }
+
+func TestGetAvailablePrompts(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(NewToolset("issues", "Issues"))
+	tsg.AddToolset(NewToolset("repos", "Repos"))
+
+	tsg.AddPrompts(
+		NewServerPrompt(mcp.NewPrompt("no-requirements"), nil),
+		NewServerPrompt(mcp.NewPrompt("issues-only"), nil).Requires("issues"),
+		NewServerPrompt(mcp.NewPrompt("issues-and-repos"), nil).Requires("issues", "repos"),
+	)
+
+	promptNames := func() []string {
+		var names []string
+		for _, prompt := range tsg.GetAvailablePrompts() {
+			names = append(names, prompt.Prompt.Name)
+		}
+		return names
+	}
+
+	if names := promptNames(); len(names) != 1 || names[0] != "no-requirements" {
+		t.Errorf("Expected only the prompt without requirements, got %v", names)
+	}
+
+	if err := tsg.EnableToolset("issues"); err != nil {
+		t.Fatalf("Expected no error enabling toolset, got: %v", err)
+	}
+	if names := promptNames(); len(names) != 2 || names[1] != "issues-only" {
+		t.Errorf("Expected the issues prompt to become available, got %v", names)
+	}
+
+	if err := tsg.EnableToolset("repos"); err != nil {
+		t.Fatalf("Expected no error enabling toolset, got: %v", err)
+	}
+	if names := promptNames(); len(names) != 3 {
+		t.Errorf("Expected all prompts to be available, got %v", names)
+	}
+}
+
+func TestGetAvailablePromptsReadOnly(t *testing.T) {
+	tsg := NewToolsetGroup(true)
+	tsg.AddToolset(NewToolset("issues", "Issues"))
+	tsg.AddPrompts(
+		NewServerPrompt(mcp.NewPrompt("reads"), nil).Requires("issues"),
+		NewServerPrompt(mcp.NewPrompt("writes"), nil).Requires("issues").RequiresWriteTools(),
+	)
+	if err := tsg.EnableToolset("issues"); err != nil {
+		t.Fatalf("Expected no error enabling toolset, got: %v", err)
+	}
+
+	prompts := tsg.GetAvailablePrompts()
+	if len(prompts) != 1 || prompts[0].Prompt.Name != "reads" {
+		t.Errorf("Expected only the prompt without write tools in read-only mode, got %v", prompts)
+	}
+}